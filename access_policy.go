@@ -18,6 +18,7 @@ type AccessPolicies interface {
 	Create(ctx context.Context, options AccessPolicyCreateOptions) (*AccessPolicy, error)
 	Update(ctx context.Context, accessPolicyID string, options AccessPolicyUpdateOptions) (*AccessPolicy, error)
 	Delete(ctx context.Context, accessPolicyID string) error
+	FindExisting(ctx context.Context, subject AccessPolicySubject, scope AccessPolicyScope) (*AccessPolicy, error)
 }
 
 // accessPolicies implements AccessPolicies.
@@ -44,6 +45,39 @@ type AccessPolicy struct {
 	Workspace      *Workspace      `jsonapi:"relation,workspace,omitempty"`
 }
 
+// AccessPolicySubject represents the subject of an access policy, one of the fields must be filled.
+type AccessPolicySubject struct {
+	User           *User
+	Team           *Team
+	ServiceAccount *ServiceAccount
+}
+
+// AccessPolicyScope represents the scope of an access policy, one of the fields must be filled.
+type AccessPolicyScope struct {
+	Account     *Account
+	Environment *Environment
+	Workspace   *Workspace
+}
+
+// Subject returns the user, team or service account the policy grants the roles to.
+func (ap *AccessPolicy) Subject() AccessPolicySubject {
+	return AccessPolicySubject{User: ap.User, Team: ap.Team, ServiceAccount: ap.ServiceAccount}
+}
+
+// Scope returns the account, environment or workspace the policy is bound to.
+func (ap *AccessPolicy) Scope() AccessPolicyScope {
+	return AccessPolicyScope{Account: ap.Account, Environment: ap.Environment, Workspace: ap.Workspace}
+}
+
+// AccessPolicyConflictError is returned by Create when a policy for the same
+// subject and scope already exists.
+type AccessPolicyConflictError struct {
+	ResourceConflictError
+
+	// The existing policy, nil if it could not be found.
+	Existing *AccessPolicy
+}
+
 // AccessPolicyCreateOptions represents the options for creating a new AccessPolicy.
 type AccessPolicyCreateOptions struct {
 	ID string `jsonapi:"primary,access-policies"`
@@ -118,6 +152,7 @@ type AccessPolicyListOptions struct {
 	User           *string `url:"filter[user],omitempty"`
 	ServiceAccount *string `url:"filter[service-account],omitempty"`
 	Team           *string `url:"filter[team],omitempty"`
+	Role           *string `url:"filter[role],omitempty"`
 	Include        string  `url:"include,omitempty"`
 }
 
@@ -152,12 +187,77 @@ func (s *accessPolicies) Create(ctx context.Context, options AccessPolicyCreateO
 	accessPolicy := &AccessPolicy{}
 	err = s.client.do(ctx, req, accessPolicy)
 	if err != nil {
+		var conflict ResourceConflictError
+		if errors.As(err, &conflict) {
+			existing, _ := s.FindExisting(ctx,
+				AccessPolicySubject{User: options.User, Team: options.Team, ServiceAccount: options.ServiceAccount},
+				AccessPolicyScope{Account: options.Account, Environment: options.Environment, Workspace: options.Workspace},
+			)
+			return nil, AccessPolicyConflictError{ResourceConflictError: conflict, Existing: existing}
+		}
 		return nil, err
 	}
 
 	return accessPolicy, nil
 }
 
+// FindExisting finds the access policy of the subject on exactly the given scope.
+func (s *accessPolicies) FindExisting(ctx context.Context, subject AccessPolicySubject, scope AccessPolicyScope) (*AccessPolicy, error) {
+	options := AccessPolicyListOptions{}
+
+	switch {
+	case subject.User != nil && validStringID(&subject.User.ID):
+		options.User = String(subject.User.ID)
+	case subject.Team != nil && validStringID(&subject.Team.ID):
+		options.Team = String(subject.Team.ID)
+	case subject.ServiceAccount != nil && validStringID(&subject.ServiceAccount.ID):
+		options.ServiceAccount = String(subject.ServiceAccount.ID)
+	default:
+		return nil, errors.New("one of: user,team,service_account must be provided")
+	}
+
+	switch {
+	case scope.Workspace != nil && validStringID(&scope.Workspace.ID):
+		options.Workspace = String(scope.Workspace.ID)
+	case scope.Environment != nil && validStringID(&scope.Environment.ID):
+		options.Environment = String(scope.Environment.ID)
+	case scope.Account != nil && validStringID(&scope.Account.ID):
+		options.Account = String(scope.Account.ID)
+	default:
+		return nil, errors.New("one of: account,environment,workspace must be provided")
+	}
+
+	for {
+		apl, err := s.List(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, ap := range apl.Items {
+			if ap.matchesScope(options) {
+				return ap, nil
+			}
+		}
+		if apl.Pagination == nil || apl.NextPage == 0 {
+			break
+		}
+		options.PageNumber = apl.NextPage
+	}
+
+	return nil, ResourceNotFoundError{Message: "Access policy for the given subject and scope not found"}
+}
+
+// matchesScope reports whether the policy is bound to exactly the scope of the list filter.
+func (ap *AccessPolicy) matchesScope(options AccessPolicyListOptions) bool {
+	switch {
+	case options.Workspace != nil:
+		return ap.Workspace != nil && ap.Workspace.ID == *options.Workspace
+	case options.Environment != nil:
+		return ap.Workspace == nil && ap.Environment != nil && ap.Environment.ID == *options.Environment
+	default:
+		return ap.Workspace == nil && ap.Environment == nil
+	}
+}
+
 // Read an accessPolicy by its ID.
 func (s *accessPolicies) Read(ctx context.Context, accessPolicyID string) (*AccessPolicy, error) {
 	if !validStringID(&accessPolicyID) {
@@ -95,6 +95,26 @@ func TestAccessPoliciesCreate(t *testing.T) {
 		client.AccessPolicies.Delete(ctx, ap.ID)
 	})
 
+	t.Run("when the policy already exists", func(t *testing.T) {
+		options := AccessPolicyCreateOptions{
+			Environment: envTest,
+			Roles:       []*Role{roleReadTest},
+			User:        &User{ID: defaultUserID},
+		}
+
+		ap, err := client.AccessPolicies.Create(ctx, options)
+		require.NoError(t, err)
+		defer client.AccessPolicies.Delete(ctx, ap.ID)
+
+		_, err = client.AccessPolicies.Create(ctx, options)
+		assert.ErrorIs(t, err, ErrResourceConflict)
+
+		var conflict AccessPolicyConflictError
+		require.ErrorAs(t, err, &conflict)
+		require.NotNil(t, conflict.Existing)
+		assert.Equal(t, ap.ID, conflict.Existing.ID)
+	})
+
 	t.Run("when options is missing scope", func(t *testing.T) {
 		w, err := client.AccessPolicies.Create(ctx, AccessPolicyCreateOptions{
 			Roles: []*Role{roleReadTest},
@@ -255,3 +275,50 @@ func TestAccessPoliciesDelete(t *testing.T) {
 		assert.EqualError(t, err, "invalid value for access policy ID")
 	})
 }
+
+func TestAccessPoliciesFindExisting(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	envTest, envTestCleanup := createEnvironment(t, client)
+	defer envTestCleanup()
+
+	teamTest, teamTestCleanup := createTeam(t, client, nil)
+	defer teamTestCleanup()
+
+	roleTest, roleTestCleanup := createRole(t, client, readPermissions)
+	defer roleTestCleanup()
+
+	apTest, apTestCleanup := createAccessPolicy(t, client, []*Role{roleTest}, teamTest)
+	defer apTestCleanup()
+
+	t.Run("when the policy exists", func(t *testing.T) {
+		ap, err := client.AccessPolicies.FindExisting(ctx,
+			AccessPolicySubject{Team: teamTest},
+			AccessPolicyScope{Account: &Account{ID: defaultAccountID}},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, apTest.ID, ap.ID)
+	})
+
+	t.Run("when the policy does not exist on the scope", func(t *testing.T) {
+		ap, err := client.AccessPolicies.FindExisting(ctx,
+			AccessPolicySubject{Team: teamTest},
+			AccessPolicyScope{Environment: envTest},
+		)
+		assert.Nil(t, ap)
+		assert.ErrorIs(t, err, ErrResourceNotFound)
+	})
+
+	t.Run("without a subject", func(t *testing.T) {
+		ap, err := client.AccessPolicies.FindExisting(ctx, AccessPolicySubject{}, AccessPolicyScope{Environment: envTest})
+		assert.Nil(t, ap)
+		assert.EqualError(t, err, "one of: user,team,service_account must be provided")
+	})
+
+	t.Run("without a scope", func(t *testing.T) {
+		ap, err := client.AccessPolicies.FindExisting(ctx, AccessPolicySubject{Team: teamTest}, AccessPolicyScope{})
+		assert.Nil(t, ap)
+		assert.EqualError(t, err, "one of: account,environment,workspace must be provided")
+	})
+}
@@ -17,6 +17,8 @@ type AccessTokens interface {
 	Read(ctx context.Context, accessTokenID string) (*AccessToken, error)
 	Update(ctx context.Context, accessTokenID string, options AccessTokenUpdateOptions) (*AccessToken, error)
 	Delete(ctx context.Context, accessTokenID string) error
+	// FindStale returns the owner's access tokens that have not been used for longer than olderThan.
+	FindStale(ctx context.Context, owner AccessTokenOwner, olderThan time.Duration) ([]*AccessToken, error)
 }
 
 // accessTokens implements AccessTokens.
@@ -36,6 +38,25 @@ type AccessToken struct {
 	CreatedAt   time.Time `jsonapi:"attr,created-at,iso8601"`
 	Description string    `jsonapi:"attr,description"`
 	Token       string    `jsonapi:"attr,token"`
+
+	// The time the token was last used for authentication, nil if it was never used.
+	LastUsedAt *time.Time `jsonapi:"attr,last-used-at,iso8601"`
+}
+
+// LastActivity returns the time the token was last used, or its creation time
+// if it was never used.
+func (at *AccessToken) LastActivity() time.Time {
+	if at.LastUsedAt != nil {
+		return *at.LastUsedAt
+	}
+	return at.CreatedAt
+}
+
+// AccessTokenOwner identifies the owner of access tokens.
+// Exactly one of the fields must be set.
+type AccessTokenOwner struct {
+	AgentPool      *AgentPool
+	ServiceAccount *ServiceAccount
 }
 
 // AccessTokenListOptions represents the options for listing access tokens.
@@ -118,3 +139,51 @@ func (s *accessTokens) Delete(ctx context.Context, accessTokenID string) error {
 
 	return s.client.do(ctx, req, nil)
 }
+
+// FindStale lists all access tokens of the owner and returns those that were not
+// used (or created, if never used) within olderThan. The result is meant as a list of
+// candidates for revocation.
+func (s *accessTokens) FindStale(ctx context.Context, owner AccessTokenOwner, olderThan time.Duration) ([]*AccessToken, error) {
+	var list func(options AccessTokenListOptions) (*AccessTokenList, error)
+	switch {
+	case owner.AgentPool != nil && owner.ServiceAccount != nil:
+		return nil, errors.New("only one of agent pool or service account must be provided")
+	case owner.AgentPool != nil:
+		if !validStringID(&owner.AgentPool.ID) {
+			return nil, errors.New("invalid value for agent pool ID")
+		}
+		list = func(options AccessTokenListOptions) (*AccessTokenList, error) {
+			return s.client.AgentPoolTokens.List(ctx, owner.AgentPool.ID, options)
+		}
+	case owner.ServiceAccount != nil:
+		if !validStringID(&owner.ServiceAccount.ID) {
+			return nil, errors.New("invalid value for service account ID")
+		}
+		list = func(options AccessTokenListOptions) (*AccessTokenList, error) {
+			return s.client.ServiceAccountTokens.List(ctx, owner.ServiceAccount.ID, options)
+		}
+	default:
+		return nil, errors.New("agent pool or service account is required")
+	}
+
+	threshold := time.Now().Add(-olderThan)
+	var stale []*AccessToken
+	options := AccessTokenListOptions{}
+	for {
+		atl, err := list(options)
+		if err != nil {
+			return nil, err
+		}
+		for _, at := range atl.Items {
+			if at.LastActivity().Before(threshold) {
+				stale = append(stale, at)
+			}
+		}
+		if atl.Pagination == nil || atl.NextPage == 0 {
+			break
+		}
+		options.PageNumber = atl.NextPage
+	}
+
+	return stale, nil
+}
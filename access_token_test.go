@@ -3,7 +3,10 @@ package scalr
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -23,6 +26,8 @@ func TestAccessTokenRead(t *testing.T) {
 		at, err := client.AccessTokens.Read(ctx, atTest.ID)
 		require.NoError(t, err)
 		assert.Equal(t, atTest.ID, at.ID)
+		assert.False(t, at.CreatedAt.IsZero())
+		assert.Nil(t, at.LastUsedAt)
 	})
 
 	t.Run("when the token does not exist", func(t *testing.T) {
@@ -93,3 +98,63 @@ func TestAccessTokenDelete(t *testing.T) {
 		assert.EqualError(t, err, fmt.Sprintf("invalid value for access token ID: '%s'", badIdentifier))
 	})
 }
+
+func TestAccessTokenFindStale(t *testing.T) {
+	now := time.Now().UTC()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		switch r.URL.Path {
+		case "/api/iacp/v3/service-accounts/sa-123/access-tokens":
+			if r.URL.Query().Get("page[number]") == "2" {
+				fmt.Fprintf(w, `{"data":[{"id":"at-never-used","type":"access-tokens","attributes":{"created-at":%q}}],`+
+					`"meta":{"pagination":{"current-page":2,"total-pages":2}}}`,
+					now.Add(-100*24*time.Hour).Format(time.RFC3339))
+				return
+			}
+			fmt.Fprintf(w, `{"data":[`+
+				`{"id":"at-stale","type":"access-tokens","attributes":{"created-at":%q,"last-used-at":%q}},`+
+				`{"id":"at-active","type":"access-tokens","attributes":{"created-at":%q,"last-used-at":%q}}],`+
+				`"meta":{"pagination":{"current-page":1,"next-page":2,"total-pages":2}}}`,
+				now.Add(-200*24*time.Hour).Format(time.RFC3339), now.Add(-95*24*time.Hour).Format(time.RFC3339),
+				now.Add(-200*24*time.Hour).Format(time.RFC3339), now.Add(-time.Hour).Format(time.RFC3339))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("with service account owner", func(t *testing.T) {
+		owner := AccessTokenOwner{ServiceAccount: &ServiceAccount{ID: "sa-123"}}
+		stale, err := client.AccessTokens.FindStale(ctx, owner, 90*24*time.Hour)
+		require.NoError(t, err)
+		ids := make([]string, len(stale))
+		for i, at := range stale {
+			ids[i] = at.ID
+		}
+		assert.Equal(t, []string{"at-stale", "at-never-used"}, ids)
+	})
+
+	t.Run("without owner", func(t *testing.T) {
+		stale, err := client.AccessTokens.FindStale(ctx, AccessTokenOwner{}, time.Hour)
+		assert.Nil(t, stale)
+		assert.EqualError(t, err, "agent pool or service account is required")
+	})
+
+	t.Run("with both owners", func(t *testing.T) {
+		owner := AccessTokenOwner{AgentPool: &AgentPool{ID: "apool-123"}, ServiceAccount: &ServiceAccount{ID: "sa-123"}}
+		stale, err := client.AccessTokens.FindStale(ctx, owner, time.Hour)
+		assert.Nil(t, stale)
+		assert.EqualError(t, err, "only one of agent pool or service account must be provided")
+	})
+
+	t.Run("with invalid agent pool ID", func(t *testing.T) {
+		owner := AccessTokenOwner{AgentPool: &AgentPool{ID: badIdentifier}}
+		stale, err := client.AccessTokens.FindStale(ctx, owner, time.Hour)
+		assert.Nil(t, stale)
+		assert.EqualError(t, err, "invalid value for agent pool ID")
+	})
+}
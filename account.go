@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 )
 
 // Compile-time proof of interface implementation.
@@ -14,7 +15,17 @@ var _ Accounts = (*accounts)(nil)
 // Scalr IACP API supports.
 type Accounts interface {
 	Read(ctx context.Context, account string) (*Account, error)
+	// Update the name, billing contacts or allowed IPs of the account.
 	Update(ctx context.Context, account string, options AccountUpdateOptions) (*Account, error)
+	// Limits reads the per-account quotas (environments, workspaces, concurrent runs
+	// and agents) along with their current usage.
+	Limits(ctx context.Context, account string) (*AccountLimits, error)
+	Summary(ctx context.Context, account string) (*AccountSummary, error)
+	FindUnused(ctx context.Context, account string) (*AccountUnusedResources, error)
+	ReadEnvironmentDefaults(ctx context.Context, account string) (*AccountEnvironmentDefaults, error)
+	UpdateEnvironmentDefaults(
+		ctx context.Context, account string, options AccountEnvironmentDefaultsUpdateOptions,
+	) (*AccountEnvironmentDefaults, error)
 }
 
 // accounts implements Accounts.
@@ -24,9 +35,13 @@ type accounts struct {
 
 // Account represents a Scalr IACP account.
 type Account struct {
-	ID         string   `jsonapi:"primary,accounts"`
-	Name       string   `jsonapi:"attr,name"`
-	AllowedIPs []string `jsonapi:"attr,allowed-ips"`
+	ID              string   `jsonapi:"primary,accounts"`
+	Name            string   `jsonapi:"attr,name"`
+	AllowedIPs      []string `jsonapi:"attr,allowed-ips"`
+	BillingContacts []string `jsonapi:"attr,billing-contacts"`
+
+	// Relations
+	DefaultAgentPool *AgentPool `jsonapi:"relation,default-agent-pool"`
 }
 
 // Read a account by its ID.
@@ -50,15 +65,37 @@ func (s *accounts) Read(ctx context.Context, accountID string) (*Account, error)
 	return a, nil
 }
 
+// AccountUpdateOptions represents the options for updating an account.
 type AccountUpdateOptions struct {
 	ID         string    `jsonapi:"primary,accounts"`
+	Name       *string   `jsonapi:"attr,name,omitempty"`
 	AllowedIPs *[]string `jsonapi:"attr,allowed-ips,omitempty"`
+
+	// The email addresses receiving the billing notifications.
+	BillingContacts *[]string `jsonapi:"attr,billing-contacts,omitempty"`
+}
+
+func (o AccountUpdateOptions) valid() error {
+	if o.Name != nil && !validString(o.Name) {
+		return errors.New("invalid value for name")
+	}
+	if o.BillingContacts != nil {
+		for _, email := range *o.BillingContacts {
+			if !strings.Contains(email, "@") {
+				return fmt.Errorf("invalid value for billing contact: %q", email)
+			}
+		}
+	}
+	return nil
 }
 
 func (s *accounts) Update(ctx context.Context, accountID string, options AccountUpdateOptions) (*Account, error) {
 	if !validStringID(&accountID) {
 		return nil, errors.New("invalid value for account ID")
 	}
+	if err := options.valid(); err != nil {
+		return nil, err
+	}
 
 	// Make sure we don't send a user provided ID.
 	options.ID = ""
@@ -77,3 +114,174 @@ func (s *accounts) Update(ctx context.Context, accountID string, options Account
 
 	return a, nil
 }
+
+// AccountQuota represents the usage of a single limited resource.
+type AccountQuota struct {
+	// The maximum allowed amount, nil if the resource is unlimited.
+	Limit *int `json:"limit"`
+	// The amount currently in use.
+	Used int `json:"used"`
+}
+
+// Allows reports whether n more resources can be created within the quota.
+func (q *AccountQuota) Allows(n int) bool {
+	if q == nil || q.Limit == nil {
+		return true
+	}
+	return q.Used+n <= *q.Limit
+}
+
+// AccountLimits represents the resource quotas of a Scalr account.
+type AccountLimits struct {
+	ID             string        `jsonapi:"primary,account-limits"`
+	Environments   *AccountQuota `jsonapi:"attr,environments"`
+	Workspaces     *AccountQuota `jsonapi:"attr,workspaces"`
+	ConcurrentRuns *AccountQuota `jsonapi:"attr,concurrent-runs"`
+	Agents         *AccountQuota `jsonapi:"attr,agents"`
+}
+
+// Limits reads the resource quotas of the account.
+func (s *accounts) Limits(ctx context.Context, accountID string) (*AccountLimits, error) {
+	if !validStringID(&accountID) {
+		return nil, errors.New("invalid value for account ID")
+	}
+
+	u := fmt.Sprintf("accounts/%s/limits", url.QueryEscape(accountID))
+	req, err := s.client.newRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &AccountLimits{}
+	err = s.client.do(ctx, req, l)
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// AccountSummary represents the number of resources within a Scalr account.
+type AccountSummary struct {
+	ID              string `jsonapi:"primary,account-summaries"`
+	Environments    int    `jsonapi:"attr,environments"`
+	Workspaces      int    `jsonapi:"attr,workspaces"`
+	Users           int    `jsonapi:"attr,users"`
+	Teams           int    `jsonapi:"attr,teams"`
+	ServiceAccounts int    `jsonapi:"attr,service-accounts"`
+}
+
+// Summary reads the resource counts of the account.
+func (s *accounts) Summary(ctx context.Context, accountID string) (*AccountSummary, error) {
+	if !validStringID(&accountID) {
+		return nil, errors.New("invalid value for account ID")
+	}
+
+	u := fmt.Sprintf("accounts/%s/summary", url.QueryEscape(accountID))
+	req, err := s.client.newRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &AccountSummary{}
+	err = s.client.do(ctx, req, summary)
+	if err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// AccountEnvironmentDefaults represents the settings the account applies
+// to every environment created in it.
+type AccountEnvironmentDefaults struct {
+	ID string `jsonapi:"primary,account-environment-defaults"`
+
+	// Relations
+	DefaultPolicyGroups           []*PolicyGroup           `jsonapi:"relation,default-policy-groups"`
+	DefaultProviderConfigurations []*ProviderConfiguration `jsonapi:"relation,default-provider-configurations"`
+}
+
+// AccountEnvironmentDefaultsUpdateOptions represents the options for updating
+// the environment defaults of an account. A nil field keeps the current value,
+// an empty one removes all the defaults of that kind.
+type AccountEnvironmentDefaultsUpdateOptions struct {
+	ID string `jsonapi:"primary,account-environment-defaults"`
+
+	// Relations
+	DefaultPolicyGroups           []*PolicyGroup           `jsonapi:"relation,default-policy-groups"`
+	DefaultProviderConfigurations []*ProviderConfiguration `jsonapi:"relation,default-provider-configurations"`
+}
+
+// ReadEnvironmentDefaults reads the defaults applied to new environments of the account.
+func (s *accounts) ReadEnvironmentDefaults(ctx context.Context, accountID string) (*AccountEnvironmentDefaults, error) {
+	if !validStringID(&accountID) {
+		return nil, errors.New("invalid value for account ID")
+	}
+
+	u := fmt.Sprintf("accounts/%s/environment-defaults", url.QueryEscape(accountID))
+	req, err := s.client.newRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &AccountEnvironmentDefaults{}
+	err = s.client.do(ctx, req, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// UpdateEnvironmentDefaults updates the defaults applied to new environments of the account.
+// Existing environments are left unchanged.
+func (s *accounts) UpdateEnvironmentDefaults(
+	ctx context.Context, accountID string, options AccountEnvironmentDefaultsUpdateOptions,
+) (*AccountEnvironmentDefaults, error) {
+	if !validStringID(&accountID) {
+		return nil, errors.New("invalid value for account ID")
+	}
+	for _, pg := range options.DefaultPolicyGroups {
+		if pg == nil || !validStringID(&pg.ID) {
+			return nil, errors.New("invalid value for policy group ID")
+		}
+	}
+	for _, pcfg := range options.DefaultProviderConfigurations {
+		if pcfg == nil || !validStringID(&pcfg.ID) {
+			return nil, errors.New("invalid value for provider configuration ID")
+		}
+	}
+
+	// Make sure we don't send a user provided ID.
+	options.ID = ""
+
+	// The API replaces both relationships, so keep the current
+	// value of the one that is left unchanged.
+	if options.DefaultPolicyGroups == nil || options.DefaultProviderConfigurations == nil {
+		d, err := s.ReadEnvironmentDefaults(ctx, accountID)
+		if err != nil {
+			return nil, err
+		}
+		if options.DefaultPolicyGroups == nil {
+			options.DefaultPolicyGroups = d.DefaultPolicyGroups
+		}
+		if options.DefaultProviderConfigurations == nil {
+			options.DefaultProviderConfigurations = d.DefaultProviderConfigurations
+		}
+	}
+
+	u := fmt.Sprintf("accounts/%s/environment-defaults", url.QueryEscape(accountID))
+	req, err := s.client.newRequest("PATCH", u, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &AccountEnvironmentDefaults{}
+	err = s.client.do(ctx, req, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
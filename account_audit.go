@@ -0,0 +1,154 @@
+package scalr
+
+import (
+	"context"
+	"errors"
+)
+
+// AccountUnusedResources represents the resources of an account that no
+// workspace uses and are candidates for removal.
+type AccountUnusedResources struct {
+	// Provider configurations that are neither linked to a workspace
+	// or an environment nor a default of an environment.
+	ProviderConfigurations []*ProviderConfiguration
+
+	// Account and environment variables that no workspace inherits, as
+	// there are no workspaces in their scope.
+	Variables []*Variable
+}
+
+// FindUnused cross-references the provider configurations and the account and
+// environment variables of the account against its workspaces and returns the
+// ones that are not used. It reads every workspace of the account, so it is
+// meant for periodic cleanups rather than frequent calls.
+func (s *accounts) FindUnused(ctx context.Context, accountID string) (*AccountUnusedResources, error) {
+	if !validStringID(&accountID) {
+		return nil, errors.New("invalid value for account ID")
+	}
+
+	usedConfigurations := make(map[string]bool)
+
+	envOptions := EnvironmentListOptions{Filter: &EnvironmentFilter{Account: &accountID}}
+	for {
+		el, err := s.client.Environments.List(ctx, envOptions)
+		if err != nil {
+			return nil, err
+		}
+		for _, env := range el.Items {
+			// The legacy defaults still keep a configuration in use.
+			for _, pc := range env.DefaultProviderConfigurations {
+				usedConfigurations[pc.ID] = true
+			}
+
+			linkOptions := ProviderConfigurationLinksListOptions{}
+			for {
+				ll, err := s.client.ProviderConfigurationLinks.ListForEnvironment(ctx, env.ID, linkOptions)
+				if err != nil {
+					return nil, err
+				}
+				for _, link := range ll.Items {
+					if link.ProviderConfiguration != nil {
+						usedConfigurations[link.ProviderConfiguration.ID] = true
+					}
+				}
+				if ll.Pagination == nil || ll.NextPage == 0 {
+					break
+				}
+				linkOptions.PageNumber = ll.NextPage
+			}
+		}
+		if el.Pagination == nil || el.NextPage == 0 {
+			break
+		}
+		envOptions.PageNumber = el.NextPage
+	}
+
+	workspaces := make(map[string]int)
+	wsOptions := WorkspaceListOptions{Filter: &WorkspaceFilter{Account: &accountID}}
+	for {
+		wl, err := s.client.Workspaces.List(ctx, wsOptions)
+		if err != nil {
+			return nil, err
+		}
+		for _, ws := range wl.Items {
+			if ws.Environment != nil {
+				workspaces[ws.Environment.ID]++
+			}
+
+			linkOptions := ProviderConfigurationLinksListOptions{}
+			for {
+				ll, err := s.client.ProviderConfigurationLinks.List(ctx, ws.ID, linkOptions)
+				if err != nil {
+					return nil, err
+				}
+				for _, link := range ll.Items {
+					if link.ProviderConfiguration != nil {
+						usedConfigurations[link.ProviderConfiguration.ID] = true
+					}
+				}
+				if ll.Pagination == nil || ll.NextPage == 0 {
+					break
+				}
+				linkOptions.PageNumber = ll.NextPage
+			}
+		}
+		if wl.Pagination == nil || wl.NextPage == 0 {
+			break
+		}
+		wsOptions.PageNumber = wl.NextPage
+	}
+
+	result := &AccountUnusedResources{}
+
+	pcOptions := ProviderConfigurationsListOptions{Filter: &ProviderConfigurationFilter{AccountID: accountID}}
+	for {
+		pcl, err := s.client.ProviderConfigurations.List(ctx, pcOptions)
+		if err != nil {
+			return nil, err
+		}
+		for _, pc := range pcl.Items {
+			if !usedConfigurations[pc.ID] {
+				result.ProviderConfigurations = append(result.ProviderConfigurations, pc)
+			}
+		}
+		if pcl.Pagination == nil || pcl.NextPage == 0 {
+			break
+		}
+		pcOptions.PageNumber = pcl.NextPage
+	}
+
+	total := 0
+	for _, n := range workspaces {
+		total += n
+	}
+
+	varOptions := VariableListOptions{
+		Filter: &VariableFilter{
+			Account:   String(accountID),
+			Workspace: String("null"),
+		},
+	}
+	for {
+		vl, err := s.client.Variables.List(ctx, varOptions)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range vl.Items {
+			switch {
+			case v.Workspace != nil:
+			case v.Environment != nil:
+				if workspaces[v.Environment.ID] == 0 {
+					result.Variables = append(result.Variables, v)
+				}
+			case total == 0:
+				result.Variables = append(result.Variables, v)
+			}
+		}
+		if vl.Pagination == nil || vl.NextPage == 0 {
+			break
+		}
+		varOptions.PageNumber = vl.NextPage
+	}
+
+	return result, nil
+}
@@ -2,7 +2,10 @@ package scalr
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -99,3 +102,220 @@ func TestAccountUpdate(t *testing.T) {
 		assert.Equal(t, []string{}, account.AllowedIPs)
 	})
 }
+
+func TestAccountLimits(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	t.Run("account exists", func(t *testing.T) {
+		limits, err := client.Accounts.Limits(ctx, defaultAccountID)
+		require.NoError(t, err)
+		require.NotNil(t, limits.Workspaces)
+		assert.True(t, limits.Workspaces.Allows(0))
+	})
+
+	t.Run("with invalid acc ID", func(t *testing.T) {
+		r, err := client.Accounts.Limits(ctx, badIdentifier)
+		assert.Nil(t, r)
+		assert.EqualError(t, err, "invalid value for account ID")
+	})
+}
+
+func TestAccountSummary(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	envTest, envTestCleanup := createEnvironment(t, client)
+	defer envTestCleanup()
+
+	_, wsTestCleanup := createWorkspace(t, client, envTest)
+	defer wsTestCleanup()
+
+	t.Run("account exists", func(t *testing.T) {
+		summary, err := client.Accounts.Summary(ctx, defaultAccountID)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, summary.Environments, 1)
+		assert.GreaterOrEqual(t, summary.Workspaces, 1)
+		assert.GreaterOrEqual(t, summary.Users, 1)
+	})
+
+	t.Run("with invalid acc ID", func(t *testing.T) {
+		r, err := client.Accounts.Summary(ctx, badIdentifier)
+		assert.Nil(t, r)
+		assert.EqualError(t, err, "invalid value for account ID")
+	})
+}
+
+func TestAccountQuotaAllows(t *testing.T) {
+	var unset *AccountQuota
+	assert.True(t, unset.Allows(100))
+	assert.True(t, (&AccountQuota{Used: 10}).Allows(100))
+	assert.True(t, (&AccountQuota{Limit: Int(10), Used: 8}).Allows(2))
+	assert.False(t, (&AccountQuota{Limit: Int(10), Used: 8}).Allows(3))
+}
+
+func TestAccountFindUnused(t *testing.T) {
+	pages := map[string]string{
+		"/api/iacp/v3/environments": `{"data":[` +
+			`{"id":"env-used","type":"environments","relationships":{"default-provider-configurations":{"data":[{"id":"pcfg-default","type":"provider-configurations"}]}}},` +
+			`{"id":"env-empty","type":"environments"}]}`,
+		"/api/iacp/v3/environments/env-used/provider-configuration-links": `{"data":[]}`,
+		"/api/iacp/v3/environments/env-empty/provider-configuration-links": `{"data":[` +
+			`{"id":"pcfgl-2","type":"provider-configuration-links","relationships":{"provider-configuration":{"data":{"id":"pcfg-env-linked","type":"provider-configurations"}}}}]}`,
+		"/api/iacp/v3/workspaces": `{"data":[` +
+			`{"id":"ws-1","type":"workspaces","relationships":{"environment":{"data":{"id":"env-used","type":"environments"}}}}]}`,
+		"/api/iacp/v3/workspaces/ws-1/provider-configuration-links": `{"data":[` +
+			`{"id":"pcfgl-1","type":"provider-configuration-links","relationships":{"provider-configuration":{"data":{"id":"pcfg-linked","type":"provider-configurations"}}}}]}`,
+		"/api/iacp/v3/provider-configurations": `{"data":[` +
+			`{"id":"pcfg-default","type":"provider-configurations"},` +
+			`{"id":"pcfg-linked","type":"provider-configurations"},` +
+			`{"id":"pcfg-env-linked","type":"provider-configurations"},` +
+			`{"id":"pcfg-unused","type":"provider-configurations"}]}`,
+		"/api/iacp/v3/vars": `{"data":[` +
+			`{"id":"var-account","type":"vars","relationships":{"account":{"data":{"id":"acc-1","type":"accounts"}}}},` +
+			`{"id":"var-used","type":"vars","relationships":{"environment":{"data":{"id":"env-used","type":"environments"}}}},` +
+			`{"id":"var-unused","type":"vars","relationships":{"environment":{"data":{"id":"env-empty","type":"environments"}}}}]}`,
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, ok := pages[r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected request: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		fmt.Fprint(w, page)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("with valid account", func(t *testing.T) {
+		unused, err := client.Accounts.FindUnused(ctx, "acc-1")
+		require.NoError(t, err)
+
+		require.Len(t, unused.ProviderConfigurations, 1)
+		assert.Equal(t, "pcfg-unused", unused.ProviderConfigurations[0].ID)
+		require.Len(t, unused.Variables, 1)
+		assert.Equal(t, "var-unused", unused.Variables[0].ID)
+	})
+
+	t.Run("with invalid account ID", func(t *testing.T) {
+		unused, err := client.Accounts.FindUnused(ctx, badIdentifier)
+		assert.Nil(t, unused)
+		assert.EqualError(t, err, "invalid value for account ID")
+	})
+}
+
+func TestAccountEnvironmentDefaults(t *testing.T) {
+	var patched map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/iacp/v3/accounts/acc-1/environment-defaults", r.URL.Path)
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		if r.Method == "PATCH" {
+			var body struct {
+				Data struct {
+					Relationships map[string]interface{} `json:"relationships"`
+				} `json:"data"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			patched = body.Data.Relationships
+		}
+		fmt.Fprint(w, `{"data":{"id":"acc-1","type":"account-environment-defaults","relationships":{`+
+			`"default-policy-groups":{"data":[{"id":"pgrp-1","type":"policy-groups"}]},`+
+			`"default-provider-configurations":{"data":[{"id":"pcfg-1","type":"provider-configurations"}]}}}}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("read", func(t *testing.T) {
+		d, err := client.Accounts.ReadEnvironmentDefaults(ctx, "acc-1")
+		require.NoError(t, err)
+		require.Len(t, d.DefaultPolicyGroups, 1)
+		assert.Equal(t, "pgrp-1", d.DefaultPolicyGroups[0].ID)
+		require.Len(t, d.DefaultProviderConfigurations, 1)
+		assert.Equal(t, "pcfg-1", d.DefaultProviderConfigurations[0].ID)
+	})
+
+	t.Run("update keeps the omitted relationship", func(t *testing.T) {
+		_, err := client.Accounts.UpdateEnvironmentDefaults(ctx, "acc-1", AccountEnvironmentDefaultsUpdateOptions{
+			DefaultPolicyGroups: []*PolicyGroup{},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"data": []interface{}{}}, patched["default-policy-groups"])
+		assert.Equal(t, map[string]interface{}{"data": []interface{}{
+			map[string]interface{}{"id": "pcfg-1", "type": "provider-configurations"},
+		}}, patched["default-provider-configurations"])
+	})
+
+	t.Run("with invalid policy group ID", func(t *testing.T) {
+		d, err := client.Accounts.UpdateEnvironmentDefaults(ctx, "acc-1", AccountEnvironmentDefaultsUpdateOptions{
+			DefaultPolicyGroups: []*PolicyGroup{{ID: badIdentifier}},
+		})
+		assert.Nil(t, d)
+		assert.EqualError(t, err, "invalid value for policy group ID")
+	})
+
+	t.Run("with invalid account ID", func(t *testing.T) {
+		d, err := client.Accounts.ReadEnvironmentDefaults(ctx, badIdentifier)
+		assert.Nil(t, d)
+		assert.EqualError(t, err, "invalid value for account ID")
+	})
+}
+
+func TestAccountUpdateNameAndBillingContacts(t *testing.T) {
+	var attributes map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+		assert.Equal(t, "/api/iacp/v3/accounts/acc-1", r.URL.Path)
+		var body struct {
+			Data struct {
+				Attributes map[string]interface{} `json:"attributes"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		attributes = body.Data.Attributes
+
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		fmt.Fprint(w, `{"data":{"id":"acc-1","type":"accounts","attributes":{`+
+			`"name":"renamed","billing-contacts":["billing@example.com"]}}}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		account, err := client.Accounts.Update(ctx, "acc-1", AccountUpdateOptions{
+			Name:            String("renamed"),
+			BillingContacts: &[]string{"billing@example.com"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"name":             "renamed",
+			"billing-contacts": []interface{}{"billing@example.com"},
+		}, attributes)
+		assert.Equal(t, "renamed", account.Name)
+		assert.Equal(t, []string{"billing@example.com"}, account.BillingContacts)
+	})
+
+	t.Run("with empty name", func(t *testing.T) {
+		account, err := client.Accounts.Update(ctx, "acc-1", AccountUpdateOptions{Name: String("")})
+		assert.Nil(t, account)
+		assert.EqualError(t, err, "invalid value for name")
+	})
+
+	t.Run("with invalid billing contact", func(t *testing.T) {
+		account, err := client.Accounts.Update(ctx, "acc-1", AccountUpdateOptions{
+			BillingContacts: &[]string{"billing"},
+		})
+		assert.Nil(t, account)
+		assert.EqualError(t, err, `invalid value for billing contact: "billing"`)
+	})
+}
@@ -3,6 +3,8 @@ package scalr
 import (
 	"context"
 	"errors"
+	"strings"
+	"time"
 )
 
 // Compile-time proof of interface implementation.
@@ -11,7 +13,11 @@ var _ AccountUsers = (*accountUsers)(nil)
 // AccountUsers describes all the account user related methods that the
 // Scalr IACP API supports.
 type AccountUsers interface {
+	// List the account users matching the filter, either the account or the user is required.
 	List(ctx context.Context, options AccountUserListOptions) (*AccountUserList, error)
+
+	// ListActiveUsers lists all the active users of the account.
+	ListActiveUsers(ctx context.Context, accountID string) ([]*User, error)
 }
 
 // accountUsers implements AccountUsers.
@@ -29,13 +35,43 @@ const (
 	AccountUserStatusPending  AccountUserStatus = "Pending"
 )
 
+// AccountUserInclude represents a relationship that can be included in the account users list.
+type AccountUserInclude string
+
+// List of the relationships available for inclusion.
+const (
+	AccountUserIncludeUser    AccountUserInclude = "user"
+	AccountUserIncludeAccount AccountUserInclude = "account"
+	AccountUserIncludeTeams   AccountUserInclude = "teams"
+)
+
+// AccountUserIncludes returns the comma-separated list of the relationships for the Include option.
+func AccountUserIncludes(includes ...AccountUserInclude) *string {
+	names := make([]string, len(includes))
+	for i, include := range includes {
+		names[i] = string(include)
+	}
+	return String(strings.Join(names, ","))
+}
+
 // AccountUserListOptions represents the options for listing account users.
 type AccountUserListOptions struct {
+	ListOptions
+
 	Account *string `url:"filter[account],omitempty"`
 	User    *string `url:"filter[user],omitempty"`
 	Query   *string `url:"query,omitempty"`
 	Sort    *string `url:"sort,omitempty"`
+
+	// The comma-separated list of the relationships to include, see AccountUserIncludes.
 	Include *string `url:"include,omitempty"`
+
+	// Filter by the status of the account user.
+	Status *AccountUserStatus `url:"filter[status],omitempty"`
+
+	// Filter by the last login time, see TimeRange, e.g. the users
+	// inactive for 90 days: TimeRange(time.Time{}, time.Now().AddDate(0, 0, -90)).
+	LastLoginAt *TimeRangeFilter `url:"filter[last-login-at],omitempty"`
 }
 
 func (o AccountUserListOptions) validate() error {
@@ -56,12 +92,21 @@ type AccountUser struct {
 	ID     string            `jsonapi:"primary,account-users"`
 	Status AccountUserStatus `jsonapi:"attr,status"`
 
-	// Relations
+	// The last time the user logged in, nil if the user never did.
+	LastLoginAt *time.Time `jsonapi:"attr,last-login-at,iso8601"`
+
+	// Relations, only their IDs are set unless they are included in the list,
+	// see AccountUserIncludes.
 	Account *Account `jsonapi:"relation,account"`
 	User    *User    `jsonapi:"relation,user"`
 	Teams   []*Team  `jsonapi:"relation,teams"`
 }
 
+// InactiveSince reports whether the user has not logged in since the given time.
+func (au *AccountUser) InactiveSince(t time.Time) bool {
+	return au.LastLoginAt == nil || au.LastLoginAt.Before(t)
+}
+
 // List all the account users.
 func (s *accountUsers) List(ctx context.Context, options AccountUserListOptions) (*AccountUserList, error) {
 	if err := options.validate(); err != nil {
@@ -81,3 +126,35 @@ func (s *accountUsers) List(ctx context.Context, options AccountUserListOptions)
 
 	return aul, nil
 }
+
+// ListActiveUsers lists all the active users of the account, with their attributes.
+func (s *accountUsers) ListActiveUsers(ctx context.Context, accountID string) ([]*User, error) {
+	if !validStringID(&accountID) {
+		return nil, errors.New("invalid value for account ID")
+	}
+
+	options := AccountUserListOptions{
+		Account: String(accountID),
+		Status:  AccountUserStatusPtr(AccountUserStatusActive),
+		Include: AccountUserIncludes(AccountUserIncludeUser),
+	}
+
+	var users []*User
+	for {
+		aul, err := s.List(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, au := range aul.Items {
+			if au.User != nil {
+				users = append(users, au.User)
+			}
+		}
+		if aul.Pagination == nil || aul.NextPage == 0 {
+			break
+		}
+		options.PageNumber = aul.NextPage
+	}
+
+	return users, nil
+}
@@ -2,7 +2,11 @@ package scalr
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -48,6 +52,17 @@ func TestAccountUsersList(t *testing.T) {
 		assert.Contains(t, aIDs, defaultAccountID)
 	})
 
+	t.Run("with inactive users filter", func(t *testing.T) {
+		aul, err := client.AccountUsers.List(ctx, AccountUserListOptions{
+			Account:     String(defaultAccountID),
+			LastLoginAt: TimeRange(time.Time{}, time.Now().AddDate(0, 0, -90)),
+		})
+		require.NoError(t, err)
+		for _, au := range aul.Items {
+			assert.True(t, au.InactiveSince(time.Now().AddDate(0, 0, -90)))
+		}
+	})
+
 	t.Run("without a valid account", func(t *testing.T) {
 		aul, err := client.AccountUsers.List(ctx, AccountUserListOptions{
 			Account: String(badIdentifier),
@@ -64,3 +79,95 @@ func TestAccountUsersList(t *testing.T) {
 		assert.Len(t, aul.Items, 0)
 	})
 }
+
+func TestAccountUsersListIncludes(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/iacp/v3/account-users", r.URL.Path)
+		assert.Equal(t, "teams", r.URL.Query().Get("include"))
+		assert.Equal(t, "Active", r.URL.Query().Get("filter[status]"))
+
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		fmt.Fprint(w, `{"data":[{"id":"au-1","type":"account-users",`+
+			`"attributes":{"status":"Active","last-login-at":"2023-01-02T03:04:05Z"},`+
+			`"relationships":{"teams":{"data":[{"id":"team-1","type":"teams"},{"id":"team-2","type":"teams"}]}}}],`+
+			`"included":[{"id":"team-1","type":"teams","attributes":{"name":"dev"}},`+
+			`{"id":"team-2","type":"teams","attributes":{"name":"ops"}}]}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+
+	t.Run("with teams and last login", func(t *testing.T) {
+		aul, err := client.AccountUsers.List(context.Background(), AccountUserListOptions{
+			Account: String("acc-123"),
+			Include: String("teams"),
+			Status:  AccountUserStatusPtr(AccountUserStatusActive),
+		})
+		require.NoError(t, err)
+		require.Len(t, aul.Items, 1)
+
+		au := aul.Items[0]
+		assert.Equal(t, AccountUserStatusActive, au.Status)
+		require.NotNil(t, au.LastLoginAt)
+		assert.Equal(t, time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC), au.LastLoginAt.UTC())
+		require.Len(t, au.Teams, 2)
+		assert.Equal(t, "team-1", au.Teams[0].ID)
+		assert.Equal(t, "dev", au.Teams[0].Name)
+		assert.Equal(t, "team-2", au.Teams[1].ID)
+		assert.Equal(t, "ops", au.Teams[1].Name)
+	})
+}
+
+func TestAccountUserInactiveSince(t *testing.T) {
+	cutoff := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	before, after := cutoff.Add(-time.Hour), cutoff.Add(time.Hour)
+
+	assert.True(t, (&AccountUser{}).InactiveSince(cutoff))
+	assert.True(t, (&AccountUser{LastLoginAt: &before}).InactiveSince(cutoff))
+	assert.False(t, (&AccountUser{LastLoginAt: &after}).InactiveSince(cutoff))
+}
+
+func TestAccountUsersListActiveUsers(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/iacp/v3/account-users", r.URL.Path)
+		assert.Equal(t, "acc-123", r.URL.Query().Get("filter[account]"))
+		assert.Equal(t, "Active", r.URL.Query().Get("filter[status]"))
+		assert.Equal(t, "user", r.URL.Query().Get("include"))
+
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		if r.URL.Query().Get("page[number]") == "2" {
+			fmt.Fprint(w, `{"data":[{"id":"au-2","type":"account-users","attributes":{"status":"Active"},`+
+				`"relationships":{"user":{"data":{"id":"user-2","type":"users"}}}}],`+
+				`"included":[{"id":"user-2","type":"users","attributes":{"email":"bob@example.com"}}],`+
+				`"meta":{"pagination":{"current-page":2,"prev-page":1,"total-pages":2,"total-count":2}}}`)
+			return
+		}
+		fmt.Fprint(w, `{"data":[{"id":"au-1","type":"account-users","attributes":{"status":"Active"},`+
+			`"relationships":{"user":{"data":{"id":"user-1","type":"users"}}}}],`+
+			`"included":[{"id":"user-1","type":"users","attributes":{"email":"alice@example.com"}}],`+
+			`"meta":{"pagination":{"current-page":1,"next-page":2,"total-pages":2,"total-count":2}}}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("with valid account", func(t *testing.T) {
+		users, err := client.AccountUsers.ListActiveUsers(ctx, "acc-123")
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+		assert.Equal(t, "alice@example.com", users[0].Email)
+		assert.Equal(t, "bob@example.com", users[1].Email)
+	})
+
+	t.Run("without a valid account", func(t *testing.T) {
+		_, err := client.AccountUsers.ListActiveUsers(ctx, badIdentifier)
+		assert.EqualError(t, err, "invalid value for account ID")
+	})
+
+	t.Run("includes", func(t *testing.T) {
+		assert.Equal(t, "user,teams", *AccountUserIncludes(AccountUserIncludeUser, AccountUserIncludeTeams))
+	})
+}
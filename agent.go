@@ -1,7 +1,139 @@
 package scalr
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Compile-time proof of interface implementation.
+var _ Agents = (*agents)(nil)
+
+// Agents describes all the agent related methods that the Scalr API supports.
+type Agents interface {
+	// List the agents registered in an agent pool.
+	List(ctx context.Context, options AgentListOptions) (*AgentList, error)
+	// Read an agent by its ID.
+	Read(ctx context.Context, agentID string) (*Agent, error)
+	// Delete deregisters an agent by its ID.
+	Delete(ctx context.Context, agentID string) error
+}
+
+// agents implements Agents.
+type agents struct {
+	client *Client
+}
+
+// AgentStatus represents the status of an agent.
+type AgentStatus string
+
+// List of available agent statuses.
+const (
+	AgentStatusIdle    AgentStatus = "idle"
+	AgentStatusBusy    AgentStatus = "busy"
+	AgentStatusUnknown AgentStatus = "unknown"
+	AgentStatusErrored AgentStatus = "errored"
+	AgentStatusExited  AgentStatus = "exited"
+)
+
+// Agent represents a Scalr agent registered in an agent pool.
 type Agent struct {
-	ID   string `jsonapi:"primary,agents"`
-	Name string `jsonapi:"attr,name"`
-	OS   string `jsonapi:"attr,os"`
+	ID              string      `jsonapi:"primary,agents"`
+	Name            string      `jsonapi:"attr,name"`
+	OS              string      `jsonapi:"attr,os"`
+	Status          AgentStatus `jsonapi:"attr,status"`
+	Version         string      `jsonapi:"attr,version"`
+	IPAddress       string      `jsonapi:"attr,ip-address"`
+	LastHeartbeatAt time.Time   `jsonapi:"attr,last-heartbeat-at,iso8601"`
+
+	// Relations
+	AgentPool *AgentPool `jsonapi:"relation,agent-pool"`
+}
+
+// IsStale reports whether the agent did not send a heartbeat within maxAge.
+func (a *Agent) IsStale(maxAge time.Duration) bool {
+	return a.LastHeartbeatAt.IsZero() || time.Since(a.LastHeartbeatAt) > maxAge
+}
+
+// AgentList represents a list of agents.
+type AgentList struct {
+	*Pagination
+	Items []*Agent
+}
+
+// AgentListOptions represents the options for listing agents.
+type AgentListOptions struct {
+	ListOptions
+
+	// The agent pool ID, required.
+	AgentPool string       `url:"filter[agent-pool]"`
+	Status    *AgentStatus `url:"filter[status],omitempty"`
+}
+
+func (o AgentListOptions) valid() error {
+	if !validString(&o.AgentPool) {
+		return errors.New("agent pool is required")
+	}
+	if !validStringID(&o.AgentPool) {
+		return errors.New("invalid value for agent pool ID")
+	}
+	return nil
+}
+
+// List the agents registered in an agent pool.
+func (s *agents) List(ctx context.Context, options AgentListOptions) (*AgentList, error) {
+	if err := options.valid(); err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.newRequest("GET", "agents", &options)
+	if err != nil {
+		return nil, err
+	}
+
+	al := &AgentList{}
+	err = s.client.do(ctx, req, al)
+	if err != nil {
+		return nil, err
+	}
+
+	return al, nil
+}
+
+// Read an agent by its ID.
+func (s *agents) Read(ctx context.Context, agentID string) (*Agent, error) {
+	if !validStringID(&agentID) {
+		return nil, errors.New("invalid value for agent ID")
+	}
+
+	u := fmt.Sprintf("agents/%s", url.QueryEscape(agentID))
+	req, err := s.client.newRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Agent{}
+	err = s.client.do(ctx, req, a)
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// Delete deregisters an agent by its ID. A running agent registers again on its next start.
+func (s *agents) Delete(ctx context.Context, agentID string) error {
+	if !validStringID(&agentID) {
+		return errors.New("invalid value for agent ID")
+	}
+
+	u := fmt.Sprintf("agents/%s", url.QueryEscape(agentID))
+	req, err := s.client.newRequest("DELETE", u, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.client.do(ctx, req, nil)
 }
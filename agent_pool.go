@@ -19,6 +19,16 @@ type AgentPools interface {
 	Create(ctx context.Context, options AgentPoolCreateOptions) (*AgentPool, error)
 	Update(ctx context.Context, agentPoolID string, options AgentPoolUpdateOptions) (*AgentPool, error)
 	Delete(ctx context.Context, agentPoolID string) error
+
+	// SetAccountDefault sets the agent pool used by the workspaces of the account
+	// that don't have a pool assigned. A nil pool removes the default.
+	SetAccountDefault(ctx context.Context, accountID string, agentPool *AgentPool) (*Account, error)
+	// SetEnvironmentDefault sets the agent pool used by the workspaces of the environment
+	// that don't have a pool assigned, it takes precedence over the account default.
+	// A nil pool removes the default.
+	SetEnvironmentDefault(ctx context.Context, environmentID string, agentPool *AgentPool) (*Environment, error)
+	// ReadEffectiveDefault reads the default agent pool that applies to the environment.
+	ReadEffectiveDefault(ctx context.Context, environmentID string) (*AgentPool, error)
 }
 
 // agentPools implements AgentPools.
@@ -202,3 +212,90 @@ func (s *agentPools) Delete(ctx context.Context, agentPoolID string) error {
 
 	return s.client.do(ctx, req, nil)
 }
+
+type accountDefaultAgentPoolOptions struct {
+	ID               string     `jsonapi:"primary,accounts"`
+	DefaultAgentPool *AgentPool `jsonapi:"relation,default-agent-pool"`
+}
+
+type environmentDefaultAgentPoolOptions struct {
+	ID               string     `jsonapi:"primary,environments"`
+	DefaultAgentPool *AgentPool `jsonapi:"relation,default-agent-pool"`
+}
+
+// SetAccountDefault sets or removes the default agent pool of the account.
+func (s *agentPools) SetAccountDefault(ctx context.Context, accountID string, agentPool *AgentPool) (*Account, error) {
+	if !validStringID(&accountID) {
+		return nil, errors.New("invalid value for account ID")
+	}
+	if agentPool != nil && !validStringID(&agentPool.ID) {
+		return nil, errors.New("invalid value for agent pool ID")
+	}
+
+	options := accountDefaultAgentPoolOptions{DefaultAgentPool: agentPool}
+	u := fmt.Sprintf("accounts/%s", url.QueryEscape(accountID))
+	req, err := s.client.newRequest("PATCH", u, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Account{}
+	err = s.client.do(ctx, req, a)
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// SetEnvironmentDefault sets or removes the default agent pool of the environment.
+func (s *agentPools) SetEnvironmentDefault(ctx context.Context, environmentID string, agentPool *AgentPool) (*Environment, error) {
+	if !validStringID(&environmentID) {
+		return nil, errors.New("invalid value for environment ID")
+	}
+	if agentPool != nil && !validStringID(&agentPool.ID) {
+		return nil, errors.New("invalid value for agent pool ID")
+	}
+
+	options := environmentDefaultAgentPoolOptions{DefaultAgentPool: agentPool}
+	u := fmt.Sprintf("environments/%s", url.QueryEscape(environmentID))
+	req, err := s.client.newRequest("PATCH", u, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	env := &Environment{}
+	err = s.client.do(ctx, req, env)
+	if err != nil {
+		return nil, err
+	}
+
+	return env, nil
+}
+
+// ReadEffectiveDefault reads the default agent pool of the environment, falling back
+// to the default agent pool of its account. A ResourceNotFoundError is returned
+// if neither has a default agent pool.
+func (s *agentPools) ReadEffectiveDefault(ctx context.Context, environmentID string) (*AgentPool, error) {
+	env, err := s.client.Environments.Read(ctx, environmentID)
+	if err != nil {
+		return nil, err
+	}
+	if env.DefaultAgentPool != nil {
+		return s.Read(ctx, env.DefaultAgentPool.ID)
+	}
+
+	if env.Account != nil {
+		account, err := s.client.Accounts.Read(ctx, env.Account.ID)
+		if err != nil {
+			return nil, err
+		}
+		if account.DefaultAgentPool != nil {
+			return s.Read(ctx, account.DefaultAgentPool.ID)
+		}
+	}
+
+	return nil, ResourceNotFoundError{
+		Message: fmt.Sprintf("Default agent pool for environment '%s' not found", environmentID),
+	}
+}
@@ -403,3 +403,56 @@ func TestAgentPoolsDelete(t *testing.T) {
 		assert.EqualError(t, err, "invalid value for agent pool ID")
 	})
 }
+
+func TestAgentPoolsDefaults(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	envTest, envTestCleanup := createEnvironment(t, client)
+	defer envTestCleanup()
+
+	accountPool, accountPoolCleanup := createAgentPool(t, client, false)
+	defer accountPoolCleanup()
+
+	envPool, envPoolCleanup := createAgentPool(t, client, false)
+	defer envPoolCleanup()
+
+	t.Run("without defaults", func(t *testing.T) {
+		ap, err := client.AgentPools.ReadEffectiveDefault(ctx, envTest.ID)
+		assert.Nil(t, ap)
+		assert.ErrorIs(t, err, ErrResourceNotFound)
+	})
+
+	t.Run("with account default", func(t *testing.T) {
+		account, err := client.AgentPools.SetAccountDefault(ctx, defaultAccountID, accountPool)
+		require.NoError(t, err)
+		defer func() { _, _ = client.AgentPools.SetAccountDefault(ctx, defaultAccountID, nil) }()
+		assert.Equal(t, accountPool.ID, account.DefaultAgentPool.ID)
+
+		ap, err := client.AgentPools.ReadEffectiveDefault(ctx, envTest.ID)
+		require.NoError(t, err)
+		assert.Equal(t, accountPool.ID, ap.ID)
+
+		t.Run("and environment default", func(t *testing.T) {
+			env, err := client.AgentPools.SetEnvironmentDefault(ctx, envTest.ID, envPool)
+			require.NoError(t, err)
+			assert.Equal(t, envPool.ID, env.DefaultAgentPool.ID)
+
+			ap, err := client.AgentPools.ReadEffectiveDefault(ctx, envTest.ID)
+			require.NoError(t, err)
+			assert.Equal(t, envPool.ID, ap.ID)
+
+			env, err = client.AgentPools.SetEnvironmentDefault(ctx, envTest.ID, nil)
+			require.NoError(t, err)
+			assert.Nil(t, env.DefaultAgentPool)
+		})
+	})
+
+	t.Run("with invalid IDs", func(t *testing.T) {
+		_, err := client.AgentPools.SetAccountDefault(ctx, badIdentifier, nil)
+		assert.EqualError(t, err, "invalid value for account ID")
+
+		_, err = client.AgentPools.SetEnvironmentDefault(ctx, envTest.ID, &AgentPool{ID: badIdentifier})
+		assert.EqualError(t, err, "invalid value for agent pool ID")
+	})
+}
@@ -0,0 +1,76 @@
+package scalr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgents(t *testing.T) {
+	heartbeat := time.Now().UTC().Add(-time.Minute).Format(time.RFC3339)
+	var deleted string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		agent := `{"id":"agent-1","type":"agents","attributes":{"name":"runner-1","status":"idle",` +
+			`"version":"0.1.30","last-heartbeat-at":"` + heartbeat + `"},` +
+			`"relationships":{"agent-pool":{"data":{"id":"apool-1","type":"agent-pools"}}}}`
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/iacp/v3/agents":
+			assert.Equal(t, "apool-1", r.URL.Query().Get("filter[agent-pool]"))
+			assert.Equal(t, "idle", r.URL.Query().Get("filter[status]"))
+			fmt.Fprint(w, `{"data":[`+agent+`]}`)
+		case "GET /api/iacp/v3/agents/agent-1":
+			fmt.Fprint(w, `{"data":`+agent+`}`)
+		case "DELETE /api/iacp/v3/agents/agent-1":
+			deleted = "agent-1"
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("list", func(t *testing.T) {
+		status := AgentStatusIdle
+		al, err := client.Agents.List(ctx, AgentListOptions{AgentPool: "apool-1", Status: &status})
+		require.NoError(t, err)
+		require.Len(t, al.Items, 1)
+		assert.Equal(t, "apool-1", al.Items[0].AgentPool.ID)
+	})
+
+	t.Run("list without agent pool", func(t *testing.T) {
+		al, err := client.Agents.List(ctx, AgentListOptions{})
+		assert.Nil(t, al)
+		assert.EqualError(t, err, "agent pool is required")
+	})
+
+	t.Run("read", func(t *testing.T) {
+		a, err := client.Agents.Read(ctx, "agent-1")
+		require.NoError(t, err)
+		assert.Equal(t, AgentStatusIdle, a.Status)
+		assert.Equal(t, "0.1.30", a.Version)
+		assert.False(t, a.IsStale(5*time.Minute))
+		assert.True(t, a.IsStale(time.Second))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, client.Agents.Delete(ctx, "agent-1"))
+		assert.Equal(t, "agent-1", deleted)
+	})
+
+	t.Run("with invalid agent ID", func(t *testing.T) {
+		a, err := client.Agents.Read(ctx, badIdentifier)
+		assert.Nil(t, a)
+		assert.EqualError(t, err, "invalid value for agent ID")
+	})
+}
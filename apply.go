@@ -1,6 +1,68 @@
 package scalr
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+)
+
+// Compile-time proof of interface implementation.
+var _ Applies = (*applies)(nil)
+
+// Applies describes all the apply related methods that the Scalr API supports.
+type Applies interface {
+	// Read an apply by its ID.
+	Read(ctx context.Context, applyID string) (*Apply, error)
+	// ReadLogs returns a reader of the apply output that follows the log until the apply is finished.
+	ReadLogs(ctx context.Context, applyID string) (io.ReadCloser, error)
+}
+
+// applies implements Applies.
+type applies struct {
+	client *Client
+}
+
 // Apply represents a Scalr apply.
 type Apply struct {
-	ID string `jsonapi:"primary,applies"`
+	ID     string `jsonapi:"primary,applies"`
+	Status string `jsonapi:"attr,status"`
+}
+
+// Read an apply by its ID.
+func (s *applies) Read(ctx context.Context, applyID string) (*Apply, error) {
+	if !validStringID(&applyID) {
+		return nil, errors.New("invalid value for apply ID")
+	}
+
+	u := fmt.Sprintf("applies/%s", url.QueryEscape(applyID))
+	req, err := s.client.newRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Apply{}
+	err = s.client.do(ctx, req, a)
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// ReadLogs returns a reader of the apply output. The reader fetches the log
+// incrementally and waits for more output until the apply is finished.
+func (s *applies) ReadLogs(ctx context.Context, applyID string) (io.ReadCloser, error) {
+	if !validStringID(&applyID) {
+		return nil, errors.New("invalid value for apply ID")
+	}
+
+	return newLogReader(ctx, s.client, fmt.Sprintf("applies/%s/logs", url.QueryEscape(applyID)), func() (bool, error) {
+		a, err := s.Read(ctx, applyID)
+		if err != nil {
+			return false, err
+		}
+		return isLogFinal(a.Status), nil
+	}), nil
 }
@@ -0,0 +1,62 @@
+package scalr
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the number of requests the batch methods send at once by default.
+const DefaultBatchConcurrency = 5
+
+// BatchOptions represents the options of the methods sending many requests at once.
+type BatchOptions struct {
+	// The number of requests sent at once, defaults to DefaultBatchConcurrency.
+	Concurrency int
+}
+
+func (o BatchOptions) valid() error {
+	if o.Concurrency < 0 {
+		return errors.New("invalid value for concurrency")
+	}
+	return nil
+}
+
+func (o BatchOptions) concurrency() int {
+	if o.Concurrency == 0 {
+		return DefaultBatchConcurrency
+	}
+	return o.Concurrency
+}
+
+// runBatch calls fn for every index from 0 to n-1, with at most concurrency calls
+// running at once; it is clamped to at least one. No more calls are started once
+// the context is done, the context error is returned when the started ones return.
+func runBatch(ctx context.Context, n, concurrency int, fn func(i int)) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	sem := make(chan struct{}, concurrency)
+	for i := 0; i < n; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i)
+		}(i)
+	}
+	return nil
+}
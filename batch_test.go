@@ -0,0 +1,46 @@
+package scalr
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunBatch(t *testing.T) {
+	t.Run("with zero concurrency", func(t *testing.T) {
+		var calls int32
+		err := runBatch(context.Background(), 3, 0, func(int) { atomic.AddInt32(&calls, 1) })
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), calls)
+	})
+
+	t.Run("with bounded concurrency", func(t *testing.T) {
+		var running, peak int32
+		err := runBatch(context.Background(), 10, 2, func(int) {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			atomic.AddInt32(&running, -1)
+		})
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, peak, int32(2))
+	})
+
+	t.Run("when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var calls int32
+		err := runBatch(ctx, 10, 1, func(int) {
+			if atomic.AddInt32(&calls, 1) == 2 {
+				cancel()
+			}
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Less(t, calls, int32(10))
+	})
+}
@@ -0,0 +1,124 @@
+package scalr
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+)
+
+// ConfigurationDiff represents the file-level changes between the
+// configuration versions of two runs. The paths are sorted.
+type ConfigurationDiff struct {
+	FromConfigurationVersion *ConfigurationVersion
+	ToConfigurationVersion   *ConfigurationVersion
+
+	Added    []string
+	Removed  []string
+	Modified []string
+}
+
+// Empty reports whether the configurations are identical.
+func (d *ConfigurationDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// ConfigurationDiff downloads the configuration versions of both runs and
+// reports which files were added, removed or modified in the second one.
+func (s *runs) ConfigurationDiff(ctx context.Context, fromRunID, toRunID string) (*ConfigurationDiff, error) {
+	if !validStringID(&fromRunID) {
+		return nil, errors.New("invalid value for source run ID")
+	}
+	if !validStringID(&toRunID) {
+		return nil, errors.New("invalid value for target run ID")
+	}
+
+	var cvs [2]*ConfigurationVersion
+	for i, runID := range []string{fromRunID, toRunID} {
+		r, err := s.Read(ctx, runID)
+		if err != nil {
+			return nil, err
+		}
+		if r.ConfigurationVersion == nil {
+			return nil, fmt.Errorf("run %s has no configuration version", runID)
+		}
+		cvs[i] = r.ConfigurationVersion
+	}
+
+	diff := &ConfigurationDiff{FromConfigurationVersion: cvs[0], ToConfigurationVersion: cvs[1]}
+	if cvs[0].ID == cvs[1].ID {
+		return diff, nil
+	}
+
+	var files [2]map[string][sha256.Size]byte
+	for i, cv := range cvs {
+		archive, err := s.client.ConfigurationVersions.Download(ctx, cv.ID)
+		if err != nil {
+			return nil, err
+		}
+		files[i], err = archiveChecksums(archive)
+		if err != nil {
+			return nil, fmt.Errorf("reading configuration version %s: %v", cv.ID, err)
+		}
+	}
+
+	for name, sum := range files[1] {
+		old, ok := files[0][name]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, name)
+		case old != sum:
+			diff.Modified = append(diff.Modified, name)
+		}
+	}
+	for name := range files[0] {
+		if _, ok := files[1][name]; !ok {
+			diff.Removed = append(diff.Removed, name)
+		}
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Strings(diff.Modified)
+
+	return diff, nil
+}
+
+// archiveChecksums returns the checksums of the regular files of a gzipped tar archive.
+func archiveChecksums(archive []byte) (map[string][sha256.Size]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	files := make(map[string][sha256.Size]byte)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		h := sha256.New()
+		if _, err := io.Copy(h, tr); err != nil {
+			return nil, err
+		}
+		var sum [sha256.Size]byte
+		copy(sum[:], h.Sum(nil))
+		files[path.Clean(hdr.Name)] = sum
+	}
+
+	return files, nil
+}
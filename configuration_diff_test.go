@@ -0,0 +1,96 @@
+package scalr
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testArchive(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestRunsConfigurationDiff(t *testing.T) {
+	archives := map[string][]byte{
+		"cv-1": testArchive(t, map[string]string{
+			"main.tf":      `resource "null_resource" "a" {}`,
+			"variables.tf": `variable "a" {}`,
+			"outputs.tf":   `output "a" { value = 1 }`,
+		}),
+		"cv-2": testArchive(t, map[string]string{
+			"main.tf":             `resource "null_resource" "b" {}`,
+			"variables.tf":        `variable "a" {}`,
+			"./modules/x/main.tf": `resource "null_resource" "x" {}`,
+		}),
+	}
+	runs := map[string]string{"run-1": "cv-1", "run-2": "cv-2", "run-3": "cv-2"}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for runID, cvID := range runs {
+			if r.URL.Path == "/api/iacp/v3/runs/"+runID {
+				w.Header().Set("Content-Type", "application/vnd.api+json")
+				fmt.Fprintf(w, `{"data":{"id":%q,"type":"runs","relationships":{"configuration-version":{"data":{"id":%q,"type":"configuration-versions"}}}}}`,
+					runID, cvID)
+				return
+			}
+		}
+		for cvID, archive := range archives {
+			if r.URL.Path == "/api/iacp/v3/configuration-versions/"+cvID+"/download" {
+				_, _ = w.Write(archive)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("with different configuration versions", func(t *testing.T) {
+		diff, err := client.Runs.ConfigurationDiff(ctx, "run-1", "run-2")
+		require.NoError(t, err)
+		assert.Equal(t, "cv-1", diff.FromConfigurationVersion.ID)
+		assert.Equal(t, "cv-2", diff.ToConfigurationVersion.ID)
+		assert.Equal(t, []string{"modules/x/main.tf"}, diff.Added)
+		assert.Equal(t, []string{"outputs.tf"}, diff.Removed)
+		assert.Equal(t, []string{"main.tf"}, diff.Modified)
+		assert.False(t, diff.Empty())
+	})
+
+	t.Run("with the same configuration version", func(t *testing.T) {
+		diff, err := client.Runs.ConfigurationDiff(ctx, "run-2", "run-3")
+		require.NoError(t, err)
+		assert.True(t, diff.Empty())
+	})
+
+	t.Run("with invalid run ID", func(t *testing.T) {
+		diff, err := client.Runs.ConfigurationDiff(ctx, badIdentifier, "run-2")
+		assert.Nil(t, diff)
+		assert.EqualError(t, err, "invalid value for source run ID")
+	})
+}
@@ -1,6 +1,7 @@
 package scalr
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -19,6 +20,9 @@ type ConfigurationVersions interface {
 
 	// Read a configuration version by its ID.
 	Read(ctx context.Context, cvID string) (*ConfigurationVersion, error)
+
+	// Download the configuration version archive.
+	Download(ctx context.Context, cvID string) ([]byte, error)
 }
 
 // configurationVersions implements ConfigurationVersions.
@@ -108,3 +112,24 @@ func (s *configurationVersions) Read(ctx context.Context, cvID string) (*Configu
 
 	return cv, nil
 }
+
+// Download the gzipped tar archive of the configuration files of the configuration version.
+func (s *configurationVersions) Download(ctx context.Context, cvID string) ([]byte, error) {
+	if !validStringID(&cvID) {
+		return nil, errors.New("invalid value for configuration version ID")
+	}
+
+	u := fmt.Sprintf("configuration-versions/%s/download", url.QueryEscape(cvID))
+	req, err := s.client.newRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = s.client.do(withTransfer(ctx, transferDownload), req, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
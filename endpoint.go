@@ -14,6 +14,10 @@ var _ Endpoints = (*endpoints)(nil)
 // IACP API supports.
 //
 // IACP API docs: https://www.scalr.com/docs/en/latest/api/index.html
+//
+// Deprecated: Endpoints and Webhooks are replaced by WebhookIntegrations,
+// use NewWebhookIntegrationOptions to migrate. The service is going to be
+// removed in the next major version. Each call is reported to the WarningHandler.
 type Endpoints interface {
 	// List the endpoints.
 	List(ctx context.Context, options EndpointListOptions) (*EndpointList, error)
@@ -71,6 +75,7 @@ func (s *endpoints) List(ctx context.Context, options EndpointListOptions) (*End
 	if err != nil {
 		return nil, err
 	}
+	s.client.warnDeprecated(req, endpointsDeprecation)
 
 	el := &EndpointList{}
 	err = s.client.do(ctx, req, el)
@@ -119,6 +124,7 @@ func (s *endpoints) Create(ctx context.Context, options EndpointCreateOptions) (
 	if err != nil {
 		return nil, err
 	}
+	s.client.warnDeprecated(req, endpointsDeprecation)
 
 	w := &Endpoint{}
 	err = s.client.do(ctx, req, w)
@@ -140,6 +146,7 @@ func (s *endpoints) Read(ctx context.Context, endpointID string) (*Endpoint, err
 	if err != nil {
 		return nil, err
 	}
+	s.client.warnDeprecated(req, endpointsDeprecation)
 
 	e := &Endpoint{}
 	err = s.client.do(ctx, req, e)
@@ -174,6 +181,7 @@ func (s *endpoints) Update(ctx context.Context, endpointID string, options Endpo
 	if err != nil {
 		return nil, err
 	}
+	s.client.warnDeprecated(req, endpointsDeprecation)
 
 	e := &Endpoint{}
 	err = s.client.do(ctx, req, e)
@@ -195,6 +203,41 @@ func (s *endpoints) Delete(ctx context.Context, endpointID string) error {
 	if err != nil {
 		return err
 	}
+	s.client.warnDeprecated(req, endpointsDeprecation)
 
 	return s.client.do(ctx, req, nil)
 }
+
+// endpointsDeprecation is reported to the WarningHandler on each call of the Endpoints service.
+const endpointsDeprecation = "the endpoints API is deprecated, use the webhook integrations instead"
+
+// NewWebhookIntegrationOptions returns the options to create a webhook integration
+// replacing the endpoint and, if given, the webhook that sends the events to it.
+// The environment scoped endpoint is converted into an integration limited to that environment.
+func NewWebhookIntegrationOptions(endpoint *Endpoint, webhook *Webhook) (WebhookIntegrationCreateOptions, error) {
+	if endpoint == nil {
+		return WebhookIntegrationCreateOptions{}, errors.New("endpoint is required")
+	}
+
+	options := WebhookIntegrationCreateOptions{
+		Name:        String(endpoint.Name),
+		Url:         String(endpoint.Url),
+		MaxAttempts: Int(endpoint.MaxAttempts),
+		Timeout:     Int(endpoint.Timeout),
+		Account:     endpoint.Account,
+	}
+	if endpoint.SecretKey != "" {
+		options.SecretKey = String(endpoint.SecretKey)
+	}
+	if endpoint.Environment != nil {
+		options.Environments = []*Environment{endpoint.Environment}
+	} else {
+		options.IsShared = Bool(true)
+	}
+	if webhook != nil {
+		options.Name = String(webhook.Name)
+		options.Enabled = Bool(webhook.Enabled)
+		options.Events = webhook.Events
+	}
+	return options, nil
+}
@@ -0,0 +1,91 @@
+package scalr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointsDeprecationWarning(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		if r.URL.Path == "/api/iacp/v3/webhooks/wh-123" {
+			fmt.Fprint(w, `{"data":{"id":"wh-123","type":"webhooks","attributes":{"name":"hook"}}}`)
+			return
+		}
+		fmt.Fprint(w, `{"data":{"id":"ep-123","type":"endpoints","attributes":{"name":"hook"}}}`)
+	}))
+	defer ts.Close()
+
+	var warnings []*APIWarning
+	client, err := NewClient(&Config{
+		Address:        ts.URL,
+		Token:          "dummy-token",
+		HTTPClient:     ts.Client(),
+		WarningHandler: func(w *APIWarning) { warnings = append(warnings, w) },
+	})
+	require.NoError(t, err)
+
+	_, err = client.Endpoints.Read(context.Background(), "ep-123")
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	assert.Equal(t, &APIWarning{
+		Method:     "GET",
+		Path:       "/api/iacp/v3/endpoints/ep-123",
+		Messages:   []string{endpointsDeprecation},
+		Deprecated: true,
+	}, warnings[0])
+
+	_, err = client.Webhooks.Read(context.Background(), "wh-123")
+	require.NoError(t, err)
+	require.Len(t, warnings, 2)
+	assert.Equal(t, []string{webhooksDeprecation}, warnings[1].Messages)
+}
+
+func TestNewWebhookIntegrationOptions(t *testing.T) {
+	endpoint := &Endpoint{
+		Name:        "endpoint",
+		Url:         "https://hooks.example.com",
+		MaxAttempts: 3,
+		Timeout:     15,
+		SecretKey:   "secret",
+		Environment: &Environment{ID: "env-123"},
+		Account:     &Account{ID: "acc-123"},
+	}
+
+	t.Run("with webhook", func(t *testing.T) {
+		webhook := &Webhook{Name: "webhook", Enabled: true, Events: []*EventDefinition{{ID: "run:completed"}}}
+		options, err := NewWebhookIntegrationOptions(endpoint, webhook)
+		require.NoError(t, err)
+		assert.Equal(t, WebhookIntegrationCreateOptions{
+			Name:         String("webhook"),
+			Enabled:      Bool(true),
+			Url:          String("https://hooks.example.com"),
+			SecretKey:    String("secret"),
+			Timeout:      Int(15),
+			MaxAttempts:  Int(3),
+			Environments: []*Environment{{ID: "env-123"}},
+			Account:      &Account{ID: "acc-123"},
+			Events:       []*EventDefinition{{ID: "run:completed"}},
+		}, options)
+	})
+
+	t.Run("with account endpoint", func(t *testing.T) {
+		options, err := NewWebhookIntegrationOptions(&Endpoint{Name: "endpoint", Url: "https://hooks.example.com"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "endpoint", *options.Name)
+		assert.True(t, *options.IsShared)
+		assert.Nil(t, options.SecretKey)
+		assert.Empty(t, options.Environments)
+	})
+
+	t.Run("without endpoint", func(t *testing.T) {
+		_, err := NewWebhookIntegrationOptions(nil, nil)
+		assert.EqualError(t, err, "endpoint is required")
+	})
+}
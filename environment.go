@@ -16,10 +16,14 @@ var _ Environments = (*environments)(nil)
 type Environments interface {
 	List(ctx context.Context, options EnvironmentListOptions) (*EnvironmentList, error)
 	Read(ctx context.Context, environmentID string) (*Environment, error)
+	ReadWithOptions(ctx context.Context, environmentID string, options EnvironmentReadOptions) (*Environment, error)
 	Create(ctx context.Context, options EnvironmentCreateOptions) (*Environment, error)
 	Update(ctx context.Context, environmentID string, options EnvironmentUpdateOptions) (*Environment, error)
+	// Deprecated: use ProviderConfigurationLinks.SetEnvironmentDefault and
+	// ProviderConfigurationLinks.UnsetEnvironmentDefault.
 	UpdateDefaultProviderConfigurationOnly(ctx context.Context, environmentID string, options EnvironmentUpdateOptionsDefaultProviderConfigurationOnly) (*Environment, error)
 	Delete(ctx context.Context, environmentID string) error
+	ListEvents(ctx context.Context, environmentID string, options EnvironmentEventListOptions) (*EnvironmentEventList, error)
 }
 
 // environments implements Environments.
@@ -51,12 +55,16 @@ type Environment struct {
 	Status                EnvironmentStatus `jsonapi:"attr,status"`
 
 	// Relations
-	Account                       *Account                 `jsonapi:"relation,account"`
-	PolicyGroups                  []*PolicyGroup           `jsonapi:"relation,policy-groups"`
+	Account      *Account       `jsonapi:"relation,account"`
+	PolicyGroups []*PolicyGroup `jsonapi:"relation,policy-groups"`
+	// Deprecated: use ProviderConfigurationLinks.ListEnvironmentDefaults. The environment
+	// defaults are the default environment provider configuration links, this legacy
+	// relationship is kept for compatibility and is not read by ResolveForWorkspace.
 	DefaultProviderConfigurations []*ProviderConfiguration `jsonapi:"relation,default-provider-configurations"`
 	ProviderConfigurations        []*ProviderConfiguration `jsonapi:"relation,provider-configurations"`
 	CreatedBy                     *User                    `jsonapi:"relation,created-by"`
 	Tags                          []*Tag                   `jsonapi:"relation,tags"`
+	DefaultAgentPool              *AgentPool               `jsonapi:"relation,default-agent-pool"`
 }
 
 // Organization is Environment included in Workspace - always prefer Environment
@@ -79,8 +87,10 @@ type EnvironmentCreateOptions struct {
 	CostEstimationEnabled *bool   `jsonapi:"attr,cost-estimation-enabled,omitempty"`
 
 	// Relations
-	Account                       *Account                 `jsonapi:"relation,account"`
-	PolicyGroups                  []*PolicyGroup           `jsonapi:"relation,policy-groups,omitempty"`
+	Account      *Account       `jsonapi:"relation,account"`
+	PolicyGroups []*PolicyGroup `jsonapi:"relation,policy-groups,omitempty"`
+	// Deprecated: use ProviderConfigurationLinks.SetEnvironmentDefault once the
+	// environment is created.
 	DefaultProviderConfigurations []*ProviderConfiguration `jsonapi:"relation,default-provider-configurations,omitempty"`
 
 	// Specifies tags assigned to the environment
@@ -100,23 +110,43 @@ func (o EnvironmentCreateOptions) valid() error {
 	return nil
 }
 
+// List of attributes environments can be sorted by.
+const (
+	EnvironmentSortByName      SortKey = "name"
+	EnvironmentSortByCreatedAt SortKey = "created-at"
+)
+
+// EnvironmentListOptions represents the options for listing environments.
 type EnvironmentListOptions struct {
 	ListOptions
 
 	Include *string            `url:"include,omitempty"`
 	Filter  *EnvironmentFilter `url:"filter,omitempty"`
+
+	// The comma-separated list of attributes, see SortBy.
+	Sort *string `url:"sort,omitempty"`
 }
 
 // EnvironmentFilter represents the options for filtering environments.
 type EnvironmentFilter struct {
-	Id      *string `url:"environment,omitempty"`
-	Account *string `url:"account,omitempty"`
-	Name    *string `url:"name,omitempty"`
-	Tag     *string `url:"tag,omitempty"`
+	Id        *string `url:"environment,omitempty"`
+	Account   *string `url:"account,omitempty"`
+	Name      *string `url:"name,omitempty"`
+	Tag       *string `url:"tag,omitempty"`
+	CreatedBy *string `url:"created-by,omitempty"`
+
+	Status *EnvironmentStatus `url:"status,omitempty"`
+
+	// Filter by the creation time, see TimeRange.
+	CreatedAt *TimeRangeFilter `url:"created-at,omitempty"`
 }
 
 // List all the environmens.
 func (s *environments) List(ctx context.Context, options EnvironmentListOptions) (*EnvironmentList, error) {
+	if err := validSort(options.Sort, EnvironmentSortByName, EnvironmentSortByCreatedAt); err != nil {
+		return nil, err
+	}
+
 	req, err := s.client.newRequest("GET", "environments", &options)
 	if err != nil {
 		return nil, err
@@ -154,15 +184,22 @@ func (s *environments) Create(ctx context.Context, options EnvironmentCreateOpti
 
 // Read an environment by its ID.
 func (s *environments) Read(ctx context.Context, environmentID string) (*Environment, error) {
+	return s.ReadWithOptions(ctx, environmentID, EnvironmentReadOptions{Include: "created-by"})
+}
+
+// EnvironmentReadOptions represents the options for reading an environment.
+type EnvironmentReadOptions struct {
+	// The comma-separated list of relationships to include, e.g.
+	// "created-by,policy-groups,tags".
+	Include string `url:"include,omitempty"`
+}
+
+// ReadWithOptions reads an environment by its ID along with the included relationships.
+func (s *environments) ReadWithOptions(ctx context.Context, environmentID string, options EnvironmentReadOptions) (*Environment, error) {
 	if !validStringID(&environmentID) {
 		return nil, errors.New("invalid value for environment ID")
 	}
 
-	options := struct {
-		Include string `url:"include"`
-	}{
-		Include: "created-by",
-	}
 	u := fmt.Sprintf("environments/%s", url.QueryEscape(environmentID))
 	req, err := s.client.newRequest("GET", u, options)
 	if err != nil {
@@ -186,10 +223,17 @@ type EnvironmentUpdateOptions struct {
 	CostEstimationEnabled *bool   `jsonapi:"attr,cost-estimation-enabled,omitempty"`
 
 	// Relations
-	PolicyGroups                  []*PolicyGroup           `jsonapi:"relation,policy-groups"`
+	PolicyGroups []*PolicyGroup `jsonapi:"relation,policy-groups"`
+	// Deprecated: use ProviderConfigurationLinks.SetEnvironmentDefault and
+	// ProviderConfigurationLinks.UnsetEnvironmentDefault.
 	DefaultProviderConfigurations []*ProviderConfiguration `jsonapi:"relation,default-provider-configurations"`
 }
 
+// EnvironmentUpdateOptionsDefaultProviderConfigurationOnly represents the options
+// for updating the legacy default provider configurations of an environment.
+//
+// Deprecated: use ProviderConfigurationLinks.SetEnvironmentDefault and
+// ProviderConfigurationLinks.UnsetEnvironmentDefault.
 type EnvironmentUpdateOptionsDefaultProviderConfigurationOnly struct {
 	ID string `jsonapi:"primary,environments"`
 	// Relations
@@ -216,6 +260,11 @@ func (s *environments) Update(ctx context.Context, environmentID string, options
 	return env, nil
 }
 
+// UpdateDefaultProviderConfigurationOnly replaces the legacy default provider
+// configurations of an environment.
+//
+// Deprecated: use ProviderConfigurationLinks.SetEnvironmentDefault and
+// ProviderConfigurationLinks.UnsetEnvironmentDefault.
 func (s *environments) UpdateDefaultProviderConfigurationOnly(ctx context.Context, environmentID string, options EnvironmentUpdateOptionsDefaultProviderConfigurationOnly) (*Environment, error) {
 	options.ID = ""
 
@@ -0,0 +1,82 @@
+package scalr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// EnvironmentEventKind represents the kind of an environment activity event.
+type EnvironmentEventKind string
+
+// List all available environment event kinds.
+const (
+	EnvironmentEventWorkspaceCreated EnvironmentEventKind = "workspace-created"
+	EnvironmentEventWorkspaceDeleted EnvironmentEventKind = "workspace-deleted"
+	EnvironmentEventRunApplied       EnvironmentEventKind = "run-applied"
+	EnvironmentEventRunErrored       EnvironmentEventKind = "run-errored"
+	EnvironmentEventVariableChanged  EnvironmentEventKind = "variable-changed"
+)
+
+// EnvironmentEventList represents a list of environment events.
+type EnvironmentEventList struct {
+	*Pagination
+	Items []*EnvironmentEvent
+}
+
+// EnvironmentEvent represents a single entry of the environment activity stream.
+type EnvironmentEvent struct {
+	ID          string               `jsonapi:"primary,environment-events"`
+	Kind        EnvironmentEventKind `jsonapi:"attr,kind"`
+	Description string               `jsonapi:"attr,description"`
+	CreatedAt   time.Time            `jsonapi:"attr,created-at,iso8601"`
+
+	// Relations, the ones related to the kind of the event are set.
+	CreatedBy *User      `jsonapi:"relation,created-by"`
+	Workspace *Workspace `jsonapi:"relation,workspace"`
+	Run       *Run       `jsonapi:"relation,run"`
+	Variable  *Variable  `jsonapi:"relation,variable"`
+}
+
+// EnvironmentEventListOptions represents the options for listing environment events.
+type EnvironmentEventListOptions struct {
+	ListOptions
+
+	// Filter by the event kind, use EnvironmentEventKindsFilter to match any of several kinds.
+	Kind *string `url:"filter[kind],omitempty"`
+
+	// Filter by the event time.
+	CreatedAt *TimeRangeFilter `url:"filter[created-at],omitempty"`
+
+	Include string `url:"include,omitempty"`
+}
+
+// EnvironmentEventKindsFilter returns the kind filter matching any of the given kinds,
+// or nil if none is given.
+func EnvironmentEventKindsFilter(kinds ...EnvironmentEventKind) *string {
+	return inFilter(kinds)
+}
+
+// ListEvents lists the recent activity of an environment, the latest events
+// first, e.g. the workspaces created, the runs applied and the variables changed.
+func (s *environments) ListEvents(ctx context.Context, environmentID string, options EnvironmentEventListOptions) (*EnvironmentEventList, error) {
+	if !validStringID(&environmentID) {
+		return nil, errors.New("invalid value for environment ID")
+	}
+
+	u := fmt.Sprintf("environments/%s/events", url.QueryEscape(environmentID))
+	req, err := s.client.newRequest("GET", u, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	el := &EnvironmentEventList{}
+	err = s.client.do(ctx, req, el)
+	if err != nil {
+		return nil, err
+	}
+
+	return el, nil
+}
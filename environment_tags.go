@@ -2,6 +2,7 @@ package scalr
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 )
@@ -12,8 +13,11 @@ var _ EnvironmentTags = (*environmentTag)(nil)
 // EnvironmentTags describes all the environment tags related methods that the
 // Scalr API supports.
 type EnvironmentTags interface {
+	// Add attaches the tags to the environment, keeping the ones it already has.
 	Add(ctx context.Context, envID string, tags []*TagRelation) error
+	// Replace sets the tags of the environment, an empty list removes all of them.
 	Replace(ctx context.Context, envID string, tags []*TagRelation) error
+	// Delete detaches the tags from the environment.
 	Delete(ctx context.Context, envID string, tags []*TagRelation) error
 }
 
@@ -22,8 +26,15 @@ type environmentTag struct {
 	client *Client
 }
 
-// Add tags to the environment
+// Add tags to the environment.
 func (s *environmentTag) Add(ctx context.Context, envID string, trs []*TagRelation) error {
+	if !validStringID(&envID) {
+		return errors.New("invalid value for environment ID")
+	}
+	if err := validTagRelations(trs, false); err != nil {
+		return err
+	}
+
 	u := fmt.Sprintf("environments/%s/relationships/tags", url.QueryEscape(envID))
 	req, err := s.client.newRequest("POST", u, trs)
 	if err != nil {
@@ -33,8 +44,15 @@ func (s *environmentTag) Add(ctx context.Context, envID string, trs []*TagRelati
 	return s.client.do(ctx, req, nil)
 }
 
-// Replace environment's tags
+// Replace environment's tags.
 func (s *environmentTag) Replace(ctx context.Context, envID string, trs []*TagRelation) error {
+	if !validStringID(&envID) {
+		return errors.New("invalid value for environment ID")
+	}
+	if err := validTagRelations(trs, true); err != nil {
+		return err
+	}
+
 	u := fmt.Sprintf("environments/%s/relationships/tags", url.QueryEscape(envID))
 	req, err := s.client.newRequest("PATCH", u, trs)
 	if err != nil {
@@ -44,8 +62,15 @@ func (s *environmentTag) Replace(ctx context.Context, envID string, trs []*TagRe
 	return s.client.do(ctx, req, nil)
 }
 
-// Delete environment's tags
+// Delete environment's tags.
 func (s *environmentTag) Delete(ctx context.Context, envID string, trs []*TagRelation) error {
+	if !validStringID(&envID) {
+		return errors.New("invalid value for environment ID")
+	}
+	if err := validTagRelations(trs, false); err != nil {
+		return err
+	}
+
 	u := fmt.Sprintf("environments/%s/relationships/tags", url.QueryEscape(envID))
 	req, err := s.client.newRequest("DELETE", u, trs)
 	if err != nil {
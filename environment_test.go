@@ -3,8 +3,13 @@ package scalr
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
+	"github.com/google/go-querystring/query"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -81,6 +86,47 @@ func TestEnvironmentsList(t *testing.T) {
 		}
 	})
 
+	t.Run("with filter by created-at and sort option", func(t *testing.T) {
+		envl, err := client.Environments.List(ctx, EnvironmentListOptions{
+			Filter: &EnvironmentFilter{
+				Id:        &envTest1.ID,
+				CreatedAt: TimeRange(envTest1.CreatedAt.Add(-time.Minute), time.Time{}),
+			},
+			Sort: SortBy(SortDesc(EnvironmentSortByCreatedAt)),
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(envl.Items))
+		assert.Equal(t, envTest1.ID, envl.Items[0].ID)
+	})
+
+	t.Run("with filter by several tags", func(t *testing.T) {
+		tag1, tag1Cleanup := createTag(t, client)
+		defer tag1Cleanup()
+		tag2, tag2Cleanup := createTag(t, client)
+		defer tag2Cleanup()
+
+		envTest2, envTest2Cleanup := createEnvironment(t, client)
+		defer envTest2Cleanup()
+
+		require.NoError(t, client.EnvironmentTags.Add(ctx, envTest1.ID, []*TagRelation{{ID: tag1.ID}}))
+		require.NoError(t, client.EnvironmentTags.Add(ctx, envTest2.ID, []*TagRelation{{ID: tag2.ID}}))
+
+		envl, err := client.Environments.List(ctx, EnvironmentListOptions{
+			Filter: &EnvironmentFilter{Tag: TagsFilter(tag1.ID, tag2.ID)},
+		})
+		require.NoError(t, err)
+		envlIDs := make([]string, 0, len(envl.Items))
+		for _, env := range envl.Items {
+			envlIDs = append(envlIDs, env.ID)
+		}
+		assert.ElementsMatch(t, []string{envTest1.ID, envTest2.ID}, envlIDs)
+	})
+
+	t.Run("with invalid sort option", func(t *testing.T) {
+		envl, err := client.Environments.List(ctx, EnvironmentListOptions{Sort: String("status")})
+		assert.Nil(t, envl)
+		assert.EqualError(t, err, "invalid value for sort: 'status'")
+	})
 }
 
 func TestEnvironmentsCreate(t *testing.T) {
@@ -279,3 +325,149 @@ func TestEnvironmentsDelete(t *testing.T) {
 		)
 	})
 }
+
+func TestTimeRange(t *testing.T) {
+	from := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	to := from.Add(time.Hour)
+
+	assert.Equal(t, "gte:2022-01-02T03:04:05Z,lte:2022-01-02T04:04:05Z", TimeRange(from, to).String())
+	assert.Equal(t, "gte:2022-01-02T03:04:05Z", TimeRange(from, time.Time{}).String())
+	assert.Equal(t, "lte:2022-01-02T04:04:05Z", TimeRange(time.Time{}, to).String())
+}
+
+func TestInFilter(t *testing.T) {
+	assert.Equal(t, "pending", *RunStatusesFilter(RunPending))
+	assert.Equal(t, "in:pending,plan_queued", *RunStatusesFilter(RunPending, RunPlanQueued))
+	assert.Nil(t, RunStatusesFilter())
+	assert.Nil(t, EnvironmentEventKindsFilter())
+}
+
+func TestTimeRangeFilter(t *testing.T) {
+	from := time.Date(2022, 1, 2, 5, 4, 5, 0, time.FixedZone("EET", 2*60*60))
+	to := from.Add(time.Hour)
+
+	options := struct {
+		Bounded *TimeRangeFilter `url:"filter[bounded],omitempty"`
+		Open    TimeRangeFilter  `url:"filter[open]"`
+		Omitted *TimeRangeFilter `url:"filter[omitted],omitempty"`
+		Unbound TimeRangeFilter  `url:"filter[unbound]"`
+	}{
+		Bounded: &TimeRangeFilter{After: from, Before: to},
+		Open:    TimeRangeFilter{Before: to},
+	}
+
+	v, err := query.Values(options)
+	require.NoError(t, err)
+	assert.Equal(t, url.Values{
+		"filter[bounded]": {"gte:2022-01-02T03:04:05Z,lte:2022-01-02T04:04:05Z"},
+		"filter[open]":    {"lte:2022-01-02T04:04:05Z"},
+	}, v)
+}
+
+func TestEnvironmentListOptionsEncoding(t *testing.T) {
+	status := EnvironmentStatusInactive
+	options := EnvironmentListOptions{
+		Include: String("created-by"),
+		Filter: &EnvironmentFilter{
+			Account: String("acc-1"),
+			Name:    String("prod"),
+			Tag:     String("tag-1"),
+			Status:  &status,
+		},
+	}
+
+	v, err := query.Values(options)
+	require.NoError(t, err)
+	assert.Equal(t, url.Values{
+		"include":         {"created-by"},
+		"filter[account]": {"acc-1"},
+		"filter[name]":    {"prod"},
+		"filter[tag]":     {"tag-1"},
+		"filter[status]":  {"Inactive"},
+	}, v)
+}
+
+func TestEnvironmentsReadWithOptions(t *testing.T) {
+	var include string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/iacp/v3/environments/env-1", r.URL.Path)
+		include = r.URL.Query().Get("include")
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		fmt.Fprint(w, `{"data":{"id":"env-1","type":"environments","attributes":{"name":"prod"},"relationships":{`+
+			`"policy-groups":{"data":[{"id":"pgrp-1","type":"policy-groups"}]},`+
+			`"default-provider-configurations":{"data":[{"id":"pcfg-1","type":"provider-configurations"}]}}},`+
+			`"included":[{"id":"pgrp-1","type":"policy-groups","attributes":{"name":"cis"}},`+
+			`{"id":"pcfg-1","type":"provider-configurations","attributes":{"name":"aws"}}]}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("with include", func(t *testing.T) {
+		env, err := client.Environments.ReadWithOptions(ctx, "env-1", EnvironmentReadOptions{
+			Include: "policy-groups,default-provider-configurations",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "policy-groups,default-provider-configurations", include)
+		require.Len(t, env.PolicyGroups, 1)
+		assert.Equal(t, "cis", env.PolicyGroups[0].Name)
+		require.Len(t, env.DefaultProviderConfigurations, 1)
+		assert.Equal(t, "aws", env.DefaultProviderConfigurations[0].Name)
+	})
+
+	t.Run("read includes the creator", func(t *testing.T) {
+		_, err := client.Environments.Read(ctx, "env-1")
+		require.NoError(t, err)
+		assert.Equal(t, "created-by", include)
+	})
+
+	t.Run("with invalid environment ID", func(t *testing.T) {
+		env, err := client.Environments.ReadWithOptions(ctx, badIdentifier, EnvironmentReadOptions{})
+		assert.Nil(t, env)
+		assert.EqualError(t, err, "invalid value for environment ID")
+	})
+}
+
+func TestEnvironmentsListEvents(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/iacp/v3/environments/env-1/events", r.URL.Path)
+		assert.Equal(t, "in:run-applied,variable-changed", r.URL.Query().Get("filter[kind]"))
+		assert.Equal(t, "2", r.URL.Query().Get("page[number]"))
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		fmt.Fprint(w, `{"data":[{"id":"envev-1","type":"environment-events","attributes":{"kind":"run-applied",`+
+			`"description":"Run applied","created-at":"2023-01-02T03:04:05Z"},"relationships":{`+
+			`"workspace":{"data":{"id":"ws-1","type":"workspaces"}},"run":{"data":{"id":"run-1","type":"runs"}}}},`+
+			`{"id":"envev-2","type":"environment-events","attributes":{"kind":"variable-changed"},"relationships":{`+
+			`"variable":{"data":{"id":"var-1","type":"vars"}}}}],`+
+			`"meta":{"pagination":{"current-page":2,"prev-page":1,"total-pages":2,"total-count":3}}}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("with kinds filter", func(t *testing.T) {
+		el, err := client.Environments.ListEvents(ctx, "env-1", EnvironmentEventListOptions{
+			ListOptions: ListOptions{PageNumber: 2},
+			Kind:        EnvironmentEventKindsFilter(EnvironmentEventRunApplied, EnvironmentEventVariableChanged),
+		})
+		require.NoError(t, err)
+		require.Len(t, el.Items, 2)
+		assert.Equal(t, 3, el.TotalCount)
+
+		assert.Equal(t, EnvironmentEventRunApplied, el.Items[0].Kind)
+		assert.Equal(t, "ws-1", el.Items[0].Workspace.ID)
+		assert.Equal(t, "run-1", el.Items[0].Run.ID)
+		assert.Equal(t, EnvironmentEventVariableChanged, el.Items[1].Kind)
+		assert.Equal(t, "var-1", el.Items[1].Variable.ID)
+	})
+
+	t.Run("with invalid environment ID", func(t *testing.T) {
+		el, err := client.Environments.ListEvents(ctx, badIdentifier, EnvironmentEventListOptions{})
+		assert.Nil(t, el)
+		assert.EqualError(t, err, "invalid value for environment ID")
+	})
+}
@@ -3,7 +3,6 @@ package main
 import (
 	"context"
 	"log"
-	"net/http"
 
 	scalr "github.com/scalr/go-scalr"
 )
@@ -13,9 +12,8 @@ func main() {
 		Address:  "https://<example>.scalr.io",
 		BasePath: "/api/iacp/v3/",
 		Token:    "<your token>",
-		Headers:  make(http.Header),
+		Profile:  scalr.ProfileInternal,
 	}
-	config.Headers.Set("Prefer", "profile=internal")
 
 	client, err := scalr.NewClient(config)
 	if err != nil {
@@ -0,0 +1,238 @@
+// Package export streams the paged lists of the Scalr API into CSV or
+// newline-delimited JSON writers, e.g. for periodic compliance exports.
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	scalr "github.com/mermoldy/go-scalr/v2"
+	"github.com/mermoldy/go-scalr/v2/internal/jsonapifield"
+)
+
+// Format represents the output format of an export.
+type Format string
+
+// List of available export formats.
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+)
+
+// Options represents the options of an export.
+type Options struct {
+	// The output format, defaults to FormatCSV.
+	Format Format
+
+	// The exported fields, in order, by their JSON:API attribute or relationship
+	// name, e.g. "id", "email" or "teams". The relationships are exported as the
+	// IDs of the related resources. All the fields are exported if empty.
+	Fields []string
+}
+
+// Users exports all the users matching the list options and returns their number.
+func Users(ctx context.Context, client *scalr.Client, w io.Writer, options scalr.UserListOptions, opts Options) (int, error) {
+	return export(w, opts, func(page int) ([]*scalr.User, *scalr.Pagination, error) {
+		options.PageNumber = page
+		l, err := client.Users.List(ctx, options)
+		if err != nil {
+			return nil, nil, err
+		}
+		return l.Items, l.Pagination, nil
+	})
+}
+
+// Teams exports all the teams matching the list options and returns their number.
+func Teams(ctx context.Context, client *scalr.Client, w io.Writer, options scalr.TeamListOptions, opts Options) (int, error) {
+	return export(w, opts, func(page int) ([]*scalr.Team, *scalr.Pagination, error) {
+		options.PageNumber = page
+		l, err := client.Teams.List(ctx, options)
+		if err != nil {
+			return nil, nil, err
+		}
+		return l.Items, l.Pagination, nil
+	})
+}
+
+// Roles exports all the roles matching the list options and returns their number.
+func Roles(ctx context.Context, client *scalr.Client, w io.Writer, options scalr.RoleListOptions, opts Options) (int, error) {
+	return export(w, opts, func(page int) ([]*scalr.Role, *scalr.Pagination, error) {
+		options.PageNumber = page
+		l, err := client.Roles.List(ctx, options)
+		if err != nil {
+			return nil, nil, err
+		}
+		return l.Items, l.Pagination, nil
+	})
+}
+
+// AccessPolicies exports all the access policies matching the list options and returns their number.
+func AccessPolicies(
+	ctx context.Context, client *scalr.Client, w io.Writer, options scalr.AccessPolicyListOptions, opts Options,
+) (int, error) {
+	return export(w, opts, func(page int) ([]*scalr.AccessPolicy, *scalr.Pagination, error) {
+		options.PageNumber = page
+		l, err := client.AccessPolicies.List(ctx, options)
+		if err != nil {
+			return nil, nil, err
+		}
+		return l.Items, l.Pagination, nil
+	})
+}
+
+// export writes the items of all the pages returned by list, one record per item.
+func export[T any](w io.Writer, opts Options, list func(page int) ([]T, *scalr.Pagination, error)) (int, error) {
+	fields, err := jsonapifield.Select(reflect.TypeOf((*T)(nil)).Elem(), opts.Fields)
+	if err != nil {
+		return 0, err
+	}
+
+	var enc encoder
+	switch opts.Format {
+	case "", FormatCSV:
+		enc = &csvEncoder{w: csv.NewWriter(w)}
+	case FormatNDJSON:
+		enc = &ndjsonEncoder{w: w}
+	default:
+		return 0, fmt.Errorf("unsupported export format: %q", opts.Format)
+	}
+
+	if err := enc.header(fields); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	page := 0
+	for {
+		items, pagination, err := list(page)
+		if err != nil {
+			return count, err
+		}
+		for _, item := range items {
+			values := make([]interface{}, len(fields))
+			for i, f := range fields {
+				values[i] = jsonapifield.Value(reflect.ValueOf(item), f)
+			}
+			if err := enc.record(fields, values); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if pagination == nil || pagination.NextPage == 0 {
+			break
+		}
+		page = pagination.NextPage
+	}
+
+	return count, enc.flush()
+}
+
+// encoder writes the exported records in a specific format.
+type encoder interface {
+	header(fields []jsonapifield.Field) error
+	record(fields []jsonapifield.Field, values []interface{}) error
+	flush() error
+}
+
+// csvEncoder writes a header row followed by one row per record,
+// the multiple values of a field are separated by semicolons.
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func (e *csvEncoder) header(fields []jsonapifield.Field) error {
+	row := make([]string, len(fields))
+	for i, f := range fields {
+		row[i] = f.Name
+	}
+	return e.w.Write(row)
+}
+
+func (e *csvEncoder) record(_ []jsonapifield.Field, values []interface{}) error {
+	row := make([]string, len(values))
+	for i, v := range values {
+		row[i] = csvValue(v)
+	}
+	return e.w.Write(row)
+}
+
+func (e *csvEncoder) flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func csvValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	case []string:
+		return strings.Join(v, ";")
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.String {
+		return rv.String()
+	}
+	if rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return ""
+	}
+	return fmt.Sprint(reflect.Indirect(rv).Interface())
+}
+
+// ndjsonEncoder writes one JSON object per line, with the fields in their export order.
+type ndjsonEncoder struct {
+	w io.Writer
+}
+
+func (e *ndjsonEncoder) header([]jsonapifield.Field) error {
+	return nil
+}
+
+func (e *ndjsonEncoder) record(fields []jsonapifield.Field, values []interface{}) error {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(f.Name)
+		if err != nil {
+			return err
+		}
+		value := values[i]
+		if t, ok := value.(time.Time); ok && t.IsZero() {
+			value = nil
+		}
+		b, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(b)
+	}
+	buf.WriteString("}\n")
+	_, err := e.w.Write(buf.Bytes())
+	return err
+}
+
+func (e *ndjsonEncoder) flush() error {
+	return nil
+}
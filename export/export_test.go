@@ -0,0 +1,95 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	scalr "github.com/mermoldy/go-scalr/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsers(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/iacp/v3/users", r.URL.Path)
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		if r.URL.Query().Get("page[number]") == "2" {
+			fmt.Fprint(w, `{"data":[{"id":"user-2","type":"users","attributes":{"email":"bob@example.com",`+
+				`"status":"Inactive","created-at":"2023-01-02T03:04:05Z"}}],`+
+				`"meta":{"pagination":{"current-page":2,"prev-page":1,"total-pages":2,"total-count":2}}}`)
+			return
+		}
+		fmt.Fprint(w, `{"data":[{"id":"user-1","type":"users","attributes":{"email":"alice@example.com",`+
+			`"status":"Active","created-at":"2023-01-01T03:04:05Z"},`+
+			`"relationships":{"teams":{"data":[{"id":"team-1","type":"teams"},{"id":"team-2","type":"teams"}]}}}],`+
+			`"meta":{"pagination":{"current-page":1,"next-page":2,"total-pages":2,"total-count":2}}}`)
+	}))
+	defer ts.Close()
+
+	client, err := scalr.NewClient(&scalr.Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		n, err := Users(ctx, client, &buf, scalr.UserListOptions{}, Options{
+			Fields: []string{"id", "email", "status", "created-at", "teams"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+		assert.Equal(t, "id,email,status,created-at,teams\n"+
+			"user-1,alice@example.com,Active,2023-01-01T03:04:05Z,team-1;team-2\n"+
+			"user-2,bob@example.com,Inactive,2023-01-02T03:04:05Z,\n", buf.String())
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		var buf bytes.Buffer
+		n, err := Users(ctx, client, &buf, scalr.UserListOptions{}, Options{
+			Format: FormatNDJSON,
+			Fields: []string{"id", "teams", "last-login-at"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+		assert.Equal(t, `{"id":"user-1","teams":["team-1","team-2"],"last-login-at":null}`+"\n"+
+			`{"id":"user-2","teams":[],"last-login-at":null}`+"\n", buf.String())
+	})
+
+	t.Run("with unknown field", func(t *testing.T) {
+		var buf bytes.Buffer
+		n, err := Users(ctx, client, &buf, scalr.UserListOptions{}, Options{Fields: []string{"password"}})
+		assert.Equal(t, 0, n)
+		assert.EqualError(t, err, `unknown field "password" of User`)
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("with unsupported format", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := Users(ctx, client, &buf, scalr.UserListOptions{}, Options{Format: "xml"})
+		assert.EqualError(t, err, `unsupported export format: "xml"`)
+	})
+}
+
+func TestAccessPolicies(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/iacp/v3/access-policies", r.URL.Path)
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		fmt.Fprint(w, `{"data":[{"id":"ap-1","type":"access-policies","attributes":{"is-system":false},"relationships":{`+
+			`"roles":{"data":[{"id":"role-1","type":"roles"}]},"team":{"data":{"id":"team-1","type":"teams"}},`+
+			`"environment":{"data":{"id":"env-1","type":"environments"}}}}]}`)
+	}))
+	defer ts.Close()
+
+	client, err := scalr.NewClient(&scalr.Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	n, err := AccessPolicies(context.Background(), client, &buf, scalr.AccessPolicyListOptions{}, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, "id,is-system,roles,user,team,service-account,account,environment,workspace\n"+
+		"ap-1,false,role-1,,team-1,,,env-1,\n", buf.String())
+}
@@ -204,7 +204,7 @@ func createVariable(t *testing.T, client *Client, ws *Workspace, env *Environmen
 	ctx := context.Background()
 	v, err := client.Variables.Create(ctx, VariableCreateOptions{
 		Key:         String(randomVariableKey(t)),
-		Value:       String(randomString(t)),
+		Value:       SecretString(randomString(t)),
 		Category:    Category(CategoryEnv),
 		Description: String("Create by go-scalr test helper."),
 		Workspace:   ws,
@@ -232,7 +232,7 @@ func createVcsProvider(t *testing.T, client *Client, envs []*Environment) (*VcsP
 			Name:     String("tst-" + randomString(t)),
 			VcsType:  Github,
 			AuthType: PersonalToken,
-			Token:    os.Getenv("GITHUB_TOKEN"),
+			Token:    Secret(os.Getenv("GITHUB_TOKEN")),
 
 			Environments: envs,
 			Account:      &Account{ID: defaultAccountID},
@@ -401,7 +401,7 @@ func createProviderConfigurationScalr(t *testing.T, client *Client, providerName
 			Account:       &Account{ID: defaultAccountID},
 			Name:          String(configurationName),
 			ProviderName:  String(providerName),
-			ScalrToken:    String(scalrToken),
+			ScalrToken:    SecretString(scalrToken),
 			ScalrHostname: String(scalrHostname),
 		},
 	)
@@ -0,0 +1,96 @@
+// Package jsonapifield resolves the fields of the resource structs by their
+// JSON:API attribute or relationship names, e.g. "email" or "teams".
+package jsonapifield
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Field is an attribute or relationship field of a resource struct.
+type Field struct {
+	// The JSON:API name of the field, "id" for the primary key.
+	Name     string
+	Index    int
+	Relation bool
+}
+
+// All returns the fields of the resource struct type in their declaration order.
+func All(t reflect.Type) []Field {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var fields []Field
+	for i := 0; i < t.NumField(); i++ {
+		tag := strings.Split(t.Field(i).Tag.Get("jsonapi"), ",")
+		if len(tag) < 2 {
+			continue
+		}
+		f := Field{Name: tag[1], Index: i, Relation: tag[0] == "relation"}
+		if tag[0] == "primary" {
+			f.Name = "id"
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// Lookup returns the field of the resource struct type with the name.
+func Lookup(t reflect.Type, name string) (Field, bool) {
+	for _, f := range All(t) {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// Select returns the fields of the resource struct type with the names, in
+// their order, or all the fields if no name is given.
+func Select(t reflect.Type, names []string) ([]Field, error) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if len(names) == 0 {
+		return All(t), nil
+	}
+
+	fields := make([]Field, 0, len(names))
+	for _, name := range names {
+		f, ok := Lookup(t, name)
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q of %s", name, t.Name())
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// Value returns the value of the field of the resource struct, or of the
+// pointer to it. A relationship is replaced with the ID of the related
+// resource, nil if there is none, or with the IDs of the related resources.
+func Value(v reflect.Value, f Field) interface{} {
+	fv := reflect.Indirect(v).Field(f.Index)
+	if !f.Relation {
+		return fv.Interface()
+	}
+
+	switch fv.Kind() {
+	case reflect.Ptr:
+		if fv.IsNil() {
+			return nil
+		}
+		return fv.Elem().FieldByName("ID").String()
+	case reflect.Slice:
+		ids := make([]string, 0, fv.Len())
+		for i := 0; i < fv.Len(); i++ {
+			if item := fv.Index(i); !item.IsNil() {
+				ids = append(ids, item.Elem().FieldByName("ID").String())
+			}
+		}
+		return ids
+	}
+	return fv.Interface()
+}
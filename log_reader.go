@@ -0,0 +1,108 @@
+package scalr
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+)
+
+// defaultLogPollInterval is how often a log reader checks for new output
+// of a plan or apply in progress, unless the config sets PollInterval.
+const defaultLogPollInterval = 2 * time.Second
+
+// isLogFinal reports whether a plan or apply with the status produces no more output.
+func isLogFinal(status string) bool {
+	switch status {
+	case "finished", "errored", "canceled", "unreachable":
+		return true
+	}
+	return false
+}
+
+// logReader reads a log incrementally, requesting the output
+// after the already read offset until the log is complete.
+type logReader struct {
+	ctx    context.Context
+	client *Client
+	path   string
+	done   func() (bool, error)
+
+	offset int64
+	buf    bytes.Buffer
+	eof    bool
+}
+
+func newLogReader(ctx context.Context, client *Client, path string, done func() (bool, error)) *logReader {
+	return &logReader{ctx: ctx, client: client, path: path, done: done}
+}
+
+// Read implements io.Reader.
+func (r *logReader) Read(p []byte) (int, error) {
+	for r.buf.Len() == 0 {
+		if r.eof {
+			return 0, io.EOF
+		}
+
+		n, err := r.fetch()
+		if err != nil {
+			return 0, err
+		}
+		if n > 0 {
+			break
+		}
+
+		done, err := r.done()
+		if err != nil {
+			return 0, err
+		}
+		if done {
+			// Fetch once more, the output may have been
+			// appended right before the status changed.
+			if _, err := r.fetch(); err != nil {
+				return 0, err
+			}
+			r.eof = true
+			continue
+		}
+
+		select {
+		case <-r.ctx.Done():
+			return 0, r.ctx.Err()
+		case <-time.After(r.client.pollInterval(defaultLogPollInterval)):
+		}
+	}
+
+	return r.buf.Read(p)
+}
+
+// Close implements io.Closer.
+func (r *logReader) Close() error {
+	r.eof = true
+	r.buf.Reset()
+	return nil
+}
+
+// fetch appends the output after the current offset to the buffer.
+func (r *logReader) fetch() (int64, error) {
+	options := struct {
+		Offset int64 `url:"offset"`
+	}{
+		Offset: r.offset,
+	}
+
+	req, err := r.client.newRequest("GET", r.path, &options)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "text/plain")
+
+	before := r.buf.Len()
+	if err := r.client.do(withTransfer(r.ctx, transferDownload), req, &r.buf); err != nil {
+		return 0, err
+	}
+	n := int64(r.buf.Len() - before)
+	r.offset += n
+
+	return n, nil
+}
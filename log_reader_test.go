@@ -0,0 +1,112 @@
+package scalr
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveLogChunk writes the part of the log after the requested offset.
+func serveLogChunk(w http.ResponseWriter, r *http.Request, log string) {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	if offset < len(log) {
+		fmt.Fprint(w, log[offset:])
+	}
+}
+
+func TestPlansReadLogs(t *testing.T) {
+	var mu sync.Mutex
+	log, status := "Refreshing state...\n", "running"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/api/iacp/v3/plans/plan-123":
+			w.Header().Set("Content-Type", "application/vnd.api+json")
+			fmt.Fprintf(w, `{"data":{"id":"plan-123","type":"plans","attributes":{"status":%q}}}`, status)
+			// The plan progresses on every status check.
+			if status == "running" {
+				log += "Plan: 1 to add, 0 to change, 0 to destroy.\n"
+				status = "finished"
+			}
+		case "/api/iacp/v3/plans/plan-123/logs":
+			serveLogChunk(w, r, log)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client(), PollInterval: time.Millisecond})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("when the plan is in progress", func(t *testing.T) {
+		rc, err := client.Plans.ReadLogs(ctx, "plan-123")
+		require.NoError(t, err)
+		defer rc.Close()
+
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		assert.Equal(t, "Refreshing state...\nPlan: 1 to add, 0 to change, 0 to destroy.\n", string(content))
+	})
+
+	t.Run("when the plan does not exist", func(t *testing.T) {
+		rc, err := client.Plans.ReadLogs(ctx, "plan-missing")
+		require.NoError(t, err)
+		defer rc.Close()
+
+		_, err = io.ReadAll(rc)
+		assert.ErrorIs(t, err, ErrResourceNotFound)
+	})
+
+	t.Run("with invalid plan ID", func(t *testing.T) {
+		rc, err := client.Plans.ReadLogs(ctx, badIdentifier)
+		assert.Nil(t, rc)
+		assert.EqualError(t, err, "invalid value for plan ID")
+	})
+}
+
+func TestAppliesReadLogs(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/iacp/v3/applies/apply-123":
+			w.Header().Set("Content-Type", "application/vnd.api+json")
+			fmt.Fprint(w, `{"data":{"id":"apply-123","type":"applies","attributes":{"status":"errored"}}}`)
+		case "/api/iacp/v3/applies/apply-123/logs":
+			serveLogChunk(w, r, "Error: creating instance\n")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("when the apply errored", func(t *testing.T) {
+		rc, err := client.Applies.ReadLogs(ctx, "apply-123")
+		require.NoError(t, err)
+		defer rc.Close()
+
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		assert.Equal(t, "Error: creating instance\n", string(content))
+	})
+
+	t.Run("with invalid apply ID", func(t *testing.T) {
+		rc, err := client.Applies.ReadLogs(ctx, badIdentifier)
+		assert.Nil(t, rc)
+		assert.EqualError(t, err, "invalid value for apply ID")
+	})
+}
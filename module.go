@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 	"time"
 )
 
@@ -23,6 +24,10 @@ type Modules interface {
 	ReadBySource(ctx context.Context, moduleSource string) (*Module, error)
 	// Delete a module by its ID.
 	Delete(ctx context.Context, moduleID string) error
+	// ResyncVersions re-reads the tags of the module VCS repository and ingests the new versions.
+	ResyncVersions(ctx context.Context, moduleID string) error
+	// Resolve a Terraform module source and version to the module and module version.
+	Resolve(ctx context.Context, source, version string) (*Module, *ModuleVersion, error)
 }
 
 // modules implements Modules.
@@ -59,6 +64,62 @@ const (
 	ModuleErrored       ModuleStatus = "errored"
 )
 
+// ModuleSource represents a Terraform registry module source address
+// in the <host>/<namespace>/<name>/<provider> form.
+type ModuleSource struct {
+	Host      string
+	Namespace string
+	Name      string
+	Provider  string
+
+	// An optional sub-directory within the module package, separated by "//".
+	Subdir string
+}
+
+// ParseModuleSource parses a Terraform registry module source address.
+func ParseModuleSource(source string) (*ModuleSource, error) {
+	address, subdir := source, ""
+	if i := strings.Index(source, "//"); i != -1 {
+		address, subdir = source[:i], strings.Trim(source[i+2:], "/")
+	}
+
+	parts := strings.Split(address, "/")
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("invalid module source '%s': expected <host>/<namespace>/<name>/<provider>", source)
+	}
+	for _, p := range parts {
+		if strings.TrimSpace(p) == "" {
+			return nil, fmt.Errorf("invalid module source '%s': empty path segment", source)
+		}
+	}
+
+	return &ModuleSource{
+		Host:      parts[0],
+		Namespace: parts[1],
+		Name:      parts[2],
+		Provider:  parts[3],
+		Subdir:    subdir,
+	}, nil
+}
+
+// Address returns the registry address of the module without the sub-directory.
+func (s *ModuleSource) Address() string {
+	return strings.Join([]string{s.Host, s.Namespace, s.Name, s.Provider}, "/")
+}
+
+// String returns the module source as used in the Terraform configuration.
+func (s *ModuleSource) String() string {
+	if s.Subdir == "" {
+		return s.Address()
+	}
+	return s.Address() + "//" + s.Subdir
+}
+
+// RegistrySource returns the parsed registry source of the module.
+func (m *Module) RegistrySource() (*ModuleSource, error) {
+	return ParseModuleSource(m.Source)
+}
+
 // ModuleVCSRepo contains the configuration of a VCS integration.
 type ModuleVCSRepo struct {
 	Identifier string  `json:"identifier"`
@@ -207,3 +268,62 @@ func (s *modules) Delete(ctx context.Context, moduleID string) error {
 
 	return s.client.do(ctx, req, nil)
 }
+
+// ResyncVersions triggers the synchronization of the module versions with the tags
+// of its VCS repository. The versions are ingested asynchronously, use
+// ModuleVersions.WaitUntilOk to wait for a new version to become available.
+func (s *modules) ResyncVersions(ctx context.Context, moduleID string) error {
+	if !validStringID(&moduleID) {
+		return errors.New("invalid value for module ID")
+	}
+
+	u := fmt.Sprintf("modules/%s/actions/resync", url.QueryEscape(moduleID))
+	req, err := s.client.newRequest("POST", u, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.client.do(ctx, req, nil)
+}
+
+// Resolve a Terraform module source and version to the module and module version.
+// If the version is empty, the latest module version is returned.
+func (s *modules) Resolve(ctx context.Context, source, version string) (*Module, *ModuleVersion, error) {
+	ms, err := ParseModuleSource(source)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m, err := s.ReadBySource(ctx, ms.Address())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if version == "" {
+		if m.LatestModuleVersion == nil {
+			return nil, nil, ResourceNotFoundError{
+				Message: fmt.Sprintf("Module with source '%s' has no versions.", ms.Address()),
+			}
+		}
+		mv, err := s.client.ModuleVersions.Read(ctx, m.LatestModuleVersion.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return m, mv, nil
+	}
+
+	mvl, err := s.client.ModuleVersions.List(ctx, ModuleVersionListOptions{
+		Module:  m.ID,
+		Version: &version,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(mvl.Items) != 1 {
+		return nil, nil, ResourceNotFoundError{
+			Message: fmt.Sprintf("Module version '%s' with source '%s' not found.", version, ms.Address()),
+		}
+	}
+
+	return m, mvl.Items[0], nil
+}
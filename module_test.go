@@ -141,3 +141,63 @@ func TestModulesDelete(t *testing.T) {
 		assert.EqualError(t, err, "invalid value for module ID")
 	})
 }
+
+func TestParseModuleSource(t *testing.T) {
+	t.Run("with a valid source", func(t *testing.T) {
+		ms, err := ParseModuleSource("scalr.io/env-123/vpc/aws")
+		require.NoError(t, err)
+		assert.Equal(t, &ModuleSource{Host: "scalr.io", Namespace: "env-123", Name: "vpc", Provider: "aws"}, ms)
+		assert.Equal(t, "scalr.io/env-123/vpc/aws", ms.String())
+	})
+
+	t.Run("with a sub-directory", func(t *testing.T) {
+		ms, err := ParseModuleSource("scalr.io/env-123/vpc/aws//modules/subnet")
+		require.NoError(t, err)
+		assert.Equal(t, "modules/subnet", ms.Subdir)
+		assert.Equal(t, "scalr.io/env-123/vpc/aws", ms.Address())
+		assert.Equal(t, "scalr.io/env-123/vpc/aws//modules/subnet", ms.String())
+	})
+
+	t.Run("without a host", func(t *testing.T) {
+		_, err := ParseModuleSource("env-123/vpc/aws")
+		assert.EqualError(t, err, "invalid module source 'env-123/vpc/aws': expected <host>/<namespace>/<name>/<provider>")
+	})
+
+	t.Run("with an empty segment", func(t *testing.T) {
+		_, err := ParseModuleSource("scalr.io//vpc/aws")
+		assert.Error(t, err)
+	})
+}
+
+func TestModulesResolve(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+	m, err := client.Modules.Read(ctx, defaultModuleID)
+	require.NoError(t, err)
+
+	t.Run("without a version", func(t *testing.T) {
+		rm, mv, err := client.Modules.Resolve(ctx, m.Source, "")
+		require.NoError(t, err)
+		assert.Equal(t, m.ID, rm.ID)
+		assert.Equal(t, m.LatestModuleVersion.ID, mv.ID)
+	})
+
+	t.Run("with a version", func(t *testing.T) {
+		latest, err := client.ModuleVersions.Read(ctx, m.LatestModuleVersion.ID)
+		require.NoError(t, err)
+
+		_, mv, err := client.Modules.Resolve(ctx, m.Source, latest.Version)
+		require.NoError(t, err)
+		assert.Equal(t, latest.ID, mv.ID)
+	})
+
+	t.Run("with a nonexistent version", func(t *testing.T) {
+		_, _, err := client.Modules.Resolve(ctx, m.Source, "999.999.999")
+		assert.ErrorIs(t, err, ErrResourceNotFound)
+	})
+
+	t.Run("with an invalid source", func(t *testing.T) {
+		_, _, err := client.Modules.Resolve(ctx, "vpc/aws", "")
+		assert.Error(t, err)
+	})
+}
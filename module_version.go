@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"time"
 )
 
 // Compile-time proof of interface implementation.
@@ -14,8 +15,10 @@ var _ ModuleVersions = (*moduleVersions)(nil)
 type ModuleVersions interface {
 	// List all the module versions within a module.
 	List(ctx context.Context, options ModuleVersionListOptions) (*ModuleVersionList, error)
-	// Read a module version by its ID.
+	// Read a module version by its ID, including its module.
 	Read(ctx context.Context, moduleVersionID string) (*ModuleVersion, error)
+	// WaitUntilOk waits until the module version is ingested and ready to use.
+	WaitUntilOk(ctx context.Context, moduleVersionID string, timeout time.Duration) (*ModuleVersion, error)
 }
 
 // moduleVersions implements ModuleVersions.
@@ -35,6 +38,24 @@ type ModuleVersion struct {
 	IsRootModule bool                `jsonapi:"attr,is-root-module"`
 	Status       ModuleVersionStatus `jsonapi:"attr,status"`
 	Version      string              `jsonapi:"attr,version"`
+	ErrorMessage string              `jsonapi:"attr,error-message"`
+	CreatedAt    time.Time           `jsonapi:"attr,created-at,iso8601"`
+
+	// The Terraform core version constraint required by the module, e.g. ">= 1.3",
+	// empty if the module does not set one.
+	RequiredTerraformVersion string `jsonapi:"attr,required-terraform-version"`
+
+	// Relations
+	Module *Module `jsonapi:"relation,module,omitempty"`
+}
+
+// DownloadSource returns the registry source and version constraint referencing the
+// module version in a Terraform configuration. The module must be included in the response.
+func (mv *ModuleVersion) DownloadSource() (source, version string) {
+	if mv.Module == nil {
+		return "", mv.Version
+	}
+	return mv.Module.Source, mv.Version
 }
 
 type ModuleVersionStatus string
@@ -53,6 +74,9 @@ type ModuleVersionListOptions struct {
 	Status  *string `url:"filter[status],omitempty"`
 	Version *string `url:"filter[version],omitempty"`
 	Include string  `url:"include,omitempty"`
+
+	// Filter by the creation time.
+	CreatedAt *TimeRangeFilter `url:"filter[created-at],omitempty"`
 }
 
 func (o ModuleVersionListOptions) validate() error {
@@ -69,8 +93,14 @@ func (s *moduleVersions) Read(ctx context.Context, moduleVersionID string) (*Mod
 		return nil, errors.New("invalid value for module version ID")
 	}
 
+	options := struct {
+		Include string `url:"include"`
+	}{
+		Include: "module",
+	}
+
 	u := fmt.Sprintf("module-versions/%s", url.QueryEscape(moduleVersionID))
-	req, err := s.client.newRequest("GET", u, nil)
+	req, err := s.client.newRequest("GET", u, options)
 	if err != nil {
 		return nil, err
 	}
@@ -103,3 +133,45 @@ func (s *moduleVersions) List(ctx context.Context, options ModuleVersionListOpti
 
 	return mv, nil
 }
+
+// defaultModuleVersionPollInterval is how often WaitUntilOk checks the module
+// version status, unless the config sets PollInterval.
+const defaultModuleVersionPollInterval = 5 * time.Second
+
+// ErrModuleVersionTimeout is returned when the module version is not ingested
+// within the given timeout.
+var ErrModuleVersionTimeout = errors.New("timed out waiting for the module version to be ingested")
+
+// WaitUntilOk polls the module version until its status is ok, e.g. after a new
+// tag is published, and returns it. An error with the ingestion error message is
+// returned if the module version errored or is being deleted, ErrModuleVersionTimeout
+// if the timeout expires first. A zero timeout waits until ctx is done.
+func (s *moduleVersions) WaitUntilOk(ctx context.Context, moduleVersionID string, timeout time.Duration) (*ModuleVersion, error) {
+	if !validStringID(&moduleVersionID) {
+		return nil, errors.New("invalid value for module version ID")
+	}
+
+	var mv *ModuleVersion
+	interval := s.client.pollInterval(defaultModuleVersionPollInterval)
+	err := poll(ctx, interval, timeout, ErrModuleVersionTimeout, func(ctx context.Context) (bool, error) {
+		var err error
+		mv, err = s.Read(ctx, moduleVersionID)
+		if err != nil {
+			return false, err
+		}
+		switch mv.Status {
+		case ModuleVersionOk:
+			return true, nil
+		case ModuleVersionErrored:
+			return false, fmt.Errorf("module version %s errored: %s", mv.Version, mv.ErrorMessage)
+		case ModuleVersionPendingDelete:
+			return false, fmt.Errorf("module version %s is being deleted", mv.Version)
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mv, nil
+}
@@ -2,7 +2,12 @@ package scalr
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -34,3 +39,95 @@ func TestModuleVersionsList(t *testing.T) {
 		assert.Equal(t, 999, ml.CurrentPage)
 	})
 }
+
+func TestModuleVersionsWaitUntilOk(t *testing.T) {
+	statuses := []ModuleVersionStatus{ModuleVersionNotUploaded, ModuleVersionPending, ModuleVersionOk}
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		status, message := ModuleVersionPending, ""
+		switch r.URL.Path {
+		case "/api/iacp/v3/module-versions/modver-ok":
+			n := int(atomic.AddInt32(&calls, 1)) - 1
+			if n >= len(statuses) {
+				n = len(statuses) - 1
+			}
+			status = statuses[n]
+		case "/api/iacp/v3/module-versions/modver-errored":
+			status, message = ModuleVersionErrored, "invalid module structure"
+		}
+		fmt.Fprintf(w, `{"data":{"id":"modver-1","type":"module-versions",`+
+			`"attributes":{"version":"1.2.0","status":%q,"error-message":%q}}}`, status, message)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client(), PollInterval: time.Millisecond})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("when the module version is ingested", func(t *testing.T) {
+		mv, err := client.ModuleVersions.WaitUntilOk(ctx, "modver-ok", time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, ModuleVersionOk, mv.Status)
+	})
+
+	t.Run("when the module version errors", func(t *testing.T) {
+		mv, err := client.ModuleVersions.WaitUntilOk(ctx, "modver-errored", time.Second)
+		assert.Nil(t, mv)
+		assert.EqualError(t, err, "module version 1.2.0 errored: invalid module structure")
+	})
+
+	t.Run("when the timeout expires", func(t *testing.T) {
+		mv, err := client.ModuleVersions.WaitUntilOk(ctx, "modver-pending", 10*time.Millisecond)
+		assert.Nil(t, mv)
+		assert.ErrorIs(t, err, ErrModuleVersionTimeout)
+	})
+
+	t.Run("with invalid module version ID", func(t *testing.T) {
+		mv, err := client.ModuleVersions.WaitUntilOk(ctx, badIdentifier, time.Second)
+		assert.Nil(t, mv)
+		assert.EqualError(t, err, "invalid value for module version ID")
+	})
+}
+
+func TestModuleVersionsPublishFlow(t *testing.T) {
+	var resynced bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /api/iacp/v3/modules/mod-1/actions/resync":
+			resynced = true
+			w.WriteHeader(http.StatusNoContent)
+		case "GET /api/iacp/v3/module-versions/modver-1":
+			assert.Equal(t, "module", r.URL.Query().Get("include"))
+			fmt.Fprint(w, `{"data":{"id":"modver-1","type":"module-versions","attributes":{"version":"1.2.0","status":"ok"},`+
+				`"relationships":{"module":{"data":{"id":"mod-1","type":"modules"}}}},`+
+				`"included":[{"id":"mod-1","type":"modules","attributes":{"source":"acme.scalr.io/env-1/vpc/aws"}}]}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("resync versions", func(t *testing.T) {
+		require.NoError(t, client.Modules.ResyncVersions(ctx, "mod-1"))
+		assert.True(t, resynced)
+	})
+
+	t.Run("read download source", func(t *testing.T) {
+		mv, err := client.ModuleVersions.Read(ctx, "modver-1")
+		require.NoError(t, err)
+		source, version := mv.DownloadSource()
+		assert.Equal(t, "acme.scalr.io/env-1/vpc/aws", source)
+		assert.Equal(t, "1.2.0", version)
+	})
+
+	t.Run("with invalid module ID", func(t *testing.T) {
+		err := client.Modules.ResyncVersions(ctx, badIdentifier)
+		assert.EqualError(t, err, "invalid value for module ID")
+	})
+}
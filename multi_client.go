@@ -0,0 +1,136 @@
+package scalr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MultiClient manages Scalr API clients for several accounts or hosts,
+// each registered under a unique name.
+type MultiClient struct {
+	mu      sync.RWMutex
+	clients map[string]*Client
+}
+
+// MultiClientError aggregates the errors returned by a fan-out operation,
+// keyed by the client name.
+type MultiClientError struct {
+	Errors map[string]error
+}
+
+func (e *MultiClientError) Error() string {
+	names := make([]string, 0, len(e.Errors))
+	for name := range e.Errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	msgs := make([]string, len(names))
+	for i, name := range names {
+		msgs[i] = fmt.Sprintf("%s: %v", name, e.Errors[name])
+	}
+	return fmt.Sprintf("%d of the clients failed:\n%s", len(names), strings.Join(msgs, "\n"))
+}
+
+// NewMultiClient creates a new client for each of the given configs.
+func NewMultiClient(configs map[string]*Config) (*MultiClient, error) {
+	m := &MultiClient{clients: make(map[string]*Client, len(configs))}
+	for name, cfg := range configs {
+		if err := m.Add(name, cfg); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// Add creates a new client from the config and registers it under the given name.
+func (m *MultiClient) Add(name string, cfg *Config) error {
+	if !validString(&name) {
+		return errors.New("client name is required")
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("error creating client %s: %v", name, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.clients == nil {
+		m.clients = make(map[string]*Client)
+	}
+	if _, ok := m.clients[name]; ok {
+		return fmt.Errorf("client %s already exists", name)
+	}
+	m.clients[name] = client
+
+	return nil
+}
+
+// Remove unregisters the client with the given name.
+func (m *MultiClient) Remove(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.clients, name)
+}
+
+// Client returns the client registered under the given name.
+func (m *MultiClient) Client(name string) (*Client, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	client, ok := m.clients[name]
+	if !ok {
+		return nil, fmt.Errorf("client %s not found", name)
+	}
+	return client, nil
+}
+
+// Names returns the sorted names of all registered clients.
+func (m *MultiClient) Names() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	names := make([]string, 0, len(m.clients))
+	for name := range m.clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ForEach calls fn concurrently for every registered client. It waits for all
+// calls to finish and returns a *MultiClientError if any of them failed.
+func (m *MultiClient) ForEach(ctx context.Context, fn func(ctx context.Context, name string, client *Client) error) error {
+	m.mu.RLock()
+	clients := make(map[string]*Client, len(m.clients))
+	for name, client := range m.clients {
+		clients[name] = client
+	}
+	m.mu.RUnlock()
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs = make(map[string]error)
+	)
+	for name, client := range clients {
+		wg.Add(1)
+		go func(name string, client *Client) {
+			defer wg.Done()
+			if err := fn(ctx, name, client); err != nil {
+				mu.Lock()
+				errs[name] = err
+				mu.Unlock()
+			}
+		}(name, client)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return &MultiClientError{Errors: errs}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package scalr
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiClient(t *testing.T) {
+	m, err := NewMultiClient(map[string]*Config{
+		"foo": {Address: "https://foo.scalr.io", Token: "foo-token"},
+		"bar": {Address: "https://bar.scalr.io", Token: "bar-token"},
+	})
+	require.NoError(t, err)
+
+	t.Run("names are sorted", func(t *testing.T) {
+		assert.Equal(t, []string{"bar", "foo"}, m.Names())
+	})
+
+	t.Run("read client by name", func(t *testing.T) {
+		c, err := m.Client("foo")
+		require.NoError(t, err)
+		assert.Equal(t, "foo-token", c.token)
+
+		_, err = m.Client("baz")
+		assert.EqualError(t, err, "client baz not found")
+	})
+
+	t.Run("add a duplicate client", func(t *testing.T) {
+		err := m.Add("foo", &Config{Token: "other-token"})
+		assert.EqualError(t, err, "client foo already exists")
+	})
+
+	t.Run("for each aggregates errors", func(t *testing.T) {
+		visited := make(chan string, 2)
+		err := m.ForEach(context.Background(), func(ctx context.Context, name string, c *Client) error {
+			visited <- name
+			if name == "foo" {
+				return errors.New("boom")
+			}
+			return nil
+		})
+		close(visited)
+
+		var names []string
+		for name := range visited {
+			names = append(names, name)
+		}
+		assert.ElementsMatch(t, []string{"foo", "bar"}, names)
+
+		var merr *MultiClientError
+		require.True(t, errors.As(err, &merr))
+		assert.Len(t, merr.Errors, 1)
+		assert.EqualError(t, err, "1 of the clients failed:\nfoo: boom")
+	})
+
+	t.Run("for each without errors", func(t *testing.T) {
+		err := m.ForEach(context.Background(), func(ctx context.Context, name string, c *Client) error {
+			return nil
+		})
+		assert.NoError(t, err)
+	})
+}
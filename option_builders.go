@@ -0,0 +1,193 @@
+package scalr
+
+// WorkspaceCreateOption sets an optional field of WorkspaceCreateOptions.
+type WorkspaceCreateOption func(*WorkspaceCreateOptions)
+
+// NewWorkspaceCreateOptions returns the options for creating a workspace with
+// the given name in the environment, with the optional fields set by opts, e.g.
+//
+//	scalr.NewWorkspaceCreateOptions("app", env,
+//		scalr.WithAutoApply(true),
+//		scalr.WithTerraformVersion("1.5.7"),
+//	)
+func NewWorkspaceCreateOptions(name string, environment *Environment, opts ...WorkspaceCreateOption) WorkspaceCreateOptions {
+	options := WorkspaceCreateOptions{
+		Name:        Ptr(name),
+		Environment: environment,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// WithAutoApply sets whether successful plans are applied automatically.
+func WithAutoApply(v bool) WorkspaceCreateOption {
+	return func(o *WorkspaceCreateOptions) { o.AutoApply = Ptr(v) }
+}
+
+// WithDeletionProtection sets whether the deletion of a workspace with resources is prevented.
+func WithDeletionProtection(v bool) WorkspaceCreateOption {
+	return func(o *WorkspaceCreateOptions) { o.DeletionProtectionEnabled = Ptr(v) }
+}
+
+// WithExecutionMode sets the execution mode of the workspace.
+func WithExecutionMode(v WorkspaceExecutionMode) WorkspaceCreateOption {
+	return func(o *WorkspaceCreateOptions) { o.ExecutionMode = Ptr(v) }
+}
+
+// WithTerraformVersion sets the Terraform version of the workspace.
+func WithTerraformVersion(v string) WorkspaceCreateOption {
+	return func(o *WorkspaceCreateOptions) { o.TerraformVersion = Ptr(v) }
+}
+
+// WithWorkingDirectory sets the directory Terraform is executed in.
+func WithWorkingDirectory(v string) WorkspaceCreateOption {
+	return func(o *WorkspaceCreateOptions) { o.WorkingDirectory = Ptr(v) }
+}
+
+// WithVCSRepo connects the workspace to the repository of the VCS provider.
+func WithVCSRepo(provider *VcsProvider, repo WorkspaceVCSRepoOptions) WorkspaceCreateOption {
+	return func(o *WorkspaceCreateOptions) {
+		o.VcsProvider = provider
+		o.VCSRepo = &repo
+	}
+}
+
+// WithAgentPool runs the workspace on the agent pool.
+func WithAgentPool(pool *AgentPool) WorkspaceCreateOption {
+	return func(o *WorkspaceCreateOptions) { o.AgentPool = pool }
+}
+
+// WithVarFiles sets the variable files passed to Terraform.
+func WithVarFiles(files ...string) WorkspaceCreateOption {
+	return func(o *WorkspaceCreateOptions) { o.VarFiles = files }
+}
+
+// WithWorkspaceTags adds the tags to the workspace.
+func WithWorkspaceTags(tags ...*Tag) WorkspaceCreateOption {
+	return func(o *WorkspaceCreateOptions) { o.Tags = append(o.Tags, tags...) }
+}
+
+// ProviderConfigurationCreateOption sets an optional field of ProviderConfigurationCreateOptions.
+type ProviderConfigurationCreateOption func(*ProviderConfigurationCreateOptions)
+
+// NewProviderConfigurationCreateOptions returns the options for creating a provider
+// configuration in the account, with the credentials and other optional fields set by opts, e.g.
+//
+//	scalr.NewProviderConfigurationCreateOptions("aws_dev", "aws", account,
+//		scalr.WithAWSAccessKeys(accessKey, secretKey),
+//		scalr.WithProviderEnvironments(env),
+//	)
+func NewProviderConfigurationCreateOptions(
+	name, providerName string, account *Account, opts ...ProviderConfigurationCreateOption,
+) ProviderConfigurationCreateOptions {
+	options := ProviderConfigurationCreateOptions{
+		Name:         Ptr(name),
+		ProviderName: Ptr(providerName),
+		Account:      account,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// WithProviderEnvironments shares the provider configuration with the environments.
+func WithProviderEnvironments(environments ...*Environment) ProviderConfigurationCreateOption {
+	return func(o *ProviderConfigurationCreateOptions) { o.Environments = append(o.Environments, environments...) }
+}
+
+// WithProviderShared shares the provider configuration with all the environments of the account.
+func WithProviderShared() ProviderConfigurationCreateOption {
+	return func(o *ProviderConfigurationCreateOptions) { o.IsShared = Ptr(true) }
+}
+
+// WithExportShellVariables sets whether the credentials are exported as shell variables.
+func WithExportShellVariables(v bool) ProviderConfigurationCreateOption {
+	return func(o *ProviderConfigurationCreateOptions) { o.ExportShellVariables = Ptr(v) }
+}
+
+// WithAWSAccessKeys authenticates a regular AWS account with access keys.
+func WithAWSAccessKeys(accessKey, secretKey string) ProviderConfigurationCreateOption {
+	return func(o *ProviderConfigurationCreateOptions) {
+		o.AwsAccountType = Ptr("regular")
+		o.AwsCredentialsType = Ptr("access_keys")
+		o.AwsAccessKey = Ptr(accessKey)
+		o.AwsSecretKey = SecretString(secretKey)
+	}
+}
+
+// WithAWSRoleDelegation authenticates a regular AWS account by assuming the role
+// trusted by an AWS service.
+func WithAWSRoleDelegation(roleArn, externalID string) ProviderConfigurationCreateOption {
+	return func(o *ProviderConfigurationCreateOptions) {
+		o.AwsAccountType = Ptr("regular")
+		o.AwsCredentialsType = Ptr("role_delegation")
+		o.AwsTrustedEntityType = Ptr("aws_service")
+		o.AwsRoleArn = Ptr(roleArn)
+		o.AwsExternalId = Ptr(externalID)
+	}
+}
+
+// WithAWSOIDC authenticates a regular AWS account by assuming the role with the
+// OIDC token of the run, the role must trust the Scalr identity provider for the audience.
+func WithAWSOIDC(roleArn, audience string) ProviderConfigurationCreateOption {
+	return func(o *ProviderConfigurationCreateOptions) {
+		o.AwsAccountType = Ptr("regular")
+		o.AwsCredentialsType = Ptr(ProviderConfigurationOIDC)
+		o.AwsRoleArn = Ptr(roleArn)
+		o.AwsAudience = Ptr(audience)
+	}
+}
+
+// WithAzurermClientSecrets authenticates to Azure with the client secret.
+func WithAzurermClientSecrets(clientID, clientSecret, subscriptionID, tenantID string) ProviderConfigurationCreateOption {
+	return func(o *ProviderConfigurationCreateOptions) {
+		o.AzurermAuthType = Ptr("client-secrets")
+		o.AzurermClientId = Ptr(clientID)
+		o.AzurermClientSecret = SecretString(clientSecret)
+		o.AzurermSubscriptionId = Ptr(subscriptionID)
+		o.AzurermTenantId = Ptr(tenantID)
+	}
+}
+
+// WithAzurermOIDC authenticates to Azure with the OIDC token of the run, the
+// application must have a federated credential for the audience.
+func WithAzurermOIDC(clientID, subscriptionID, tenantID, audience string) ProviderConfigurationCreateOption {
+	return func(o *ProviderConfigurationCreateOptions) {
+		o.AzurermAuthType = Ptr(ProviderConfigurationOIDC)
+		o.AzurermClientId = Ptr(clientID)
+		o.AzurermSubscriptionId = Ptr(subscriptionID)
+		o.AzurermTenantId = Ptr(tenantID)
+		o.AzurermAudience = Ptr(audience)
+	}
+}
+
+// WithGoogleCredentials authenticates to Google Cloud with the service account key.
+func WithGoogleCredentials(project, credentials string) ProviderConfigurationCreateOption {
+	return func(o *ProviderConfigurationCreateOptions) {
+		o.GoogleProject = Ptr(project)
+		o.GoogleCredentials = SecretString(credentials)
+	}
+}
+
+// WithGoogleWorkloadIdentity authenticates to Google Cloud by impersonating the
+// service account through the workload identity federation, e.g. the provider
+// "projects/123/locations/global/workloadIdentityPools/scalr/providers/scalr".
+func WithGoogleWorkloadIdentity(project, serviceAccountEmail, workloadProviderName string) ProviderConfigurationCreateOption {
+	return func(o *ProviderConfigurationCreateOptions) {
+		o.GoogleAuthType = Ptr(ProviderConfigurationOIDC)
+		o.GoogleProject = Ptr(project)
+		o.GoogleServiceAccountEmail = Ptr(serviceAccountEmail)
+		o.GoogleWorkloadProviderName = Ptr(workloadProviderName)
+	}
+}
+
+// WithScalrToken authenticates to the Scalr hostname with the token.
+func WithScalrToken(hostname, token string) ProviderConfigurationCreateOption {
+	return func(o *ProviderConfigurationCreateOptions) {
+		o.ScalrHostname = Ptr(hostname)
+		o.ScalrToken = SecretString(token)
+	}
+}
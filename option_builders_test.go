@@ -0,0 +1,132 @@
+package scalr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPtr(t *testing.T) {
+	assert.Equal(t, "foo", *Ptr("foo"))
+	assert.Equal(t, WorkspaceExecutionModeLocal, *Ptr(WorkspaceExecutionModeLocal))
+}
+
+func TestNewWorkspaceCreateOptions(t *testing.T) {
+	env := &Environment{ID: "env-123"}
+	vcs := &VcsProvider{ID: "vcs-123"}
+	tag := &Tag{ID: "tag-123"}
+
+	options := NewWorkspaceCreateOptions("app", env,
+		WithAutoApply(true),
+		WithExecutionMode(WorkspaceExecutionModeRemote),
+		WithTerraformVersion("1.5.7"),
+		WithWorkingDirectory("infra"),
+		WithVCSRepo(vcs, WorkspaceVCSRepoOptions{Identifier: String("org/repo")}),
+		WithVarFiles("dev.tfvars"),
+		WithWorkspaceTags(tag),
+	)
+
+	assert.Equal(t, WorkspaceCreateOptions{
+		Name:             String("app"),
+		Environment:      env,
+		AutoApply:        Bool(true),
+		ExecutionMode:    Ptr(WorkspaceExecutionModeRemote),
+		TerraformVersion: String("1.5.7"),
+		WorkingDirectory: String("infra"),
+		VCSRepo:          &WorkspaceVCSRepoOptions{Identifier: String("org/repo")},
+		VcsProvider:      vcs,
+		VarFiles:         []string{"dev.tfvars"},
+		Tags:             []*Tag{tag},
+	}, options)
+}
+
+func TestNewProviderConfigurationCreateOptions(t *testing.T) {
+	account := &Account{ID: "acc-123"}
+	env := &Environment{ID: "env-123"}
+
+	options := NewProviderConfigurationCreateOptions("aws_dev", "aws", account,
+		WithAWSAccessKeys("access", "secret"),
+		WithExportShellVariables(false),
+		WithProviderEnvironments(env),
+	)
+
+	assert.Equal(t, ProviderConfigurationCreateOptions{
+		Name:                 String("aws_dev"),
+		ProviderName:         String("aws"),
+		Account:              account,
+		AwsAccountType:       String("regular"),
+		AwsCredentialsType:   String("access_keys"),
+		AwsAccessKey:         String("access"),
+		AwsSecretKey:         SecretString("secret"),
+		ExportShellVariables: Bool(false),
+		Environments:         []*Environment{env},
+	}, options)
+
+	options = NewProviderConfigurationCreateOptions("azure_dev", "azurerm", account,
+		WithAzurermClientSecrets("client", "secret", "subscription", "tenant"),
+	)
+
+	assert.Equal(t, ProviderConfigurationCreateOptions{
+		Name:                  String("azure_dev"),
+		ProviderName:          String("azurerm"),
+		Account:               account,
+		AzurermAuthType:       String("client-secrets"),
+		AzurermClientId:       String("client"),
+		AzurermClientSecret:   SecretString("secret"),
+		AzurermSubscriptionId: String("subscription"),
+		AzurermTenantId:       String("tenant"),
+	}, options)
+}
+
+func TestProviderConfigurationOIDC(t *testing.T) {
+	account := &Account{ID: "acc-123"}
+
+	t.Run("valid", func(t *testing.T) {
+		options := NewProviderConfigurationCreateOptions("aws_oidc", "aws", account,
+			WithAWSOIDC("arn:aws:iam::123456789012:role/scalr", "aws.scalr-run-workload"))
+		assert.Equal(t, String(ProviderConfigurationOIDC), options.AwsCredentialsType)
+		assert.NoError(t, options.oidc().valid())
+
+		options = NewProviderConfigurationCreateOptions("azure_oidc", "azurerm", account,
+			WithAzurermOIDC("client", "subscription", "tenant", "azure.scalr-run-workload"))
+		assert.NoError(t, options.oidc().valid())
+
+		options = NewProviderConfigurationCreateOptions("google_oidc", "google", account,
+			WithGoogleWorkloadIdentity("project", "scalr@project.iam.gserviceaccount.com",
+				"projects/123/locations/global/workloadIdentityPools/scalr/providers/scalr"))
+		assert.Equal(t, String("project"), options.GoogleProject)
+		assert.NoError(t, options.oidc().valid())
+	})
+
+	t.Run("without audience", func(t *testing.T) {
+		options := NewProviderConfigurationCreateOptions("aws_oidc", "aws", account,
+			WithAWSOIDC("arn:aws:iam::123456789012:role/scalr", ""))
+		assert.EqualError(t, options.oidc().valid(), "aws audience is required for the oidc credentials type")
+	})
+
+	t.Run("with static secret", func(t *testing.T) {
+		options := NewProviderConfigurationCreateOptions("azure_oidc", "azurerm", account,
+			WithAzurermOIDC("client", "subscription", "tenant", "azure.scalr-run-workload"))
+		options.AzurermClientSecret = SecretString("secret")
+		assert.EqualError(t, options.oidc().valid(), "azurerm client secret must not be set for the oidc auth type")
+	})
+
+	t.Run("with invalid workload provider", func(t *testing.T) {
+		options := ProviderConfigurationUpdateOptions{
+			GoogleAuthType:             String(ProviderConfigurationOIDC),
+			GoogleServiceAccountEmail:  String("scalr@project.iam.gserviceaccount.com"),
+			GoogleWorkloadProviderName: String("scalr"),
+		}
+		assert.EqualError(t, options.oidc().valid(), "invalid value for google workload provider name")
+	})
+
+	t.Run("update setting the oidc type only", func(t *testing.T) {
+		options := ProviderConfigurationUpdateOptions{AwsCredentialsType: String(ProviderConfigurationOIDC)}
+		assert.NoError(t, options.oidc().valid())
+	})
+
+	t.Run("update changing the role only", func(t *testing.T) {
+		options := ProviderConfigurationUpdateOptions{AwsRoleArn: String("scalr")}
+		assert.EqualError(t, options.oidc().valid(), "invalid value for aws role arn")
+	})
+}
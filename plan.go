@@ -1,6 +1,79 @@
 package scalr
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+)
+
+// Compile-time proof of interface implementation.
+var _ Plans = (*plans)(nil)
+
+// Plans describes all the plan related methods that the Scalr API supports.
+type Plans interface {
+	// Read a plan by its ID.
+	Read(ctx context.Context, planID string) (*Plan, error)
+	// ReadLogs returns a reader of the plan output that follows the log until the plan is finished.
+	ReadLogs(ctx context.Context, planID string) (io.ReadCloser, error)
+}
+
+// plans implements Plans.
+type plans struct {
+	client *Client
+}
+
 // Plan represents a Scalr plan.
 type Plan struct {
-	ID string `jsonapi:"primary,plans"`
+	ID                   string `jsonapi:"primary,plans"`
+	Status               string `jsonapi:"attr,status"`
+	HasChanges           bool   `jsonapi:"attr,has-changes"`
+	ResourceAdditions    int    `jsonapi:"attr,resource-additions"`
+	ResourceChanges      int    `jsonapi:"attr,resource-changes"`
+	ResourceDestructions int    `jsonapi:"attr,resource-destructions"`
+}
+
+// IsDestructive reports whether applying the plan destroys any resources.
+// Replaced resources are counted as destructions too.
+func (p *Plan) IsDestructive() bool {
+	return p.ResourceDestructions > 0
+}
+
+// Read a plan by its ID.
+func (s *plans) Read(ctx context.Context, planID string) (*Plan, error) {
+	if !validStringID(&planID) {
+		return nil, errors.New("invalid value for plan ID")
+	}
+
+	u := fmt.Sprintf("plans/%s", url.QueryEscape(planID))
+	req, err := s.client.newRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Plan{}
+	err = s.client.do(ctx, req, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// ReadLogs returns a reader of the plan output. The reader fetches the log
+// incrementally and waits for more output until the plan is finished, so it
+// can be used to tail the log of a plan in progress.
+func (s *plans) ReadLogs(ctx context.Context, planID string) (io.ReadCloser, error) {
+	if !validStringID(&planID) {
+		return nil, errors.New("invalid value for plan ID")
+	}
+
+	return newLogReader(ctx, s.client, fmt.Sprintf("plans/%s/logs", url.QueryEscape(planID)), func() (bool, error) {
+		p, err := s.Read(ctx, planID)
+		if err != nil {
+			return false, err
+		}
+		return isLogFinal(p.Status), nil
+	}), nil
 }
@@ -18,6 +18,7 @@ type PolicyGroups interface {
 	Create(ctx context.Context, options PolicyGroupCreateOptions) (*PolicyGroup, error)
 	Update(ctx context.Context, policyGroupID string, options PolicyGroupUpdateOptions) (*PolicyGroup, error)
 	Delete(ctx context.Context, policyGroupID string) error
+	EffectiveForWorkspace(ctx context.Context, workspaceID string) ([]*PolicyGroup, error)
 }
 
 // policyGroups implements PolicyGroups.
@@ -70,6 +71,26 @@ type PolicyGroupVCSRepoOptions struct {
 	Path       *string `json:"path,omitempty"`
 }
 
+// PolicyGroupInlinePolicy represents an OPA policy uploaded directly
+// to a policy group instead of being fetched from a VCS repository.
+type PolicyGroupInlinePolicy struct {
+	Name             string                 `json:"name"`
+	Content          string                 `json:"content"`
+	EnforcementLevel PolicyEnforcementLevel `json:"enforced-level,omitempty"`
+}
+
+func validInlinePolicies(policies []*PolicyGroupInlinePolicy) error {
+	for i, p := range policies {
+		if p == nil || !validString(&p.Name) {
+			return fmt.Errorf("inline policy %d: name is required", i)
+		}
+		if !validString(&p.Content) {
+			return fmt.Errorf("inline policy %s: content is required", p.Name)
+		}
+	}
+	return nil
+}
+
 // PolicyGroup represents a Scalr policy group.
 type PolicyGroup struct {
 	ID           string              `jsonapi:"primary,policy-groups"`
@@ -79,6 +100,9 @@ type PolicyGroup struct {
 	OpaVersion   string              `jsonapi:"attr,opa-version"`
 	VCSRepo      *PolicyGroupVCSRepo `jsonapi:"attr,vcs-repo"`
 
+	// The policies of a policy group that is not connected to a VCS repository.
+	InlinePolicies []*PolicyGroupInlinePolicy `jsonapi:"attr,inline-policies"`
+
 	// Relations
 	Account      *Account       `jsonapi:"relation,account"`
 	VcsProvider  *VcsProvider   `jsonapi:"relation,vcs-provider"`
@@ -113,6 +137,9 @@ type PolicyGroupCreateOptions struct {
 	OpaVersion *string                    `jsonapi:"attr,opa-version,omitempty"`
 	VCSRepo    *PolicyGroupVCSRepoOptions `jsonapi:"attr,vcs-repo"`
 
+	// The policies to upload directly, instead of the VCS repo and provider.
+	InlinePolicies []*PolicyGroupInlinePolicy `jsonapi:"attr,inline-policies,omitempty"`
+
 	// Relations
 	Account     *Account     `jsonapi:"relation,account"`
 	VcsProvider *VcsProvider `jsonapi:"relation,vcs-provider"`
@@ -128,6 +155,12 @@ func (o PolicyGroupCreateOptions) valid() error {
 	if !validStringID(&o.Account.ID) {
 		return errors.New("invalid value for account ID")
 	}
+	if len(o.InlinePolicies) > 0 {
+		if o.VcsProvider != nil || o.VCSRepo != nil {
+			return errors.New("vcs repo and inline policies are mutually exclusive")
+		}
+		return validInlinePolicies(o.InlinePolicies)
+	}
 	if o.VcsProvider == nil {
 		return errors.New("vcs provider is required")
 	}
@@ -147,10 +180,20 @@ type PolicyGroupUpdateOptions struct {
 	OpaVersion *string                    `jsonapi:"attr,opa-version,omitempty"`
 	VCSRepo    *PolicyGroupVCSRepoOptions `jsonapi:"attr,vcs-repo,omitempty"`
 
+	// Replaces the policies of a policy group that is not connected to a VCS repository.
+	InlinePolicies []*PolicyGroupInlinePolicy `jsonapi:"attr,inline-policies,omitempty"`
+
 	// Relations
 	VcsProvider *VcsProvider `jsonapi:"relation,vcs-provider,omitempty"`
 }
 
+func (o PolicyGroupUpdateOptions) valid() error {
+	if len(o.InlinePolicies) > 0 && (o.VcsProvider != nil || o.VCSRepo != nil) {
+		return errors.New("vcs repo and inline policies are mutually exclusive")
+	}
+	return validInlinePolicies(o.InlinePolicies)
+}
+
 // List all the policy groups.
 func (s *policyGroups) List(ctx context.Context, options PolicyGroupListOptions) (*PolicyGroupList, error) {
 	req, err := s.client.newRequest("GET", "policy-groups", &options)
@@ -219,6 +262,9 @@ func (s *policyGroups) Update(ctx context.Context, policyGroupID string, options
 	if !validStringID(&policyGroupID) {
 		return nil, errors.New("invalid value for policy group ID")
 	}
+	if err := options.valid(); err != nil {
+		return nil, err
+	}
 
 	// Make sure we don't send a user provided ID.
 	options.ID = ""
@@ -252,3 +298,39 @@ func (s *policyGroups) Delete(ctx context.Context, policyGroupID string) error {
 
 	return s.client.do(ctx, req, nil)
 }
+
+// EffectiveForWorkspace lists the policy groups that apply to the runs of the
+// workspace, that is the ones linked to its environment, with their policies included.
+func (s *policyGroups) EffectiveForWorkspace(ctx context.Context, workspaceID string) ([]*PolicyGroup, error) {
+	if !validStringID(&workspaceID) {
+		return nil, errors.New("invalid value for workspace ID")
+	}
+
+	ws, err := s.client.Workspaces.ReadByID(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if ws.Environment == nil {
+		return nil, fmt.Errorf("workspace %s has no environment", workspaceID)
+	}
+
+	options := PolicyGroupListOptions{
+		Environment: ws.Environment.ID,
+		Include:     "policies",
+	}
+
+	var result []*PolicyGroup
+	for {
+		pgl, err := s.List(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, pgl.Items...)
+		if pgl.Pagination == nil || pgl.NextPage == 0 {
+			break
+		}
+		options.PageNumber = pgl.NextPage
+	}
+
+	return result, nil
+}
@@ -2,7 +2,11 @@ package scalr
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -171,6 +175,79 @@ func TestPolicyGroupsCreate(t *testing.T) {
 	})
 }
 
+func TestPolicyGroupsCreateInline(t *testing.T) {
+	t.Run("with inline policies", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/iacp/v3/policy-groups", r.URL.Path)
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			var payload struct {
+				Data struct {
+					Attributes struct {
+						InlinePolicies []*PolicyGroupInlinePolicy `json:"inline-policies"`
+					} `json:"attributes"`
+				} `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(body, &payload))
+			require.Len(t, payload.Data.Attributes.InlinePolicies, 1)
+			assert.Equal(t, "deny_all", payload.Data.Attributes.InlinePolicies[0].Name)
+
+			w.Header().Set("Content-Type", "application/vnd.api+json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"data":{"type":"policy-groups","id":"pgrp-1","attributes":{"name":"foo",` +
+				`"inline-policies":[{"name":"deny_all","content":"package terraform","enforced-level":"hard-mandatory"}]}}}`))
+		}))
+		defer ts.Close()
+
+		client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+		require.NoError(t, err)
+
+		pg, err := client.PolicyGroups.Create(context.Background(), PolicyGroupCreateOptions{
+			Name:    String("foo"),
+			Account: &Account{ID: defaultAccountID},
+			InlinePolicies: []*PolicyGroupInlinePolicy{
+				{Name: "deny_all", Content: "package terraform", EnforcementLevel: PolicyEnforcementLevelHard},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "pgrp-1", pg.ID)
+		require.Len(t, pg.InlinePolicies, 1)
+		assert.Equal(t, PolicyEnforcementLevel(PolicyEnforcementLevelHard), pg.InlinePolicies[0].EnforcementLevel)
+	})
+
+	t.Run("with both vcs repo and inline policies", func(t *testing.T) {
+		err := PolicyGroupCreateOptions{
+			Name:        String("foo"),
+			Account:     &Account{ID: defaultAccountID},
+			VcsProvider: &VcsProvider{ID: "vcs-123"},
+			InlinePolicies: []*PolicyGroupInlinePolicy{
+				{Name: "deny_all", Content: "package terraform"},
+			},
+		}.valid()
+		assert.EqualError(t, err, "vcs repo and inline policies are mutually exclusive")
+	})
+
+	t.Run("without policy content", func(t *testing.T) {
+		err := PolicyGroupCreateOptions{
+			Name:           String("foo"),
+			Account:        &Account{ID: defaultAccountID},
+			InlinePolicies: []*PolicyGroupInlinePolicy{{Name: "deny_all"}},
+		}.valid()
+		assert.EqualError(t, err, "inline policy deny_all: content is required")
+	})
+
+	t.Run("update with both vcs repo and inline policies", func(t *testing.T) {
+		err := PolicyGroupUpdateOptions{
+			VCSRepo: &PolicyGroupVCSRepoOptions{Identifier: String("org/policies")},
+			InlinePolicies: []*PolicyGroupInlinePolicy{
+				{Name: "deny_all", Content: "package terraform"},
+			},
+		}.valid()
+		assert.EqualError(t, err, "vcs repo and inline policies are mutually exclusive")
+	})
+}
+
 func TestPolicyGroupsRead(t *testing.T) {
 	// TODO: delete skip after SCALRCORE-19891
 	t.Skip("Works with personal token but does not work with github action token.")
@@ -279,3 +356,42 @@ func TestPolicyGroupsDelete(t *testing.T) {
 		assert.EqualError(t, err, "invalid value for policy group ID")
 	})
 }
+
+func TestPolicyGroupsEffectiveForWorkspace(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		switch r.URL.Path {
+		case "/api/iacp/v3/workspaces/ws-123":
+			fmt.Fprint(w, `{"data":{"id":"ws-123","type":"workspaces",`+
+				`"relationships":{"environment":{"data":{"id":"env-123","type":"environments"}}}}}`)
+		case "/api/iacp/v3/policy-groups":
+			assert.Equal(t, "env-123", r.URL.Query().Get("filter[environment]"))
+			assert.Equal(t, "policies", r.URL.Query().Get("include"))
+			fmt.Fprint(w, `{"data":[{"id":"pgrp-1","type":"policy-groups","attributes":{"name":"baseline"},`+
+				`"relationships":{"policies":{"data":[{"id":"pol-1","type":"policies"}]}}}],`+
+				`"included":[{"id":"pol-1","type":"policies","attributes":{"name":"deny_all","enabled":true}}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("when the environment has policy groups", func(t *testing.T) {
+		pgs, err := client.PolicyGroups.EffectiveForWorkspace(ctx, "ws-123")
+		require.NoError(t, err)
+		require.Len(t, pgs, 1)
+		assert.Equal(t, "baseline", pgs[0].Name)
+		require.Len(t, pgs[0].Policies, 1)
+		assert.Equal(t, "deny_all", pgs[0].Policies[0].Name)
+	})
+
+	t.Run("with invalid workspace ID", func(t *testing.T) {
+		pgs, err := client.PolicyGroups.EffectiveForWorkspace(ctx, badIdentifier)
+		assert.Nil(t, pgs)
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+}
@@ -0,0 +1,55 @@
+package scalr
+
+import (
+	"context"
+	"time"
+)
+
+// pollInterval returns how often to check the status of a resource that is
+// waited for: the PollInterval of the config, or def if it is not set.
+func (c *Client) pollInterval(def time.Duration) time.Duration {
+	if c.config.PollInterval > 0 {
+		return c.config.PollInterval
+	}
+	return def
+}
+
+// poll calls check right away and then every interval until check reports done
+// or fails, passing it the context to send the requests with. If the timeout is
+// positive and expires first, errTimeout is returned. If ctx is done first, its
+// error is returned.
+func poll(
+	ctx context.Context, interval, timeout time.Duration, errTimeout error,
+	check func(ctx context.Context) (bool, error),
+) error {
+	pctx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		pctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		done, err := check(pctx)
+		if err == nil && done {
+			return nil
+		}
+		if err == nil {
+			select {
+			case <-pctx.Done():
+				err = pctx.Err()
+			case <-ticker.C:
+				continue
+			}
+		}
+
+		// The requests fail with the context error once the timeout expires.
+		if pctx != ctx && pctx.Err() != nil && ctx.Err() == nil {
+			return errTimeout
+		}
+		return err
+	}
+}
@@ -0,0 +1,47 @@
+package scalr
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoll(t *testing.T) {
+	errTimeout := errors.New("timeout")
+
+	t.Run("until done", func(t *testing.T) {
+		calls := 0
+		err := poll(context.Background(), time.Millisecond, time.Second, errTimeout, func(context.Context) (bool, error) {
+			calls++
+			return calls == 3, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("when the check fails", func(t *testing.T) {
+		err := poll(context.Background(), time.Millisecond, 0, nil, func(context.Context) (bool, error) {
+			return false, ErrResourceNotFound
+		})
+		assert.ErrorIs(t, err, ErrResourceNotFound)
+	})
+
+	t.Run("when the timeout expires", func(t *testing.T) {
+		err := poll(context.Background(), time.Millisecond, 10*time.Millisecond, errTimeout, func(context.Context) (bool, error) {
+			return false, nil
+		})
+		assert.Equal(t, errTimeout, err)
+	})
+
+	t.Run("when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := poll(ctx, time.Millisecond, time.Second, errTimeout, func(context.Context) (bool, error) {
+			return false, nil
+		})
+		assert.Equal(t, context.Canceled, err)
+	})
+}
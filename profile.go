@@ -0,0 +1,37 @@
+package scalr
+
+import "context"
+
+// APIProfile selects the shape of the API responses with the Prefer header.
+//
+// The SDK structs are written against the preview profile, which is the default.
+// The internal profile adds the attributes used by the Scalr UI and the Terraform
+// provider, among them:
+//   - Workspace: Actions, Permissions, CreatedBy and the current run statuses;
+//   - Environment: CreatedBy and the policy groups;
+//   - Run: Source and the VCS revision details.
+//
+// The attributes that the selected profile does not return are left blank.
+type APIProfile string
+
+// List of available API profiles.
+const (
+	ProfilePreview  APIProfile = "preview"
+	ProfileInternal APIProfile = "internal"
+)
+
+// profileContextKey is the context key of the per-request API profile.
+type profileContextKey struct{}
+
+// ContextWithProfile returns a context that makes the requests sent with it
+// use the profile instead of the one configured for the client, e.g.
+//
+//	ws, err := client.Workspaces.ReadByID(scalr.ContextWithProfile(ctx, scalr.ProfileInternal), wsID)
+func ContextWithProfile(ctx context.Context, profile APIProfile) context.Context {
+	return context.WithValue(ctx, profileContextKey{}, profile)
+}
+
+// preferHeader returns the value of the Prefer header selecting the profile.
+func (p APIProfile) preferHeader() string {
+	return "profile=" + string(p)
+}
@@ -17,6 +17,7 @@ type ProviderConfigurations interface {
 	Read(ctx context.Context, configurationID string) (*ProviderConfiguration, error)
 	Delete(ctx context.Context, configurationID string) error
 	Update(ctx context.Context, configurationID string, options ProviderConfigurationUpdateOptions) (*ProviderConfiguration, error)
+	Validate(ctx context.Context, configurationID string) (*ProviderConfigurationValidation, error)
 }
 
 // providerConfigurations implements ProviderConfigurations.
@@ -39,7 +40,7 @@ type ProviderConfiguration struct {
 	IsShared                   bool   `jsonapi:"attr,is-shared"`
 	IsCustom                   bool   `jsonapi:"attr,is-custom"`
 	AwsAccessKey               string `jsonapi:"attr,aws-access-key"`
-	AwsSecretKey               string `jsonapi:"attr,aws-secret-key"`
+	AwsSecretKey               Secret `jsonapi:"attr,aws-secret-key"`
 	AwsAccountType             string `jsonapi:"attr,aws-account-type"`
 	AwsCredentialsType         string `jsonapi:"attr,aws-credentials-type"`
 	AwsTrustedEntityType       string `jsonapi:"attr,aws-trusted-entity-type"`
@@ -47,7 +48,7 @@ type ProviderConfiguration struct {
 	AwsExternalId              string `jsonapi:"attr,aws-external-id"`
 	AwsAudience                string `jsonapi:"attr,aws-audience"`
 	AzurermClientId            string `jsonapi:"attr,azurerm-client-id"`
-	AzurermClientSecret        string `jsonapi:"attr,azurerm-client-secret"`
+	AzurermClientSecret        Secret `jsonapi:"attr,azurerm-client-secret"`
 	AzurermSubscriptionId      string `jsonapi:"attr,azurerm-subscription-id"`
 	AzurermTenantId            string `jsonapi:"attr,azurerm-tenant-id"`
 	AzurermAuthType            string `jsonapi:"attr,azurerm-auth-type"`
@@ -56,20 +57,27 @@ type ProviderConfiguration struct {
 	GoogleServiceAccountEmail  string `jsonapi:"attr,google-service-account-email"`
 	GoogleWorkloadProviderName string `jsonapi:"attr,google-workload-provider-name"`
 	GoogleProject              string `jsonapi:"attr,google-project"`
-	GoogleCredentials          string `jsonapi:"attr,google-credentials"`
+	GoogleCredentials          Secret `jsonapi:"attr,google-credentials"`
 	ScalrHostname              string `jsonapi:"attr,scalr-hostname"`
-	ScalrToken                 string `jsonapi:"attr,scalr-token"`
+	ScalrToken                 Secret `jsonapi:"attr,scalr-token"`
+	VersionConstraint          string `jsonapi:"attr,version-constraint"`
 
 	Account      *Account                          `jsonapi:"relation,account"`
 	Parameters   []*ProviderConfigurationParameter `jsonapi:"relation,parameters"`
 	Environments []*Environment                    `jsonapi:"relation,environments"`
 }
 
+// List of attributes provider configurations can be sorted by.
+const (
+	ProviderConfigurationSortByName         SortKey = "name"
+	ProviderConfigurationSortByProviderName SortKey = "provider-name"
+)
+
 // ProviderConfigurationsListOptions represents the options for listing provider configurations.
 type ProviderConfigurationsListOptions struct {
 	ListOptions
 
-	Sort    string                       `url:"sort,omitempty"`
+	Sort    *string                      `url:"sort,omitempty"`
 	Include string                       `url:"include,omitempty"`
 	Filter  *ProviderConfigurationFilter `url:"filter,omitempty"`
 }
@@ -80,10 +88,20 @@ type ProviderConfigurationFilter struct {
 	ProviderName          string `url:"provider-name,omitempty"`
 	Name                  string `url:"name,omitempty"`
 	AccountID             string `url:"account,omitempty"`
+
+	// Filter by the environment the configurations are available to.
+	Environment string `url:"environment,omitempty"`
+
+	// Filter by whether the configurations are shared with all environments.
+	IsShared *bool `url:"is-shared,omitempty"`
 }
 
 // List all the provider configurations within a scalr account.
 func (s *providerConfigurations) List(ctx context.Context, options ProviderConfigurationsListOptions) (*ProviderConfigurationsList, error) {
+	if err := validSort(options.Sort, ProviderConfigurationSortByName, ProviderConfigurationSortByProviderName); err != nil {
+		return nil, err
+	}
+
 	req, err := s.client.newRequest("GET", "provider-configurations", &options)
 	if err != nil {
 		return nil, err
@@ -107,7 +125,7 @@ type ProviderConfigurationCreateOptions struct {
 	IsShared                   *bool   `jsonapi:"attr,is-shared,omitempty"`
 	IsCustom                   *bool   `jsonapi:"attr,is-custom,omitempty"`
 	AwsAccessKey               *string `jsonapi:"attr,aws-access-key,omitempty"`
-	AwsSecretKey               *string `jsonapi:"attr,aws-secret-key,omitempty"`
+	AwsSecretKey               *Secret `jsonapi:"attr,aws-secret-key,omitempty"`
 	AwsAccountType             *string `jsonapi:"attr,aws-account-type"`
 	AwsCredentialsType         *string `jsonapi:"attr,aws-credentials-type"`
 	AwsTrustedEntityType       *string `jsonapi:"attr,aws-trusted-entity-type"`
@@ -115,7 +133,7 @@ type ProviderConfigurationCreateOptions struct {
 	AwsRoleArn                 *string `jsonapi:"attr,aws-role-arn"`
 	AwsExternalId              *string `jsonapi:"attr,aws-external-id"`
 	AzurermClientId            *string `jsonapi:"attr,azurerm-client-id,omitempty"`
-	AzurermClientSecret        *string `jsonapi:"attr,azurerm-client-secret,omitempty"`
+	AzurermClientSecret        *Secret `jsonapi:"attr,azurerm-client-secret,omitempty"`
 	AzurermSubscriptionId      *string `jsonapi:"attr,azurerm-subscription-id,omitempty"`
 	AzurermTenantId            *string `jsonapi:"attr,azurerm-tenant-id,omitempty"`
 	AzurermAuthType            *string `jsonapi:"attr,azurerm-auth-type,omitempty"`
@@ -124,9 +142,10 @@ type ProviderConfigurationCreateOptions struct {
 	GoogleServiceAccountEmail  *string `jsonapi:"attr,google-service-account-email,omitempty"`
 	GoogleWorkloadProviderName *string `jsonapi:"attr,google-workload-provider-name,omitempty"`
 	GoogleProject              *string `jsonapi:"attr,google-project,omitempty"`
-	GoogleCredentials          *string `jsonapi:"attr,google-credentials,omitempty"`
+	GoogleCredentials          *Secret `jsonapi:"attr,google-credentials,omitempty"`
 	ScalrHostname              *string `jsonapi:"attr,scalr-hostname,omitempty"`
-	ScalrToken                 *string `jsonapi:"attr,scalr-token,omitempty"`
+	ScalrToken                 *Secret `jsonapi:"attr,scalr-token,omitempty"`
+	VersionConstraint          *string `jsonapi:"attr,version-constraint,omitempty"`
 
 	Account      *Account       `jsonapi:"relation,account,omitempty"`
 	Environments []*Environment `jsonapi:"relation,environments,omitempty"`
@@ -134,6 +153,12 @@ type ProviderConfigurationCreateOptions struct {
 
 // Create is used to create a new provider configuration.
 func (s *providerConfigurations) Create(ctx context.Context, options ProviderConfigurationCreateOptions) (*ProviderConfiguration, error) {
+	if options.VersionConstraint != nil && !validVersionConstraint(*options.VersionConstraint) {
+		return nil, errors.New("invalid value for version constraint")
+	}
+	if err := options.oidc().valid(); err != nil {
+		return nil, err
+	}
 	options.ID = ""
 
 	req, err := s.client.newRequest("POST", "provider-configurations", &options)
@@ -184,7 +209,7 @@ type ProviderConfigurationUpdateOptions struct {
 	Environments               []*Environment `jsonapi:"relation,environments"`
 	ExportShellVariables       *bool          `jsonapi:"attr,export-shell-variables"`
 	AwsAccessKey               *string        `jsonapi:"attr,aws-access-key"`
-	AwsSecretKey               *string        `jsonapi:"attr,aws-secret-key"`
+	AwsSecretKey               *Secret        `jsonapi:"attr,aws-secret-key"`
 	AwsAccountType             *string        `jsonapi:"attr,aws-account-type"`
 	AwsCredentialsType         *string        `jsonapi:"attr,aws-credentials-type"`
 	AwsTrustedEntityType       *string        `jsonapi:"attr,aws-trusted-entity-type"`
@@ -194,16 +219,19 @@ type ProviderConfigurationUpdateOptions struct {
 	AzurermAuthType            *string        `jsonapi:"attr,azurerm-auth-type"`
 	AzurermAudience            *string        `jsonapi:"attr,azurerm-audience"`
 	AzurermClientId            *string        `jsonapi:"attr,azurerm-client-id"`
-	AzurermClientSecret        *string        `jsonapi:"attr,azurerm-client-secret"`
+	AzurermClientSecret        *Secret        `jsonapi:"attr,azurerm-client-secret"`
 	AzurermSubscriptionId      *string        `jsonapi:"attr,azurerm-subscription-id"`
 	AzurermTenantId            *string        `jsonapi:"attr,azurerm-tenant-id"`
 	GoogleAuthType             *string        `jsonapi:"attr,google-auth-type"`
 	GoogleServiceAccountEmail  *string        `jsonapi:"attr,google-service-account-email"`
 	GoogleWorkloadProviderName *string        `jsonapi:"attr,google-workload-provider-name"`
 	GoogleProject              *string        `jsonapi:"attr,google-project"`
-	GoogleCredentials          *string        `jsonapi:"attr,google-credentials"`
+	GoogleCredentials          *Secret        `jsonapi:"attr,google-credentials"`
 	ScalrHostname              *string        `jsonapi:"attr,scalr-hostname"`
-	ScalrToken                 *string        `jsonapi:"attr,scalr-token"`
+	ScalrToken                 *Secret        `jsonapi:"attr,scalr-token"`
+
+	// VersionConstraint pins the provider version, an empty string removes the constraint.
+	VersionConstraint *string `jsonapi:"attr,version-constraint,omitempty"`
 }
 
 // Update an existing provider configuration.
@@ -211,6 +239,14 @@ func (s *providerConfigurations) Update(ctx context.Context, configurationID str
 	if !validStringID(&configurationID) {
 		return nil, errors.New("invalid value for provider configuration ID")
 	}
+	// An empty version constraint removes it.
+	if options.VersionConstraint != nil && *options.VersionConstraint != "" &&
+		!validVersionConstraint(*options.VersionConstraint) {
+		return nil, errors.New("invalid value for version constraint")
+	}
+	if err := options.oidc().valid(); err != nil {
+		return nil, err
+	}
 
 	// Make sure we don't send a user provided ID.
 	options.ID = ""
@@ -244,3 +280,49 @@ func (s *providerConfigurations) Delete(ctx context.Context, configurationID str
 
 	return s.client.do(ctx, req, nil)
 }
+
+// ProviderConfigurationCheck represents the result of a single credential check.
+type ProviderConfigurationCheck struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message"`
+}
+
+// ProviderConfigurationValidation represents the result of the provider configuration credentials validation.
+type ProviderConfigurationValidation struct {
+	ID     string                        `jsonapi:"primary,provider-configuration-validations"`
+	Valid  bool                          `jsonapi:"attr,valid"`
+	Checks []*ProviderConfigurationCheck `jsonapi:"attr,checks"`
+}
+
+// Failed returns the checks that did not pass.
+func (v *ProviderConfigurationValidation) Failed() []*ProviderConfigurationCheck {
+	var failed []*ProviderConfigurationCheck
+	for _, c := range v.Checks {
+		if !c.Passed {
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
+
+// Validate verifies the credentials of the provider configuration against the cloud provider.
+func (s *providerConfigurations) Validate(ctx context.Context, configurationID string) (*ProviderConfigurationValidation, error) {
+	if !validStringID(&configurationID) {
+		return nil, errors.New("invalid value for provider configuration ID")
+	}
+
+	url_path := fmt.Sprintf("provider-configurations/%s/actions/validate", url.QueryEscape(configurationID))
+	req, err := s.client.newRequest("POST", url_path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	validation := &ProviderConfigurationValidation{}
+	err = s.client.do(ctx, req, validation)
+	if err != nil {
+		return nil, err
+	}
+
+	return validation, nil
+}
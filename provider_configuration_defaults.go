@@ -0,0 +1,166 @@
+package scalr
+
+import (
+	"context"
+	"errors"
+)
+
+// ListEnvironmentDefaults lists the provider configurations applied by default
+// to the workspaces of the environment, i.e. those of its default links.
+func (s *providerConfigurationLinks) ListEnvironmentDefaults(ctx context.Context, environmentID string) ([]*ProviderConfiguration, error) {
+	links, err := s.listAllForEnvironment(ctx, environmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var defaults []*ProviderConfiguration
+	for _, link := range links {
+		if link.Default && link.ProviderConfiguration != nil {
+			defaults = append(defaults, link.ProviderConfiguration)
+		}
+	}
+	return defaults, nil
+}
+
+// SetEnvironmentDefault makes the provider configuration a default of the environment. It updates
+// the environment link of the configuration, or creates a default link if there is none, so the
+// other defaults are left untouched. It does nothing if the configuration is already a default.
+func (s *providerConfigurationLinks) SetEnvironmentDefault(ctx context.Context, environmentID, configurationID string) error {
+	if !validStringID(&configurationID) {
+		return errors.New("invalid value for provider configuration ID")
+	}
+
+	link, err := s.findForEnvironment(ctx, environmentID, configurationID)
+	if err != nil {
+		return err
+	}
+	if link == nil {
+		_, err = s.CreateForEnvironment(ctx, environmentID, ProviderConfigurationLinkCreateOptions{
+			Default:               Bool(true),
+			ProviderConfiguration: &ProviderConfiguration{ID: configurationID},
+		})
+		return err
+	}
+	if link.Default {
+		return nil
+	}
+
+	_, err = s.Update(ctx, link.ID, ProviderConfigurationLinkUpdateOptions{Alias: String(link.Alias), Default: Bool(true)})
+	return err
+}
+
+// UnsetEnvironmentDefault removes the provider configuration from the defaults of the environment,
+// keeping its environment link. It does nothing if the configuration is not a default.
+func (s *providerConfigurationLinks) UnsetEnvironmentDefault(ctx context.Context, environmentID, configurationID string) error {
+	if !validStringID(&configurationID) {
+		return errors.New("invalid value for provider configuration ID")
+	}
+
+	link, err := s.findForEnvironment(ctx, environmentID, configurationID)
+	if err != nil || link == nil || !link.Default {
+		return err
+	}
+
+	_, err = s.Update(ctx, link.ID, ProviderConfigurationLinkUpdateOptions{Alias: String(link.Alias), Default: Bool(false)})
+	return err
+}
+
+// findForEnvironment returns the environment link of the provider configuration, or nil if it is not linked.
+func (s *providerConfigurationLinks) findForEnvironment(
+	ctx context.Context, environmentID, configurationID string,
+) (*ProviderConfigurationLink, error) {
+	links, err := s.listAllForEnvironment(ctx, environmentID)
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range links {
+		if link.ProviderConfiguration != nil && link.ProviderConfiguration.ID == configurationID {
+			return link, nil
+		}
+	}
+	return nil, nil
+}
+
+// listAllForEnvironment lists the environment links of all pages, including the provider configurations.
+func (s *providerConfigurationLinks) listAllForEnvironment(
+	ctx context.Context, environmentID string,
+) ([]*ProviderConfigurationLink, error) {
+	var links []*ProviderConfigurationLink
+	options := ProviderConfigurationLinksListOptions{Include: "provider-configuration"}
+	for {
+		ll, err := s.ListForEnvironment(ctx, environmentID, options)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, ll.Items...)
+		if ll.Pagination == nil || ll.NextPage == 0 {
+			break
+		}
+		options.PageNumber = ll.NextPage
+	}
+	return links, nil
+}
+
+// ResolvedProviderConfiguration represents a provider configuration used by a workspace.
+type ResolvedProviderConfiguration struct {
+	ProviderConfiguration *ProviderConfiguration
+
+	// The alias of the provider, empty for the default provider.
+	Alias string
+
+	// Whether the configuration is an environment default rather than linked to the workspace.
+	Inherited bool
+}
+
+// ResolveForWorkspace returns the provider configurations linked to the workspace along with the
+// environment defaults. A default is skipped if the workspace links another configuration of the
+// same provider without an alias, as the workspace link takes precedence.
+func (s *providerConfigurationLinks) ResolveForWorkspace(ctx context.Context, workspaceID string) ([]*ResolvedProviderConfiguration, error) {
+	ws, err := s.client.Workspaces.ReadByID(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var resolved []*ResolvedProviderConfiguration
+	overridden := make(map[string]bool)
+
+	options := ProviderConfigurationLinksListOptions{Include: "provider-configuration"}
+	for {
+		ll, err := s.List(ctx, workspaceID, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, link := range ll.Items {
+			if link.ProviderConfiguration == nil {
+				continue
+			}
+			if link.Alias == "" {
+				overridden[link.ProviderConfiguration.ProviderName] = true
+			}
+			resolved = append(resolved, &ResolvedProviderConfiguration{
+				ProviderConfiguration: link.ProviderConfiguration,
+				Alias:                 link.Alias,
+			})
+		}
+		if ll.Pagination == nil || ll.NextPage == 0 {
+			break
+		}
+		options.PageNumber = ll.NextPage
+	}
+
+	if ws.Environment == nil {
+		return resolved, nil
+	}
+	defaults, err := s.ListEnvironmentDefaults(ctx, ws.Environment.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, pcfg := range defaults {
+		if overridden[pcfg.ProviderName] {
+			continue
+		}
+		resolved = append(resolved, &ResolvedProviderConfiguration{ProviderConfiguration: pcfg, Inherited: true})
+	}
+
+	return resolved, nil
+}
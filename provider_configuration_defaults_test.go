@@ -0,0 +1,119 @@
+package scalr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderConfigurationDefaults(t *testing.T) {
+	var written []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/iacp/v3/environments/env-1/provider-configuration-links":
+			assert.Equal(t, "provider-configuration", r.URL.Query().Get("include"))
+			fmt.Fprint(w, `{"data":[`+
+				`{"id":"pcl-aws","type":"provider-configuration-links","attributes":{"default":true},`+
+				`"relationships":{"provider-configuration":{"data":{"id":"pcfg-aws","type":"provider-configurations"}}}},`+
+				`{"id":"pcl-google","type":"provider-configuration-links","attributes":{"default":true},`+
+				`"relationships":{"provider-configuration":{"data":{"id":"pcfg-google","type":"provider-configurations"}}}},`+
+				`{"id":"pcl-azure","type":"provider-configuration-links","attributes":{"default":false},`+
+				`"relationships":{"provider-configuration":{"data":{"id":"pcfg-azure","type":"provider-configurations"}}}}],`+
+				`"included":[{"id":"pcfg-aws","type":"provider-configurations","attributes":{"provider-name":"aws"}},`+
+				`{"id":"pcfg-google","type":"provider-configurations","attributes":{"provider-name":"google"}},`+
+				`{"id":"pcfg-azure","type":"provider-configurations","attributes":{"provider-name":"azurerm"}}]}`)
+		case "PATCH /api/iacp/v3/provider-configuration-links/pcl-aws",
+			"PATCH /api/iacp/v3/provider-configuration-links/pcl-azure",
+			"POST /api/iacp/v3/environments/env-1/provider-configuration-links":
+			var body struct {
+				Data struct {
+					Attributes struct {
+						Default bool `json:"default"`
+					} `json:"attributes"`
+				} `json:"data"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			written = append(written, fmt.Sprintf("%s %s default=%t", r.Method, r.URL.Path, body.Data.Attributes.Default))
+			fmt.Fprint(w, `{"data":{"id":"pcl-1","type":"provider-configuration-links"}}`)
+		case "GET /api/iacp/v3/workspaces/ws-1":
+			fmt.Fprint(w, `{"data":{"id":"ws-1","type":"workspaces","relationships":{`+
+				`"environment":{"data":{"id":"env-1","type":"environments"}}}}}`)
+		case "GET /api/iacp/v3/workspaces/ws-1/provider-configuration-links":
+			fmt.Fprint(w, `{"data":[`+
+				`{"id":"pcl-1","type":"provider-configuration-links","attributes":{"alias":""},`+
+				`"relationships":{"provider-configuration":{"data":{"id":"pcfg-aws-ws","type":"provider-configurations"}}}},`+
+				`{"id":"pcl-2","type":"provider-configuration-links","attributes":{"alias":"secondary"},`+
+				`"relationships":{"provider-configuration":{"data":{"id":"pcfg-google-2","type":"provider-configurations"}}}}],`+
+				`"included":[{"id":"pcfg-aws-ws","type":"provider-configurations","attributes":{"provider-name":"aws"}},`+
+				`{"id":"pcfg-google-2","type":"provider-configurations","attributes":{"provider-name":"google"}}]}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("list", func(t *testing.T) {
+		defaults, err := client.ProviderConfigurationLinks.ListEnvironmentDefaults(ctx, "env-1")
+		require.NoError(t, err)
+		require.Len(t, defaults, 2)
+		assert.Equal(t, "aws", defaults[0].ProviderName)
+		assert.Equal(t, "google", defaults[1].ProviderName)
+	})
+
+	t.Run("set linked", func(t *testing.T) {
+		written = nil
+		require.NoError(t, client.ProviderConfigurationLinks.SetEnvironmentDefault(ctx, "env-1", "pcfg-azure"))
+		assert.Equal(t, []string{"PATCH /api/iacp/v3/provider-configuration-links/pcl-azure default=true"}, written)
+	})
+
+	t.Run("set unlinked", func(t *testing.T) {
+		written = nil
+		require.NoError(t, client.ProviderConfigurationLinks.SetEnvironmentDefault(ctx, "env-1", "pcfg-k8s"))
+		assert.Equal(t, []string{"POST /api/iacp/v3/environments/env-1/provider-configuration-links default=true"}, written)
+	})
+
+	t.Run("set existing", func(t *testing.T) {
+		written = nil
+		require.NoError(t, client.ProviderConfigurationLinks.SetEnvironmentDefault(ctx, "env-1", "pcfg-aws"))
+		assert.Nil(t, written)
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		written = nil
+		require.NoError(t, client.ProviderConfigurationLinks.UnsetEnvironmentDefault(ctx, "env-1", "pcfg-aws"))
+		assert.Equal(t, []string{"PATCH /api/iacp/v3/provider-configuration-links/pcl-aws default=false"}, written)
+	})
+
+	t.Run("unset not default", func(t *testing.T) {
+		written = nil
+		require.NoError(t, client.ProviderConfigurationLinks.UnsetEnvironmentDefault(ctx, "env-1", "pcfg-azure"))
+		assert.Nil(t, written)
+	})
+
+	t.Run("resolve for workspace", func(t *testing.T) {
+		resolved, err := client.ProviderConfigurationLinks.ResolveForWorkspace(ctx, "ws-1")
+		require.NoError(t, err)
+		require.Len(t, resolved, 3)
+		assert.Equal(t, "pcfg-aws-ws", resolved[0].ProviderConfiguration.ID)
+		assert.False(t, resolved[0].Inherited)
+		assert.Equal(t, "secondary", resolved[1].Alias)
+		assert.Equal(t, "pcfg-google", resolved[2].ProviderConfiguration.ID)
+		assert.True(t, resolved[2].Inherited)
+	})
+
+	t.Run("with invalid provider configuration ID", func(t *testing.T) {
+		err := client.ProviderConfigurationLinks.SetEnvironmentDefault(ctx, "env-1", badIdentifier)
+		assert.EqualError(t, err, "invalid value for provider configuration ID")
+	})
+}
@@ -17,6 +17,28 @@ type ProviderConfigurationLinks interface {
 	Read(ctx context.Context, linkID string) (*ProviderConfigurationLink, error)
 	Delete(ctx context.Context, linkID string) error
 	Update(ctx context.Context, linkID string, options ProviderConfigurationLinkUpdateOptions) (*ProviderConfigurationLink, error)
+
+	// ListForEnvironment lists the provider configurations linked to the environment.
+	ListForEnvironment(
+		ctx context.Context, environmentID string, options ProviderConfigurationLinksListOptions,
+	) (*ProviderConfigurationLinksList, error)
+	// CreateForEnvironment links a provider configuration to the environment. The default
+	// links are applied to every workspace of the environment, unless the workspace links
+	// a configuration of the same provider itself. Use Delete to remove the link.
+	CreateForEnvironment(
+		ctx context.Context, environmentID string, options ProviderConfigurationLinkCreateOptions,
+	) (*ProviderConfigurationLink, error)
+
+	// ListEnvironmentDefaults lists the provider configurations of the default environment links.
+	// These are the environment defaults; the legacy Environment.DefaultProviderConfigurations
+	// relationship is deprecated and not taken into account.
+	ListEnvironmentDefaults(ctx context.Context, environmentID string) ([]*ProviderConfiguration, error)
+	// SetEnvironmentDefault makes the environment link of the provider configuration a default one.
+	SetEnvironmentDefault(ctx context.Context, environmentID, configurationID string) error
+	// UnsetEnvironmentDefault makes the environment link of the provider configuration a non-default one.
+	UnsetEnvironmentDefault(ctx context.Context, environmentID, configurationID string) error
+	// ResolveForWorkspace returns the provider configurations effectively used by the workspace.
+	ResolveForWorkspace(ctx context.Context, workspaceID string) ([]*ResolvedProviderConfiguration, error)
 }
 
 // providerConfigurationLinks implements ProviderConfigurationLinks.
@@ -41,6 +63,15 @@ type ProviderConfigurationLink struct {
 	Workspace             *Workspace             `jsonapi:"relation,workspace,omitempty"`
 }
 
+// VersionConstraint returns the provider version constraint pinned by the linked
+// provider configuration. The provider configuration must be included in the response.
+func (l *ProviderConfigurationLink) VersionConstraint() string {
+	if l.ProviderConfiguration == nil {
+		return ""
+	}
+	return l.ProviderConfiguration.VersionConstraint
+}
+
 // ProviderConfigurationLinksListOptions represents the options for listing provider configuration links.
 type ProviderConfigurationLinksListOptions struct {
 	ListOptions
@@ -55,7 +86,24 @@ func (s *providerConfigurationLinks) List(ctx context.Context, workspaceID strin
 	}
 
 	url_path := fmt.Sprintf("workspaces/%s/provider-configuration-links", url.QueryEscape(workspaceID))
+	return s.list(ctx, url_path, options)
+}
 
+// ListForEnvironment lists all provider configurations linked to the environment.
+func (s *providerConfigurationLinks) ListForEnvironment(
+	ctx context.Context, environmentID string, options ProviderConfigurationLinksListOptions,
+) (*ProviderConfigurationLinksList, error) {
+	if !validStringID(&environmentID) {
+		return nil, errors.New("invalid value for environment ID")
+	}
+
+	url_path := fmt.Sprintf("environments/%s/provider-configuration-links", url.QueryEscape(environmentID))
+	return s.list(ctx, url_path, options)
+}
+
+func (s *providerConfigurationLinks) list(
+	ctx context.Context, url_path string, options ProviderConfigurationLinksListOptions,
+) (*ProviderConfigurationLinksList, error) {
 	req, err := s.client.newRequest("GET", url_path, &options)
 	if err != nil {
 		return nil, err
@@ -70,11 +118,14 @@ func (s *providerConfigurationLinks) List(ctx context.Context, workspaceID strin
 	return linksList, nil
 }
 
-// ProviderConfigurationLinkCreateOptions represents the options for creating a new provider configuration workspace link.
+// ProviderConfigurationLinkCreateOptions represents the options for creating a new provider configuration link.
 type ProviderConfigurationLinkCreateOptions struct {
 	ID    string  `jsonapi:"primary,provider-configuration-links"`
 	Alias *string `jsonapi:"attr,alias"`
 
+	// Default is only supported by the environment links.
+	Default *bool `jsonapi:"attr,default,omitempty"`
+
 	ProviderConfiguration *ProviderConfiguration `jsonapi:"relation,provider-configuration"`
 }
 
@@ -83,6 +134,28 @@ func (s *providerConfigurationLinks) Create(ctx context.Context, workspaceID str
 	options.ID = ""
 
 	url_path := fmt.Sprintf("workspaces/%s/provider-configuration-links", url.QueryEscape(workspaceID))
+	return s.create(ctx, url_path, options)
+}
+
+// CreateForEnvironment is used to create a new provider configuration environment link.
+func (s *providerConfigurationLinks) CreateForEnvironment(
+	ctx context.Context, environmentID string, options ProviderConfigurationLinkCreateOptions,
+) (*ProviderConfigurationLink, error) {
+	if !validStringID(&environmentID) {
+		return nil, errors.New("invalid value for environment ID")
+	}
+	if options.ProviderConfiguration == nil || !validStringID(&options.ProviderConfiguration.ID) {
+		return nil, errors.New("invalid value for provider configuration ID")
+	}
+	options.ID = ""
+
+	url_path := fmt.Sprintf("environments/%s/provider-configuration-links", url.QueryEscape(environmentID))
+	return s.create(ctx, url_path, options)
+}
+
+func (s *providerConfigurationLinks) create(
+	ctx context.Context, url_path string, options ProviderConfigurationLinkCreateOptions,
+) (*ProviderConfigurationLink, error) {
 	req, err := s.client.newRequest("POST", url_path, &options)
 	if err != nil {
 		return nil, err
@@ -130,6 +203,9 @@ func (s *providerConfigurationLinks) Read(ctx context.Context, linkID string) (*
 type ProviderConfigurationLinkUpdateOptions struct {
 	ID    string  `jsonapi:"primary,provider-configuration-links"`
 	Alias *string `jsonapi:"attr,alias"`
+
+	// Default is only supported by the environment links.
+	Default *bool `jsonapi:"attr,default,omitempty"`
 }
 
 // Update an existing provider configuration link.
@@ -2,7 +2,10 @@ package scalr
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -115,3 +118,66 @@ func TestProviderConfigurationLinkDelete(t *testing.T) {
 		)
 	})
 }
+
+func TestProviderConfigurationLinkEnvironment(t *testing.T) {
+	var created map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/iacp/v3/environments/env-1/provider-configuration-links", r.URL.Path)
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		link := `{"id":"pcl-1","type":"provider-configuration-links","attributes":{"default":true,"alias":""},` +
+			`"relationships":{"provider-configuration":{"data":{"id":"pcfg-1","type":"provider-configurations"}},` +
+			`"environment":{"data":{"id":"env-1","type":"environments"}}}}`
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"data":[`+link+`],"meta":{"pagination":{"current-page":1,"total-count":1}}}`)
+		case "POST":
+			var body struct {
+				Data struct {
+					Attributes map[string]interface{} `json:"attributes"`
+				} `json:"data"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			created = body.Data.Attributes
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"data":`+link+`}`)
+		default:
+			t.Fatalf("unexpected method: %s", r.Method)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("list", func(t *testing.T) {
+		ll, err := client.ProviderConfigurationLinks.ListForEnvironment(ctx, "env-1", ProviderConfigurationLinksListOptions{})
+		require.NoError(t, err)
+		require.Len(t, ll.Items, 1)
+		assert.True(t, ll.Items[0].Default)
+		assert.Equal(t, "env-1", ll.Items[0].Environment.ID)
+		assert.Equal(t, "pcfg-1", ll.Items[0].ProviderConfiguration.ID)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		link, err := client.ProviderConfigurationLinks.CreateForEnvironment(ctx, "env-1", ProviderConfigurationLinkCreateOptions{
+			ProviderConfiguration: &ProviderConfiguration{ID: "pcfg-1"},
+			Default:               Bool(true),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "pcl-1", link.ID)
+		assert.Equal(t, true, created["default"])
+	})
+
+	t.Run("create without provider configuration", func(t *testing.T) {
+		link, err := client.ProviderConfigurationLinks.CreateForEnvironment(ctx, "env-1", ProviderConfigurationLinkCreateOptions{})
+		assert.Nil(t, link)
+		assert.EqualError(t, err, "invalid value for provider configuration ID")
+	})
+
+	t.Run("with invalid environment ID", func(t *testing.T) {
+		ll, err := client.ProviderConfigurationLinks.ListForEnvironment(ctx, badIdentifier, ProviderConfigurationLinksListOptions{})
+		assert.Nil(t, ll)
+		assert.EqualError(t, err, "invalid value for environment ID")
+	})
+}
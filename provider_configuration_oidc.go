@@ -0,0 +1,128 @@
+package scalr
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// ProviderConfigurationOIDC is the value of the AwsCredentialsType, AzurermAuthType
+// and GoogleAuthType attributes authenticating with the OIDC tokens issued by
+// Scalr for each run, so no static credentials are stored in the configuration.
+const ProviderConfigurationOIDC = "oidc"
+
+// A regular expression used to validate the full name of a Google workload identity pool provider.
+var reGoogleWorkloadProvider = regexp.MustCompile(
+	`^projects/\d+/locations/global/workloadIdentityPools/[a-z0-9-]+/providers/[a-z0-9-]+$`,
+)
+
+// providerOIDCSettings holds the attributes of the OIDC authentication that
+// are common to the provider configuration create and update options.
+type providerOIDCSettings struct {
+	// partial is set for the update options, which only carry the changed
+	// attributes, so the attributes required by the oidc type are not checked.
+	partial bool
+
+	awsCredentialsType *string
+	awsRoleArn         *string
+	awsAudience        *string
+	awsSecretKey       *Secret
+
+	azurermAuthType     *string
+	azurermClientId     *string
+	azurermTenantId     *string
+	azurermAudience     *string
+	azurermClientSecret *Secret
+
+	googleAuthType             *string
+	googleServiceAccountEmail  *string
+	googleWorkloadProviderName *string
+	googleCredentials          *Secret
+}
+
+// valid checks the format of the given OIDC attributes, that no static secret
+// is set along with the oidc type and, on create, that the attributes the oidc
+// type requires are set.
+func (o providerOIDCSettings) valid() error {
+	required := func(authType *string) bool {
+		return !o.partial && isOIDC(authType)
+	}
+
+	switch {
+	case required(o.awsCredentialsType) && !validString(o.awsRoleArn):
+		return errors.New("aws role arn is required for the oidc credentials type")
+	case validString(o.awsRoleArn) && !strings.HasPrefix(*o.awsRoleArn, "arn:"):
+		return errors.New("invalid value for aws role arn")
+	case required(o.awsCredentialsType) && !validString(o.awsAudience):
+		return errors.New("aws audience is required for the oidc credentials type")
+	case isOIDC(o.awsCredentialsType) && o.awsSecretKey != nil && *o.awsSecretKey != "":
+		return errors.New("aws secret key must not be set for the oidc credentials type")
+	}
+
+	switch {
+	case required(o.azurermAuthType) && !validString(o.azurermClientId):
+		return errors.New("azurerm client ID is required for the oidc auth type")
+	case required(o.azurermAuthType) && !validString(o.azurermTenantId):
+		return errors.New("azurerm tenant ID is required for the oidc auth type")
+	case required(o.azurermAuthType) && !validString(o.azurermAudience):
+		return errors.New("azurerm audience is required for the oidc auth type")
+	case isOIDC(o.azurermAuthType) && o.azurermClientSecret != nil && *o.azurermClientSecret != "":
+		return errors.New("azurerm client secret must not be set for the oidc auth type")
+	}
+
+	switch {
+	case required(o.googleAuthType) && !validString(o.googleServiceAccountEmail):
+		return errors.New("google service account email is required for the oidc auth type")
+	case validString(o.googleServiceAccountEmail) && !strings.Contains(*o.googleServiceAccountEmail, "@"):
+		return errors.New("invalid value for google service account email")
+	case required(o.googleAuthType) && !validString(o.googleWorkloadProviderName):
+		return errors.New("google workload provider name is required for the oidc auth type")
+	case validString(o.googleWorkloadProviderName) && !reGoogleWorkloadProvider.MatchString(*o.googleWorkloadProviderName):
+		return errors.New("invalid value for google workload provider name")
+	case isOIDC(o.googleAuthType) && o.googleCredentials != nil && *o.googleCredentials != "":
+		return errors.New("google credentials must not be set for the oidc auth type")
+	}
+
+	return nil
+}
+
+func isOIDC(authType *string) bool {
+	return authType != nil && *authType == ProviderConfigurationOIDC
+}
+
+func (o ProviderConfigurationCreateOptions) oidc() providerOIDCSettings {
+	return providerOIDCSettings{
+		awsCredentialsType:         o.AwsCredentialsType,
+		awsRoleArn:                 o.AwsRoleArn,
+		awsAudience:                o.AwsAudience,
+		awsSecretKey:               o.AwsSecretKey,
+		azurermAuthType:            o.AzurermAuthType,
+		azurermClientId:            o.AzurermClientId,
+		azurermTenantId:            o.AzurermTenantId,
+		azurermAudience:            o.AzurermAudience,
+		azurermClientSecret:        o.AzurermClientSecret,
+		googleAuthType:             o.GoogleAuthType,
+		googleServiceAccountEmail:  o.GoogleServiceAccountEmail,
+		googleWorkloadProviderName: o.GoogleWorkloadProviderName,
+		googleCredentials:          o.GoogleCredentials,
+	}
+}
+
+func (o ProviderConfigurationUpdateOptions) oidc() providerOIDCSettings {
+	return providerOIDCSettings{
+		partial:                    true,
+		awsCredentialsType:         o.AwsCredentialsType,
+		awsRoleArn:                 o.AwsRoleArn,
+		awsAudience:                o.AwsAudience,
+		awsSecretKey:               o.AwsSecretKey,
+		azurermAuthType:            o.AzurermAuthType,
+		azurermClientId:            o.AzurermClientId,
+		azurermTenantId:            o.AzurermTenantId,
+		azurermAudience:            o.AzurermAudience,
+		azurermClientSecret:        o.AzurermClientSecret,
+		googleAuthType:             o.GoogleAuthType,
+		googleServiceAccountEmail:  o.GoogleServiceAccountEmail,
+		googleWorkloadProviderName: o.GoogleWorkloadProviderName,
+		googleCredentials:          o.GoogleCredentials,
+	}
+}
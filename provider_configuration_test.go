@@ -60,7 +60,7 @@ func TestProviderConfigurationCreateAzurerm(t *testing.T) {
 			ProviderName:          String("azurerm"),
 			ExportShellVariables:  Bool(false),
 			AzurermClientId:       String(armClientId),
-			AzurermClientSecret:   String(armClientSecret),
+			AzurermClientSecret:   SecretString(armClientSecret),
 			AzurermSubscriptionId: String(armSubscriptionId),
 			AzurermTenantId:       String(armTenantId),
 		}
@@ -78,7 +78,7 @@ func TestProviderConfigurationCreateAzurerm(t *testing.T) {
 		assert.Equal(t, *options.ProviderName, pcfg.ProviderName)
 		assert.Equal(t, *options.ExportShellVariables, pcfg.ExportShellVariables)
 		assert.Equal(t, *options.AzurermClientId, pcfg.AzurermClientId)
-		assert.Equal(t, "", pcfg.AzurermClientSecret)
+		assert.Empty(t, pcfg.AzurermClientSecret)
 		assert.Equal(t, *options.AzurermSubscriptionId, pcfg.AzurermSubscriptionId)
 		assert.Equal(t, *options.AzurermTenantId, pcfg.AzurermTenantId)
 	})
@@ -86,8 +86,8 @@ func TestProviderConfigurationCreateAzurerm(t *testing.T) {
 
 func TestProviderConfigurationCreateScalr(t *testing.T) {
 	client := testClient(t)
-	scalrHostname := client.baseURL.Host
-	scalrToken := client.token
+	scalrHostname := client.BaseURL().Host
+	scalrToken := client.Token()
 	ctx := context.Background()
 
 	t.Run("success scalr", func(t *testing.T) {
@@ -97,7 +97,7 @@ func TestProviderConfigurationCreateScalr(t *testing.T) {
 			ProviderName:         String("scalr"),
 			ExportShellVariables: Bool(false),
 			ScalrHostname:        String(scalrHostname),
-			ScalrToken:           String(scalrToken),
+			ScalrToken:           SecretString(scalrToken),
 		}
 		pcfg, err := client.ProviderConfigurations.Create(ctx, options)
 		if err != nil {
@@ -113,7 +113,7 @@ func TestProviderConfigurationCreateScalr(t *testing.T) {
 		assert.Equal(t, *options.ProviderName, pcfg.ProviderName)
 		assert.Equal(t, *options.ExportShellVariables, pcfg.ExportShellVariables)
 		assert.Equal(t, *options.ScalrHostname, pcfg.ScalrHostname)
-		assert.Equal(t, "", pcfg.ScalrToken)
+		assert.Empty(t, pcfg.ScalrToken)
 	})
 }
 
@@ -130,7 +130,7 @@ func TestProviderConfigurationCreateAws(t *testing.T) {
 			ProviderName:         String("aws"),
 			ExportShellVariables: Bool(false),
 			AwsAccessKey:         String(accessKeyId),
-			AwsSecretKey:         String(secretAccessKey),
+			AwsSecretKey:         SecretString(secretAccessKey),
 			AwsAccountType:       String("regular"),
 			AwsCredentialsType:   String("access_keys"),
 		}
@@ -150,7 +150,7 @@ func TestProviderConfigurationCreateAws(t *testing.T) {
 		assert.Equal(t, *options.AwsAccessKey, pcfg.AwsAccessKey)
 		assert.Equal(t, *options.AwsAccountType, pcfg.AwsAccountType)
 		assert.Equal(t, *options.AwsCredentialsType, pcfg.AwsCredentialsType)
-		assert.Equal(t, "", pcfg.AwsSecretKey)
+		assert.Empty(t, pcfg.AwsSecretKey)
 	})
 
 	t.Run("success aws role delegation auth service entity", func(t *testing.T) {
@@ -196,7 +196,7 @@ func TestProviderConfigurationCreateAws(t *testing.T) {
 			AwsCredentialsType:   String("role_delegation"),
 			AwsTrustedEntityType: String("aws_account"),
 			AwsAccessKey:         String(accessKeyId),
-			AwsSecretKey:         String(secretAccessKey),
+			AwsSecretKey:         SecretString(secretAccessKey),
 			AwsRoleArn:           String(roleArn),
 			AwsExternalId:        String(externalId),
 		}
@@ -217,7 +217,7 @@ func TestProviderConfigurationCreateAws(t *testing.T) {
 		assert.Equal(t, *options.AwsCredentialsType, pcfg.AwsCredentialsType)
 		assert.Equal(t, *options.AwsTrustedEntityType, pcfg.AwsTrustedEntityType)
 		assert.Equal(t, *options.AwsAccessKey, pcfg.AwsAccessKey)
-		assert.Equal(t, "", pcfg.AwsSecretKey)
+		assert.Empty(t, pcfg.AwsSecretKey)
 		assert.Equal(t, *options.AwsRoleArn, pcfg.AwsRoleArn)
 		assert.Equal(t, *options.AwsExternalId, pcfg.AwsExternalId)
 	})
@@ -237,7 +237,7 @@ func TestProviderConfigurationCreateGoogle(t *testing.T) {
 			ExportShellVariables: Bool(false),
 			IsCustom:             Bool(false),
 			GoogleProject:        String(project),
-			GoogleCredentials:    String(credentials),
+			GoogleCredentials:    SecretString(credentials),
 		}
 		pcfg, err := client.ProviderConfigurations.Create(ctx, options)
 		if err != nil {
@@ -253,7 +253,7 @@ func TestProviderConfigurationCreateGoogle(t *testing.T) {
 		assert.Equal(t, *options.ProviderName, pcfg.ProviderName)
 		assert.Equal(t, *options.ExportShellVariables, pcfg.ExportShellVariables)
 		assert.Equal(t, *options.GoogleProject, pcfg.GoogleProject)
-		assert.Equal(t, "", pcfg.GoogleCredentials)
+		assert.Empty(t, pcfg.GoogleCredentials)
 		assert.Equal(t, "service-account-key", pcfg.GoogleAuthType)
 	})
 }
@@ -316,6 +316,54 @@ func TestProviderConfigurationCreateShared(t *testing.T) {
 	})
 }
 
+func TestProviderConfigurationCreateWithVersionConstraint(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		options := ProviderConfigurationCreateOptions{
+			Account:           &Account{ID: defaultAccountID},
+			Name:              String("consul_pinned"),
+			ProviderName:      String("consul"),
+			VersionConstraint: String(">= 2.15, < 3.0"),
+		}
+		pcfg, err := client.ProviderConfigurations.Create(ctx, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer client.ProviderConfigurations.Delete(ctx, pcfg.ID)
+
+		pcfg, err = client.ProviderConfigurations.Read(ctx, pcfg.ID)
+		require.NoError(t, err)
+		assert.Equal(t, *options.VersionConstraint, pcfg.VersionConstraint)
+		pcfg, err = client.ProviderConfigurations.Update(ctx, pcfg.ID, ProviderConfigurationUpdateOptions{
+			VersionConstraint: String(""),
+		})
+		require.NoError(t, err)
+		assert.Empty(t, pcfg.VersionConstraint)
+	})
+
+	t.Run("with invalid version constraint", func(t *testing.T) {
+		pcfg, err := client.ProviderConfigurations.Create(ctx, ProviderConfigurationCreateOptions{
+			Account:           &Account{ID: defaultAccountID},
+			Name:              String("consul_pinned"),
+			ProviderName:      String("consul"),
+			VersionConstraint: String("latest"),
+		})
+		assert.Nil(t, pcfg)
+		assert.EqualError(t, err, "invalid value for version constraint")
+	})
+}
+
+func TestValidVersionConstraint(t *testing.T) {
+	for _, v := range []string{"5.0", ">= 5.0", "~> 5.1.2", ">=5.0,<6.0", "= 1.2.3-beta1"} {
+		assert.True(t, validVersionConstraint(v), v)
+	}
+	for _, v := range []string{"", "latest", ">= 5.0,", "=> 5.0", "5.0.0.1"} {
+		assert.False(t, validVersionConstraint(v), v)
+	}
+}
+
 func TestProviderConfigurationRead(t *testing.T) {
 	client := testClient(t)
 	ctx := context.Background()
@@ -414,6 +462,31 @@ func TestProviderConfigurationList(t *testing.T) {
 		assert.Contains(t, resultNames, "kubernetes_prod_us_east_1")
 		assert.Contains(t, resultNames, "kubernetes_prod_us_east_2")
 	})
+
+	t.Run("filtering by environment and is-shared", func(t *testing.T) {
+		envTest, envTestCleanup := createEnvironment(t, client)
+		defer envTestCleanup()
+
+		configuration, err := client.ProviderConfigurations.Create(ctx, ProviderConfigurationCreateOptions{
+			Account:      &Account{ID: defaultAccountID},
+			Name:         String("kubernetes_env_scoped"),
+			ProviderName: String("kubernetes"),
+			IsShared:     Bool(false),
+			Environments: []*Environment{envTest},
+		})
+		require.NoError(t, err)
+		defer client.ProviderConfigurations.Delete(ctx, configuration.ID)
+
+		configurationsList, err := client.ProviderConfigurations.List(ctx, ProviderConfigurationsListOptions{
+			Filter: &ProviderConfigurationFilter{
+				Environment: envTest.ID,
+				IsShared:    Bool(false),
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(configurationsList.Items))
+		assert.Equal(t, configuration.ID, configurationsList.Items[0].ID)
+	})
 }
 
 func TestProviderConfigurationUpdateAzurerm(t *testing.T) {
@@ -428,7 +501,7 @@ func TestProviderConfigurationUpdateAzurerm(t *testing.T) {
 			ProviderName:          String("azurerm"),
 			ExportShellVariables:  Bool(false),
 			AzurermClientId:       String(armClientId),
-			AzurermClientSecret:   String(armClientSecret),
+			AzurermClientSecret:   SecretString(armClientSecret),
 			AzurermSubscriptionId: String(armSubscriptionId),
 			AzurermTenantId:       String(armTenantId),
 		}
@@ -442,7 +515,7 @@ func TestProviderConfigurationUpdateAzurerm(t *testing.T) {
 			Name:                  String("azurerm_dev_updated"),
 			ExportShellVariables:  Bool(true),
 			AzurermClientId:       String(armClientId),
-			AzurermClientSecret:   String(armClientSecret),
+			AzurermClientSecret:   SecretString(armClientSecret),
 			AzurermSubscriptionId: String(armSubscriptionId),
 			AzurermTenantId:       String(armTenantId),
 		}
@@ -454,7 +527,7 @@ func TestProviderConfigurationUpdateAzurerm(t *testing.T) {
 		assert.Equal(t, *updateOptions.Name, updatedConfiguration.Name)
 		assert.Equal(t, *updateOptions.ExportShellVariables, updatedConfiguration.ExportShellVariables)
 		assert.Equal(t, *updateOptions.AzurermClientId, updatedConfiguration.AzurermClientId)
-		assert.Equal(t, "", updatedConfiguration.AzurermClientSecret)
+		assert.Empty(t, updatedConfiguration.AzurermClientSecret)
 		assert.Equal(t, *updateOptions.AzurermSubscriptionId, updatedConfiguration.AzurermSubscriptionId)
 		assert.Equal(t, *updateOptions.AzurermTenantId, updatedConfiguration.AzurermTenantId)
 	})
@@ -491,7 +564,7 @@ func TestProviderConfigurationUpdateAws(t *testing.T) {
 			AwsCredentialsType:   String("role_delegation"),
 			AwsTrustedEntityType: String("aws_account"),
 			AwsAccessKey:         String(accessKeyId),
-			AwsSecretKey:         String(secretAccessKey),
+			AwsSecretKey:         SecretString(secretAccessKey),
 			AwsRoleArn:           String(roleArn),
 			AwsExternalId:        String(externalId),
 		}
@@ -522,7 +595,7 @@ func TestProviderConfigurationUpdateGoogle(t *testing.T) {
 			ProviderName:         String("google"),
 			ExportShellVariables: Bool(false),
 			GoogleProject:        String(project),
-			GoogleCredentials:    String(credentials),
+			GoogleCredentials:    SecretString(credentials),
 		}
 		configuration, err := client.ProviderConfigurations.Create(ctx, createOptions)
 		if err != nil {
@@ -534,7 +607,7 @@ func TestProviderConfigurationUpdateGoogle(t *testing.T) {
 			Name:                 String("google_dev2"),
 			ExportShellVariables: Bool(true),
 			GoogleProject:        String(project),
-			GoogleCredentials:    String(credentials),
+			GoogleCredentials:    SecretString(credentials),
 		}
 		updatedConfiguration, err := client.ProviderConfigurations.Update(
 			ctx, configuration.ID, updateOptions,
@@ -543,15 +616,15 @@ func TestProviderConfigurationUpdateGoogle(t *testing.T) {
 		assert.Equal(t, *updateOptions.Name, updatedConfiguration.Name)
 		assert.Equal(t, *updateOptions.ExportShellVariables, updatedConfiguration.ExportShellVariables)
 		assert.Equal(t, *updateOptions.GoogleProject, updatedConfiguration.GoogleProject)
-		assert.Equal(t, "", updatedConfiguration.GoogleCredentials)
+		assert.Empty(t, updatedConfiguration.GoogleCredentials)
 	})
 }
 
 func TestProviderConfigurationUpdateScalr(t *testing.T) {
 	client := testClient(t)
 	ctx := context.Background()
-	scalrHostname := client.baseURL.Host
-	scalrToken := client.token
+	scalrHostname := client.BaseURL().Host
+	scalrToken := client.Token()
 
 	environment, deleteEnvironment := createEnvironment(t, client)
 	defer deleteEnvironment()
@@ -563,7 +636,7 @@ func TestProviderConfigurationUpdateScalr(t *testing.T) {
 			ProviderName:         String("scalr"),
 			ExportShellVariables: Bool(false),
 			ScalrHostname:        String(scalrHostname),
-			ScalrToken:           String(scalrToken),
+			ScalrToken:           SecretString(scalrToken),
 			IsShared:             Bool(false),
 			Environments:         []*Environment{environment},
 		}
@@ -577,7 +650,7 @@ func TestProviderConfigurationUpdateScalr(t *testing.T) {
 			Name:                 String("scalr_prod"),
 			ExportShellVariables: Bool(true),
 			ScalrHostname:        String(scalrHostname + "/"),
-			ScalrToken:           String(scalrToken),
+			ScalrToken:           SecretString(scalrToken),
 			IsShared:             Bool(true),
 			Environments:         []*Environment{},
 		}
@@ -613,3 +686,26 @@ func TestProviderConfigurationDelete(t *testing.T) {
 		)
 	})
 }
+
+func TestProviderConfigurationValidate(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	t.Run("with invalid credentials", func(t *testing.T) {
+		configuration, removeConfiguration := createProviderConfigurationScalr(
+			t, client, "scalr", "scalr_invalid", "scalr.invalid", "invalid-token",
+		)
+		defer removeConfiguration()
+
+		validation, err := client.ProviderConfigurations.Validate(ctx, configuration.ID)
+		require.NoError(t, err)
+		assert.False(t, validation.Valid)
+		assert.NotEmpty(t, validation.Failed())
+	})
+
+	t.Run("with invalid configuration ID", func(t *testing.T) {
+		validation, err := client.ProviderConfigurations.Validate(ctx, badIdentifier)
+		assert.Nil(t, validation)
+		assert.EqualError(t, err, "invalid value for provider configuration ID")
+	})
+}
@@ -0,0 +1,138 @@
+package scalr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Compile-time proof of interface implementation.
+var _ Provisioner = (*provisioner)(nil)
+
+// Provisioner describes composite operations that set up several related
+// resources at once and roll them back on failure.
+type Provisioner interface {
+	// CreateWorkspace creates a workspace together with its variables,
+	// provider configuration links, tags and run triggers.
+	CreateWorkspace(ctx context.Context, spec WorkspaceSpec) (*Workspace, error)
+}
+
+// provisioner implements Provisioner.
+type provisioner struct {
+	client *Client
+}
+
+// WorkspaceSpec represents the full specification of a workspace to provision.
+type WorkspaceSpec struct {
+	// The options used to create the workspace itself.
+	Workspace WorkspaceCreateOptions
+
+	// Variables to create in the workspace. The workspace relation is set automatically.
+	Variables []VariableCreateOptions
+
+	// Provider configurations to link to the workspace.
+	ProviderConfigurationLinks []ProviderConfigurationLinkCreateOptions
+
+	// Tags to assign to the workspace.
+	Tags []*Tag
+
+	// Workspaces which trigger runs in the new workspace on successful apply.
+	Upstreams []*Workspace
+}
+
+func (o WorkspaceSpec) valid() error {
+	if err := o.Workspace.valid(); err != nil {
+		return err
+	}
+	for i, v := range o.Variables {
+		if err := v.valid(); err != nil {
+			return fmt.Errorf("variable %d: %v", i, err)
+		}
+	}
+	for i, l := range o.ProviderConfigurationLinks {
+		if l.ProviderConfiguration == nil {
+			return fmt.Errorf("provider configuration link %d: provider configuration is required", i)
+		}
+	}
+	for i, ws := range o.Upstreams {
+		if ws == nil || !validStringID(&ws.ID) {
+			return fmt.Errorf("upstream %d: invalid value for workspace ID", i)
+		}
+	}
+	return nil
+}
+
+// CreateWorkspace creates a workspace and all of the resources described by the spec.
+// If any step fails, the resources created so far are deleted and the original
+// error is returned. The rollback runs even if ctx is canceled.
+func (s *provisioner) CreateWorkspace(ctx context.Context, spec WorkspaceSpec) (*Workspace, error) {
+	if err := spec.valid(); err != nil {
+		return nil, err
+	}
+
+	options := spec.Workspace
+	if len(spec.Tags) > 0 {
+		// Do not write into the backing array of the caller's tags.
+		tags := make([]*Tag, 0, len(options.Tags)+len(spec.Tags))
+		tags = append(tags, options.Tags...)
+		options.Tags = append(tags, spec.Tags...)
+	}
+
+	ws, err := s.client.Workspaces.Create(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var triggerIDs []string
+	rollback := func(cause error) error {
+		ctx, cancel := cleanupContext()
+		defer cancel()
+
+		var errs []string
+		for _, id := range triggerIDs {
+			if err := s.client.RunTriggers.Delete(ctx, id); err != nil {
+				errs = append(errs, fmt.Sprintf("run trigger %s: %v", id, err))
+			}
+		}
+		// Variables and provider configuration links are removed with the workspace.
+		if err := s.client.Workspaces.Delete(ctx, ws.ID); err != nil {
+			errs = append(errs, fmt.Sprintf("workspace %s: %v", ws.ID, err))
+		}
+		if len(errs) > 0 {
+			return fmt.Errorf(
+				"%v\n\nrollback failed, dangling resources may exist:\n%s", cause, strings.Join(errs, "\n"),
+			)
+		}
+		return cause
+	}
+
+	for _, v := range spec.Variables {
+		v.Workspace = &Workspace{ID: ws.ID}
+		v.Environment = nil
+		v.Account = nil
+		if _, err := s.client.Variables.Create(ctx, v); err != nil {
+			return nil, rollback(fmt.Errorf("error creating variable %s: %v", *v.Key, err))
+		}
+	}
+
+	for _, l := range spec.ProviderConfigurationLinks {
+		if _, err := s.client.ProviderConfigurationLinks.Create(ctx, ws.ID, l); err != nil {
+			return nil, rollback(fmt.Errorf(
+				"error linking provider configuration %s: %v", l.ProviderConfiguration.ID, err,
+			))
+		}
+	}
+
+	for _, upstream := range spec.Upstreams {
+		rt, err := s.client.RunTriggers.Create(ctx, RunTriggerCreateOptions{
+			Downstream: &Downstream{ID: ws.ID},
+			Upstream:   &Upstream{ID: upstream.ID},
+		})
+		if err != nil {
+			return nil, rollback(fmt.Errorf("error creating run trigger from %s: %v", upstream.ID, err))
+		}
+		triggerIDs = append(triggerIDs, rt.ID)
+	}
+
+	return ws, nil
+}
@@ -0,0 +1,137 @@
+package scalr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvisionerCreateWorkspace(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	envTest, envTestCleanup := createEnvironment(t, client)
+	defer envTestCleanup()
+
+	upstream, upstreamCleanup := createWorkspace(t, client, envTest)
+	defer upstreamCleanup()
+
+	tag, tagCleanup := createTag(t, client)
+	defer tagCleanup()
+
+	t.Run("with valid spec", func(t *testing.T) {
+		spec := WorkspaceSpec{
+			Workspace: WorkspaceCreateOptions{
+				Name:        String("tst-" + randomString(t)),
+				Environment: envTest,
+			},
+			Variables: []VariableCreateOptions{
+				{
+					Key:      String(randomVariableKey(t)),
+					Value:    SecretString(randomString(t)),
+					Category: Category(CategoryTerraform),
+				},
+			},
+			Tags:      []*Tag{tag},
+			Upstreams: []*Workspace{upstream},
+		}
+
+		ws, err := client.Provisioner.CreateWorkspace(ctx, spec)
+		require.NoError(t, err)
+		defer client.Workspaces.Delete(ctx, ws.ID)
+
+		vl, err := client.Variables.List(ctx, VariableListOptions{
+			Filter: &VariableFilter{Workspace: String(ws.ID)},
+		})
+		require.NoError(t, err)
+		require.Len(t, vl.Items, 1)
+		assert.Equal(t, *spec.Variables[0].Key, vl.Items[0].Key)
+
+		require.Len(t, ws.Tags, 1)
+		assert.Equal(t, tag.ID, ws.Tags[0].ID)
+	})
+
+	t.Run("rolls back on failure", func(t *testing.T) {
+		name := "tst-" + randomString(t)
+		_, err := client.Provisioner.CreateWorkspace(ctx, WorkspaceSpec{
+			Workspace: WorkspaceCreateOptions{
+				Name:        String(name),
+				Environment: envTest,
+			},
+			ProviderConfigurationLinks: []ProviderConfigurationLinkCreateOptions{
+				{ProviderConfiguration: &ProviderConfiguration{ID: "pcfg-nonexisting"}},
+			},
+		})
+		require.Error(t, err)
+
+		wl, err := client.Workspaces.List(ctx, WorkspaceListOptions{
+			Filter: &WorkspaceFilter{Environment: String(envTest.ID), Name: String(name)},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, wl.Items)
+	})
+
+	t.Run("with invalid variable", func(t *testing.T) {
+		ws, err := client.Provisioner.CreateWorkspace(ctx, WorkspaceSpec{
+			Workspace: WorkspaceCreateOptions{
+				Name:        String("tst-" + randomString(t)),
+				Environment: envTest,
+			},
+			Variables: []VariableCreateOptions{{Category: Category(CategoryEnv)}},
+		})
+		assert.Nil(t, ws)
+		assert.EqualError(t, err, "variable 0: key is required")
+	})
+}
+
+func TestProvisionerCreateWorkspaceRollback(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var deleted bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /api/iacp/v3/workspaces":
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"data":{"id":"ws-123","type":"workspaces","attributes":{"name":"foo"}}}`)
+		case "POST /api/iacp/v3/workspaces/ws-123/provider-configuration-links":
+			// The caller gives up while the workspace is being set up.
+			cancel()
+			w.WriteHeader(http.StatusNotFound)
+		case "DELETE /api/iacp/v3/workspaces/ws-123":
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+
+	tags := make([]*Tag, 1, 2)
+	tags[0] = &Tag{ID: "tag-1"}
+	_, err = client.Provisioner.CreateWorkspace(ctx, WorkspaceSpec{
+		Workspace: WorkspaceCreateOptions{
+			Name:        String("foo"),
+			Environment: &Environment{ID: "env-123"},
+			Tags:        tags,
+		},
+		Tags: []*Tag{{ID: "tag-2"}},
+		ProviderConfigurationLinks: []ProviderConfigurationLinkCreateOptions{
+			{ProviderConfiguration: &ProviderConfiguration{ID: "pcfg-123"}},
+		},
+	})
+	require.Error(t, err)
+	assert.True(t, deleted, "the workspace was not deleted")
+	assert.Len(t, tags, 1)
+	assert.Nil(t, tags[:2][1], "the caller's tags were modified")
+}
@@ -0,0 +1,138 @@
+package scalr
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+)
+
+// DefaultMaxRetryAfter is the default cap of the wait before retrying a
+// rate limited request, see Config.MaxRetryAfter.
+const DefaultMaxRetryAfter = time.Minute
+
+// RateLimitStatus represents the API rate limit quota reported by the response headers.
+type RateLimitStatus struct {
+	// The number of requests allowed in the current window, -1 if unknown.
+	Limit int
+	// The number of requests left in the current window, -1 if unknown.
+	Remaining int
+	// The time the quota is reset at, zero if unknown.
+	Reset time.Time
+	// How long to wait before retrying, from the Retry-After header.
+	RetryAfter time.Duration
+}
+
+// ParseRateLimitStatus reads the rate limit headers of the response. It returns
+// nil if the response has none, e.g. to be used by a RetryLogHook.
+func ParseRateLimitStatus(resp *http.Response) *RateLimitStatus {
+	if resp == nil {
+		return nil
+	}
+	s := &RateLimitStatus{Limit: -1, Remaining: -1}
+	found := false
+	now := time.Now()
+
+	if v, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit")); err == nil {
+		s.Limit, found = v, true
+	}
+	if v, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); err == nil {
+		s.Remaining, found = v, true
+	}
+	if v, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		// The reset is either a unix timestamp or the seconds left.
+		if v > 1e9 {
+			s.Reset = time.Unix(v, 0)
+		} else {
+			s.Reset = now.Add(time.Duration(v) * time.Second)
+		}
+		found = true
+	}
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil {
+			s.RetryAfter = time.Duration(seconds) * time.Second
+		} else if t, err := http.ParseTime(v); err == nil {
+			s.RetryAfter = t.Sub(now)
+		}
+		found = true
+	}
+	if !found {
+		return nil
+	}
+	return s
+}
+
+// wait returns how long to wait before the next request, at most max, zero if unknown.
+func (s *RateLimitStatus) wait(max time.Duration) time.Duration {
+	wait := s.RetryAfter
+	if wait <= 0 && !s.Reset.IsZero() {
+		wait = time.Until(s.Reset)
+	}
+	if wait < 0 {
+		return 0
+	}
+	if wait > max {
+		return max
+	}
+	return wait
+}
+
+// rateLimitTracker keeps the last rate limit status reported by the API.
+type rateLimitTracker struct {
+	mu     sync.Mutex
+	status *RateLimitStatus
+}
+
+func (t *rateLimitTracker) update(resp *http.Response) {
+	if s := ParseRateLimitStatus(resp); s != nil {
+		t.mu.Lock()
+		t.status = s
+		t.mu.Unlock()
+	}
+}
+
+func (t *rateLimitTracker) get() *RateLimitStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.status == nil {
+		return nil
+	}
+	s := *t.status
+	return &s
+}
+
+// RateLimitStatus returns the rate limit quota reported by the last API response
+// that had the rate limit headers, or nil if there was none yet.
+func (c *Client) RateLimitStatus() *RateLimitStatus {
+	return c.rateLimits.get()
+}
+
+// maxRetryAfter returns the MaxRetryAfter of the config, or DefaultMaxRetryAfter if it is not set.
+func (c *Client) maxRetryAfter() time.Duration {
+	if c.config.MaxRetryAfter > 0 {
+		return c.config.MaxRetryAfter
+	}
+	return DefaultMaxRetryAfter
+}
+
+// retryHTTPBackoff provides a callback for Client.Backoff which waits as long
+// as a rate limited response asks to, and calls the RetryLogHook before each retry.
+func (c *Client) retryHTTPBackoff(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	// The attempts are counted from zero, the hook gets the number of the retry.
+	if c.retryLogHook != nil {
+		c.retryLogHook(attemptNum+1, resp)
+	}
+
+	if resp != nil && (resp.StatusCode == 429 || resp.StatusCode == 503) {
+		c.rateLimits.update(resp)
+		if s := ParseRateLimitStatus(resp); s != nil {
+			if wait := s.wait(c.maxRetryAfter()); wait > 0 {
+				return wait
+			}
+		}
+	}
+
+	return retryablehttp.DefaultBackoff(min, max, attemptNum, resp)
+}
@@ -0,0 +1,101 @@
+package scalr
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateLimitStatus(t *testing.T) {
+	t.Run("without headers", func(t *testing.T) {
+		assert.Nil(t, ParseRateLimitStatus(&http.Response{Header: http.Header{}}))
+	})
+
+	t.Run("with quota headers", func(t *testing.T) {
+		reset := time.Now().Add(time.Minute).Truncate(time.Second)
+		header := http.Header{}
+		header.Set("X-RateLimit-Limit", "100")
+		header.Set("X-RateLimit-Remaining", "7")
+		header.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+
+		s := ParseRateLimitStatus(&http.Response{Header: header})
+		require.NotNil(t, s)
+		assert.Equal(t, 100, s.Limit)
+		assert.Equal(t, 7, s.Remaining)
+		assert.True(t, reset.Equal(s.Reset))
+		assert.Zero(t, s.RetryAfter)
+	})
+
+	t.Run("with retry after seconds", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("Retry-After", "3")
+
+		s := ParseRateLimitStatus(&http.Response{Header: header})
+		require.NotNil(t, s)
+		assert.Equal(t, -1, s.Limit)
+		assert.Equal(t, 3*time.Second, s.RetryAfter)
+		assert.Equal(t, 3*time.Second, s.wait(DefaultMaxRetryAfter))
+	})
+
+	t.Run("with long retry after", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("Retry-After", "86400")
+
+		s := ParseRateLimitStatus(&http.Response{Header: header})
+		assert.Equal(t, DefaultMaxRetryAfter, s.wait(DefaultMaxRetryAfter))
+		assert.Equal(t, time.Second, s.wait(time.Second))
+	})
+}
+
+func TestClient_rateLimitRetry(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "99")
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		w.Write([]byte(`{"data":{"id":"acc-123","type":"accounts"}}`))
+	}))
+	defer ts.Close()
+
+	var hookStatus *RateLimitStatus
+	var hookAttempt int
+	client, err := NewClient(&Config{
+		Address:    ts.URL,
+		Token:      "dummy-token",
+		HTTPClient: ts.Client(),
+		RetryLogHook: func(attemptNum int, resp *http.Response) {
+			hookAttempt = attemptNum
+			hookStatus = ParseRateLimitStatus(resp)
+		},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, client.RateLimitStatus())
+
+	start := time.Now()
+	_, err = client.Accounts.Read(context.Background(), "acc-123")
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+
+	assert.Equal(t, 1, hookAttempt)
+	require.NotNil(t, hookStatus)
+	assert.Equal(t, 0, hookStatus.Remaining)
+	assert.Equal(t, time.Second, hookStatus.RetryAfter)
+
+	s := client.RateLimitStatus()
+	require.NotNil(t, s)
+	assert.Equal(t, 100, s.Limit)
+	assert.Equal(t, 99, s.Remaining)
+}
@@ -0,0 +1,76 @@
+package scalr
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Now()
+	l := newRateLimiter(2, 2)
+	l.now = func() time.Time { return now }
+	l.last = now
+
+	t.Run("allows the burst", func(t *testing.T) {
+		assert.Equal(t, time.Duration(0), l.reserve())
+		assert.Equal(t, time.Duration(0), l.reserve())
+	})
+
+	t.Run("delays requests over the rate", func(t *testing.T) {
+		assert.Equal(t, 500*time.Millisecond, l.reserve())
+		assert.Equal(t, time.Second, l.reserve())
+	})
+
+	t.Run("refills over time", func(t *testing.T) {
+		now = now.Add(2 * time.Second)
+		assert.Equal(t, time.Duration(0), l.reserve())
+	})
+
+	t.Run("stops waiting when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		l.reserve()
+		assert.Equal(t, context.Canceled, l.Wait(ctx))
+		assert.Equal(t, 500*time.Millisecond, l.reserve())
+	})
+}
+
+func TestRateLimitedTransport(t *testing.T) {
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{
+		Address:        ts.URL,
+		Token:          "dummy-token",
+		HTTPClient:     ts.Client(),
+		RateLimit:      1000,
+		RateLimitBurst: 3,
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	client.limiter.now = func() time.Time { return now }
+	client.limiter.last = now
+
+	req, err := client.newRequest("GET", "runs/run-1", nil)
+	require.NoError(t, err)
+	require.NoError(t, client.do(context.Background(), req, nil))
+
+	// The retry of the rate limited request took a token as well.
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+	assert.Equal(t, float64(1), client.limiter.tokens)
+}
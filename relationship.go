@@ -0,0 +1,72 @@
+package scalr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ResourceIdentifier identifies a resource in a JSON:API relationship,
+// e.g. {Type: "tags", ID: "tag-123"}.
+type ResourceIdentifier struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// relationshipDocument is the request body of the relationship endpoints.
+type relationshipDocument struct {
+	Data []*ResourceIdentifier `json:"data"`
+}
+
+// PatchRelationship replaces all members of the to-many relationship relName of the
+// resource at resourcePath (e.g. "workspaces/ws-123") with refs. An empty refs clears it.
+//
+// The relationship helpers are meant for the endpoints that have no dedicated
+// service method yet, and to update a single relationship without sending the whole object.
+func (c *Client) PatchRelationship(ctx context.Context, resourcePath, relName string, refs []*ResourceIdentifier) error {
+	return c.relationshipRequest(ctx, "PATCH", resourcePath, relName, refs)
+}
+
+// PostRelationship adds refs to the to-many relationship relName of the resource at resourcePath.
+func (c *Client) PostRelationship(ctx context.Context, resourcePath, relName string, refs []*ResourceIdentifier) error {
+	return c.relationshipRequest(ctx, "POST", resourcePath, relName, refs)
+}
+
+// DeleteRelationship removes refs from the to-many relationship relName of the resource at resourcePath.
+func (c *Client) DeleteRelationship(ctx context.Context, resourcePath, relName string, refs []*ResourceIdentifier) error {
+	return c.relationshipRequest(ctx, "DELETE", resourcePath, relName, refs)
+}
+
+func (c *Client) relationshipRequest(
+	ctx context.Context, method, resourcePath, relName string, refs []*ResourceIdentifier,
+) error {
+	resourcePath = strings.Trim(resourcePath, "/")
+	if !validString(&resourcePath) {
+		return errors.New("invalid value for resource path")
+	}
+	if !validString(&relName) {
+		return errors.New("invalid value for relationship name")
+	}
+	for _, ref := range refs {
+		if ref == nil || !validString(&ref.Type) {
+			return errors.New("resource identifier type is required")
+		}
+		if !validStringID(&ref.ID) {
+			return fmt.Errorf("invalid value for %s ID", ref.Type)
+		}
+	}
+	if refs == nil {
+		refs = []*ResourceIdentifier{}
+	}
+
+	u := fmt.Sprintf("%s/relationships/%s", resourcePath, url.PathEscape(relName))
+	req, err := c.newJsonRequest(method, u, &relationshipDocument{Data: refs})
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/vnd.api+json")
+
+	return c.do(ctx, req, nil)
+}
@@ -0,0 +1,65 @@
+package scalr
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_relationships(t *testing.T) {
+	type request struct {
+		Method string
+		Path   string
+		Body   relationshipDocument
+	}
+	var got request
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = request{Method: r.Method, Path: r.URL.Path}
+		assert.Equal(t, "application/vnd.api+json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got.Body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	refs := []*ResourceIdentifier{{Type: "tags", ID: "tag-1"}, {Type: "tags", ID: "tag-2"}}
+
+	for method, call := range map[string]func(context.Context, string, string, []*ResourceIdentifier) error{
+		"PATCH":  client.PatchRelationship,
+		"POST":   client.PostRelationship,
+		"DELETE": client.DeleteRelationship,
+	} {
+		t.Run(method, func(t *testing.T) {
+			err := call(ctx, "/workspaces/ws-123/", "tags", refs)
+			require.NoError(t, err)
+			assert.Equal(t, method, got.Method)
+			assert.Equal(t, "/api/iacp/v3/workspaces/ws-123/relationships/tags", got.Path)
+			assert.Equal(t, refs, got.Body.Data)
+		})
+	}
+
+	t.Run("with empty refs", func(t *testing.T) {
+		err := client.PatchRelationship(ctx, "workspaces/ws-123", "tags", nil)
+		require.NoError(t, err)
+		assert.NotNil(t, got.Body.Data)
+		assert.Empty(t, got.Body.Data)
+	})
+
+	t.Run("with invalid resource ID", func(t *testing.T) {
+		err := client.PostRelationship(ctx, "workspaces/ws-123", "tags", []*ResourceIdentifier{{Type: "tags", ID: badIdentifier}})
+		assert.EqualError(t, err, "invalid value for tags ID")
+	})
+
+	t.Run("without relationship name", func(t *testing.T) {
+		err := client.PostRelationship(ctx, "workspaces/ws-123", "", refs)
+		assert.EqualError(t, err, "invalid value for relationship name")
+	})
+}
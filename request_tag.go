@@ -0,0 +1,30 @@
+package scalr
+
+import (
+	"context"
+	"strings"
+)
+
+// RequestTagHeader is the header carrying the tag of the requests, see WithRequestTag.
+const RequestTagHeader = "X-Request-Tag"
+
+// requestTagContextKey is the context key of the request tag.
+type requestTagContextKey struct{}
+
+// WithRequestTag returns a context that makes the requests sent with it carry
+// the tag in the X-Request-Tag header, so the server logs and the audit trail
+// can correlate the changes with the automation job that made them, e.g.
+//
+//	ctx = scalr.WithRequestTag(ctx, "reconcile-loop-7")
+//
+// The line breaks of the tag are replaced with spaces.
+func WithRequestTag(ctx context.Context, tag string) context.Context {
+	tag = strings.TrimSpace(strings.NewReplacer("\r", " ", "\n", " ").Replace(tag))
+	return context.WithValue(ctx, requestTagContextKey{}, tag)
+}
+
+// RequestTag returns the tag of the requests sent with the context, if any.
+func RequestTag(ctx context.Context) (string, bool) {
+	tag, ok := ctx.Value(requestTagContextKey{}).(string)
+	return tag, ok && tag != ""
+}
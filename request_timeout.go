@@ -0,0 +1,92 @@
+package scalr
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// requestTimeouts holds the default timeouts of the requests by their kind.
+type requestTimeouts struct {
+	read     time.Duration
+	write    time.Duration
+	upload   time.Duration
+	download time.Duration
+}
+
+func (t requestTimeouts) valid() error {
+	for name, d := range map[string]time.Duration{
+		"read": t.read, "write": t.write, "upload": t.upload, "download": t.download,
+	} {
+		if d < 0 {
+			return fmt.Errorf("invalid %s timeout: %v", name, d)
+		}
+	}
+	return nil
+}
+
+// transferKind marks the requests uploading or downloading files, e.g. the
+// states or the configuration archives, which use their own timeout.
+type transferKind int
+
+const (
+	transferUpload transferKind = iota + 1
+	transferDownload
+)
+
+// transferContextKey is the context key of the transfer kind of a request.
+type transferContextKey struct{}
+
+// withTransfer returns a context marking the requests sent with it as transfers of the kind.
+func withTransfer(ctx context.Context, kind transferKind) context.Context {
+	return context.WithValue(ctx, transferContextKey{}, kind)
+}
+
+// forRequest returns the default timeout of the request with the HTTP method.
+func (t requestTimeouts) forRequest(ctx context.Context, method string) time.Duration {
+	switch kind, _ := ctx.Value(transferContextKey{}).(transferKind); kind {
+	case transferUpload:
+		return t.upload
+	case transferDownload:
+		return t.download
+	}
+
+	switch method {
+	case "GET", "HEAD":
+		return t.read
+	default:
+		return t.write
+	}
+}
+
+// requestTimeoutContextKey is the context key of the per-request timeout.
+type requestTimeoutContextKey struct{}
+
+// ContextWithRequestTimeout returns a context that limits each request sent with it,
+// retries included, to the timeout instead of the one configured for the client.
+// Unlike context.WithTimeout, the timeout applies to every request separately,
+// which suits the methods sending many requests, e.g.
+//
+//	ctx := scalr.ContextWithRequestTimeout(ctx, 30*time.Second)
+//	results, err := client.Tags.BulkRename(ctx, options, scalr.NormalizeTagName)
+func ContextWithRequestTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, requestTimeoutContextKey{}, timeout)
+}
+
+// requestTimeout returns the timeout of the request, zero if it is unlimited.
+func (c *Client) requestTimeout(ctx context.Context, method string) time.Duration {
+	if timeout, ok := ctx.Value(requestTimeoutContextKey{}).(time.Duration); ok {
+		return timeout
+	}
+	return c.timeouts.forRequest(ctx, method)
+}
+
+// cleanupTimeout bounds the requests undoing a partially applied operation.
+const cleanupTimeout = time.Minute
+
+// cleanupContext returns the context of the requests undoing a partially applied
+// operation, e.g. a rollback. It is not tied to the context of the operation, so
+// the cleanup still runs when the operation was canceled or timed out.
+func cleanupContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), cleanupTimeout)
+}
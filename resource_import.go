@@ -0,0 +1,199 @@
+package scalr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Compile-time proof of interface implementation.
+var _ Imports = (*imports)(nil)
+
+// Imports describes all the resource import related methods that the Scalr API supports.
+type Imports interface {
+	// Create queues an import of the existing resource id to the address of the workspace state.
+	Create(ctx context.Context, workspaceID, address, id string) (*ResourceImport, error)
+	// Read a resource import by its ID.
+	Read(ctx context.Context, importID string) (*ResourceImport, error)
+	// List the resource imports of a workspace.
+	List(ctx context.Context, workspaceID string, options ResourceImportListOptions) (*ResourceImportList, error)
+	// Wait polls the resource import until it is finished.
+	Wait(ctx context.Context, importID string) (*ResourceImport, error)
+}
+
+// imports implements Imports.
+type imports struct {
+	client *Client
+}
+
+// ResourceImportStatus represents a resource import state.
+type ResourceImportStatus string
+
+// List all available resource import statuses.
+const (
+	ResourceImportPending  ResourceImportStatus = "pending"
+	ResourceImportRunning  ResourceImportStatus = "running"
+	ResourceImportFinished ResourceImportStatus = "finished"
+	ResourceImportErrored  ResourceImportStatus = "errored"
+	ResourceImportCanceled ResourceImportStatus = "canceled"
+)
+
+// defaultResourceImportPollInterval is how often Wait checks the resource
+// import status, unless the config sets PollInterval.
+const defaultResourceImportPollInterval = 5 * time.Second
+
+// ResourceImportList represents a list of resource imports.
+type ResourceImportList struct {
+	*Pagination
+	Items []*ResourceImport
+}
+
+// ResourceImport represents a queued `terraform import` of an existing resource
+// into the state of a workspace.
+type ResourceImport struct {
+	ID           string               `jsonapi:"primary,resource-imports"`
+	Address      string               `jsonapi:"attr,address"`
+	ResourceID   string               `jsonapi:"attr,resource-id"`
+	Status       ResourceImportStatus `jsonapi:"attr,status"`
+	ErrorMessage string               `jsonapi:"attr,error-message"`
+	CreatedAt    time.Time            `jsonapi:"attr,created-at,iso8601"`
+
+	// Relations
+	Workspace *Workspace `jsonapi:"relation,workspace"`
+	Run       *Run       `jsonapi:"relation,run"`
+	CreatedBy *User      `jsonapi:"relation,created-by"`
+}
+
+// IsFinal reports whether the resource import reached a status it does not leave.
+func (i *ResourceImport) IsFinal() bool {
+	switch i.Status {
+	case ResourceImportFinished, ResourceImportErrored, ResourceImportCanceled:
+		return true
+	}
+	return false
+}
+
+// resourceImportCreateOptions represents the options for creating a resource import.
+type resourceImportCreateOptions struct {
+	// For internal use only!
+	ID string `jsonapi:"primary,resource-imports"`
+
+	Address    string `jsonapi:"attr,address"`
+	ResourceID string `jsonapi:"attr,resource-id"`
+
+	// Relations
+	Workspace *Workspace `jsonapi:"relation,workspace"`
+}
+
+// ResourceImportListOptions represents the options for listing resource imports.
+type ResourceImportListOptions struct {
+	ListOptions
+
+	Status *ResourceImportStatus `url:"filter[status],omitempty"`
+}
+
+// Create queues an import of the existing resource id to the resource address
+// of the workspace state, e.g. `aws_instance.web`.
+func (s *imports) Create(ctx context.Context, workspaceID, address, id string) (*ResourceImport, error) {
+	if !validStringID(&workspaceID) {
+		return nil, errors.New("invalid value for workspace ID")
+	}
+	if !validString(&address) {
+		return nil, errors.New("address is required")
+	}
+	if !validString(&id) {
+		return nil, errors.New("resource ID is required")
+	}
+
+	options := resourceImportCreateOptions{
+		Address:    address,
+		ResourceID: id,
+		Workspace:  &Workspace{ID: workspaceID},
+	}
+	req, err := s.client.newRequest("POST", "resource-imports", &options)
+	if err != nil {
+		return nil, err
+	}
+
+	ri := &ResourceImport{}
+	err = s.client.do(ctx, req, ri)
+	if err != nil {
+		return nil, err
+	}
+
+	return ri, nil
+}
+
+// Read a resource import by its ID.
+func (s *imports) Read(ctx context.Context, importID string) (*ResourceImport, error) {
+	if !validStringID(&importID) {
+		return nil, errors.New("invalid value for resource import ID")
+	}
+
+	u := fmt.Sprintf("resource-imports/%s", url.QueryEscape(importID))
+	req, err := s.client.newRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ri := &ResourceImport{}
+	err = s.client.do(ctx, req, ri)
+	if err != nil {
+		return nil, err
+	}
+
+	return ri, nil
+}
+
+// List the resource imports of a workspace.
+func (s *imports) List(ctx context.Context, workspaceID string, options ResourceImportListOptions) (*ResourceImportList, error) {
+	if !validStringID(&workspaceID) {
+		return nil, errors.New("invalid value for workspace ID")
+	}
+
+	u := fmt.Sprintf("workspaces/%s/resource-imports", url.QueryEscape(workspaceID))
+	req, err := s.client.newRequest("GET", u, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	ril := &ResourceImportList{}
+	err = s.client.do(ctx, req, ril)
+	if err != nil {
+		return nil, err
+	}
+
+	return ril, nil
+}
+
+// Wait polls the resource import until it reaches a final status and returns it.
+// An error is returned if the import errored or was canceled.
+func (s *imports) Wait(ctx context.Context, importID string) (*ResourceImport, error) {
+	if !validStringID(&importID) {
+		return nil, errors.New("invalid value for resource import ID")
+	}
+
+	var ri *ResourceImport
+	interval := s.client.pollInterval(defaultResourceImportPollInterval)
+	err := poll(ctx, interval, 0, nil, func(ctx context.Context) (bool, error) {
+		var err error
+		ri, err = s.Read(ctx, importID)
+		if err != nil {
+			return false, err
+		}
+		switch ri.Status {
+		case ResourceImportErrored:
+			return false, fmt.Errorf("resource import %s errored: %s", importID, ri.ErrorMessage)
+		case ResourceImportCanceled:
+			return false, fmt.Errorf("resource import %s was canceled", importID)
+		}
+		return ri.Status == ResourceImportFinished, nil
+	})
+	if err != nil && (ri == nil || !ri.IsFinal()) {
+		return nil, err
+	}
+
+	return ri, err
+}
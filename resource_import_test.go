@@ -0,0 +1,124 @@
+package scalr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportsCreate(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/iacp/v3/resource-imports", r.URL.Path)
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var payload struct {
+			Data struct {
+				Attributes    map[string]string `json:"attributes"`
+				Relationships struct {
+					Workspace struct {
+						Data struct {
+							ID string `json:"id"`
+						} `json:"data"`
+					} `json:"workspace"`
+				} `json:"relationships"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, "aws_instance.web", payload.Data.Attributes["address"])
+		assert.Equal(t, "i-0123", payload.Data.Attributes["resource-id"])
+		assert.Equal(t, "ws-123", payload.Data.Relationships.Workspace.Data.ID)
+
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"data":{"id":"rim-123","type":"resource-imports",`+
+			`"attributes":{"address":"aws_instance.web","resource-id":"i-0123","status":"pending"}}}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("with valid options", func(t *testing.T) {
+		ri, err := client.Imports.Create(ctx, "ws-123", "aws_instance.web", "i-0123")
+		require.NoError(t, err)
+		assert.Equal(t, "rim-123", ri.ID)
+		assert.Equal(t, ResourceImportPending, ri.Status)
+	})
+
+	t.Run("without address", func(t *testing.T) {
+		ri, err := client.Imports.Create(ctx, "ws-123", "", "i-0123")
+		assert.Nil(t, ri)
+		assert.EqualError(t, err, "address is required")
+	})
+
+	t.Run("with invalid workspace ID", func(t *testing.T) {
+		ri, err := client.Imports.Create(ctx, badIdentifier, "aws_instance.web", "i-0123")
+		assert.Nil(t, ri)
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+}
+
+func TestImportsWait(t *testing.T) {
+	statuses := []ResourceImportStatus{ResourceImportPending, ResourceImportRunning, ResourceImportFinished}
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		status, message := ResourceImportRunning, ""
+		switch r.URL.Path {
+		case "/api/iacp/v3/resource-imports/rim-finished":
+			n := int(atomic.AddInt32(&calls, 1)) - 1
+			if n >= len(statuses) {
+				n = len(statuses) - 1
+			}
+			status = statuses[n]
+		case "/api/iacp/v3/resource-imports/rim-errored":
+			status, message = ResourceImportErrored, "resource not found"
+		}
+		fmt.Fprintf(w, `{"data":{"id":"rim-123","type":"resource-imports","attributes":{"status":%q,"error-message":%q}}}`,
+			status, message)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client(), PollInterval: time.Millisecond})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("when the import finishes", func(t *testing.T) {
+		ri, err := client.Imports.Wait(ctx, "rim-finished")
+		require.NoError(t, err)
+		assert.Equal(t, ResourceImportFinished, ri.Status)
+		assert.True(t, ri.IsFinal())
+	})
+
+	t.Run("when the import errors", func(t *testing.T) {
+		ri, err := client.Imports.Wait(ctx, "rim-errored")
+		require.NotNil(t, ri)
+		assert.EqualError(t, err, "resource import rim-errored errored: resource not found")
+	})
+
+	t.Run("when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		ri, err := client.Imports.Wait(ctx, "rim-running")
+		assert.Nil(t, ri)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("with invalid import ID", func(t *testing.T) {
+		ri, err := client.Imports.Wait(ctx, badIdentifier)
+		assert.Nil(t, ri)
+		assert.EqualError(t, err, "invalid value for resource import ID")
+	})
+}
@@ -19,6 +19,9 @@ type Roles interface {
 	Create(ctx context.Context, options RoleCreateOptions) (*Role, error)
 	Update(ctx context.Context, roleID string, options RoleUpdateOptions) (*Role, error)
 	Delete(ctx context.Context, roleID string) error
+
+	// ListBindings lists all the access policies referencing the role.
+	ListBindings(ctx context.Context, roleID string) ([]*AccessPolicy, error)
 }
 
 // roles implements Roles.
@@ -190,3 +193,36 @@ func (s *roles) Delete(ctx context.Context, roleID string) error {
 
 	return s.client.do(ctx, req, nil)
 }
+
+// ListBindings lists all the access policies referencing the role, along with
+// their subject and scope, e.g. to find where the role is used before it is
+// deprecated or deleted.
+func (s *roles) ListBindings(ctx context.Context, roleID string) ([]*AccessPolicy, error) {
+	if !validStringID(&roleID) {
+		return nil, errors.New("invalid value for role ID")
+	}
+
+	options := AccessPolicyListOptions{Role: String(roleID)}
+
+	var bindings []*AccessPolicy
+	for {
+		apl, err := s.client.AccessPolicies.List(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, ap := range apl.Items {
+			for _, r := range ap.Roles {
+				if r != nil && r.ID == roleID {
+					bindings = append(bindings, ap)
+					break
+				}
+			}
+		}
+		if apl.Pagination == nil || apl.NextPage == 0 {
+			break
+		}
+		options.PageNumber = apl.NextPage
+	}
+
+	return bindings, nil
+}
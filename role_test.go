@@ -3,6 +3,8 @@ package scalr
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -270,3 +272,44 @@ func TestRolesDelete(t *testing.T) {
 		assert.EqualError(t, err, "invalid value for role ID")
 	})
 }
+
+func TestRolesListBindings(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/iacp/v3/access-policies", r.URL.Path)
+		assert.Equal(t, "role-123", r.URL.Query().Get("filter[role]"))
+
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		if r.URL.Query().Get("page[number]") == "2" {
+			fmt.Fprint(w, `{"data":[{"id":"ap-2","type":"access-policies","relationships":{`+
+				`"roles":{"data":[{"id":"role-123","type":"roles"}]},"user":{"data":{"id":"user-1","type":"users"}},`+
+				`"workspace":{"data":{"id":"ws-1","type":"workspaces"}}}}],`+
+				`"meta":{"pagination":{"current-page":2,"prev-page":1,"total-pages":2,"total-count":2}}}`)
+			return
+		}
+		fmt.Fprint(w, `{"data":[{"id":"ap-1","type":"access-policies","relationships":{`+
+			`"roles":{"data":[{"id":"role-other","type":"roles"},{"id":"role-123","type":"roles"}]},`+
+			`"team":{"data":{"id":"team-1","type":"teams"}},"environment":{"data":{"id":"env-1","type":"environments"}}}}],`+
+			`"meta":{"pagination":{"current-page":1,"next-page":2,"total-pages":2,"total-count":2}}}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("with valid role", func(t *testing.T) {
+		bindings, err := client.Roles.ListBindings(ctx, "role-123")
+		require.NoError(t, err)
+		require.Len(t, bindings, 2)
+
+		assert.Equal(t, "team-1", bindings[0].Subject().Team.ID)
+		assert.Equal(t, "env-1", bindings[0].Scope().Environment.ID)
+		assert.Equal(t, "user-1", bindings[1].Subject().User.ID)
+		assert.Equal(t, "ws-1", bindings[1].Scope().Workspace.ID)
+	})
+
+	t.Run("without a valid role ID", func(t *testing.T) {
+		_, err := client.Roles.ListBindings(ctx, badIdentifier)
+		assert.EqualError(t, err, "invalid value for role ID")
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"sort"
+	"strconv"
 	"time"
 )
 
@@ -13,11 +15,37 @@ var _ Runs = (*runs)(nil)
 
 // Runs describes all the run related methods that the Scalr API supports.
 type Runs interface {
-
+	// List the runs matching the filters, e.g. the active runs of an environment.
+	List(ctx context.Context, options RunListOptions) (*RunList, error)
 	// Read a run by its ID.
 	Read(ctx context.Context, runID string) (*Run, error)
 	// Create a new run with the given options.
 	Create(ctx context.Context, options RunCreateOptions) (*Run, error)
+	// Retry creates a new run with the same inputs as the given run.
+	Retry(ctx context.Context, runID string) (*Run, error)
+	// ListEvents lists the timeline events of a run.
+	ListEvents(ctx context.Context, runID string, options RunEventListOptions) (*RunEventList, error)
+	// WaitForApproval waits until the run requires a confirmation to proceed.
+	WaitForApproval(ctx context.Context, runID string, timeout time.Duration, onWaiting func(*Run)) (*Run, error)
+	// ReadApprovers reads the teams and users that may approve the run.
+	ReadApprovers(ctx context.Context, runID string) (*RunApprovers, error)
+	// ConfigurationDiff compares the configuration files of two runs.
+	ConfigurationDiff(ctx context.Context, fromRunID, toRunID string) (*ConfigurationDiff, error)
+	// Apply confirms the run that waits for approval.
+	Apply(ctx context.Context, runID string, comment string) error
+	// Discard rejects the run that waits for approval.
+	Discard(ctx context.Context, runID string, comment string) error
+	// Cancel stops the run that is queued or in progress.
+	Cancel(ctx context.Context, runID string, comment string) error
+	// CancelWithOptions stops the run and optionally waits for it, force-canceling
+	// the run if it is still active after the grace period.
+	CancelWithOptions(ctx context.Context, runID string, options RunCancelOptions) (*Run, error)
+	// ApplyIfNonDestructive confirms the run only if its plan does not destroy any resources.
+	ApplyIfNonDestructive(ctx context.Context, runID string) (bool, error)
+	// Prioritize moves the queued run to the front of the run queue.
+	Prioritize(ctx context.Context, runID string) error
+	// DownloadLogs writes the plan and apply logs of the run into files in dir.
+	DownloadLogs(ctx context.Context, runID string, dir string) ([]*RunLogFile, error)
 }
 
 // runs implements Runs.
@@ -62,15 +90,31 @@ const (
 	RunSourceCLI                  RunSource = "cli"
 )
 
+// RunList represents a list of runs.
+type RunList struct {
+	*Pagination
+	Items []*Run
+}
+
 // Run represents a Scalr run.
 type Run struct {
 	ID        string    `jsonapi:"primary,runs"`
 	Source    RunSource `jsonapi:"attr,source"`
 	Message   string    `jsonapi:"attr,message"`
 	IsDestroy bool      `jsonapi:"attr,is-destroy"`
+	IsDry     bool      `jsonapi:"attr,is-dry"`
 	CreatedAt time.Time `jsonapi:"attr,created-at,iso8601"`
 	Status    RunStatus `jsonapi:"attr,status"`
 
+	// The list of resource addresses the run is targeted to.
+	TargetAddrs []string `jsonapi:"attr,target-addrs"`
+
+	// The metadata the run was stamped with, e.g. a build ID or a ticket number.
+	Labels []*RunLabel `jsonapi:"attr,labels"`
+
+	// The input variables passed to this run only.
+	Variables []*RunVariable `jsonapi:"attr,variables"`
+
 	// Relations
 	VcsRevision          *VcsRevision          `jsonapi:"relation,vcs-revision"`
 	Apply                *Apply                `jsonapi:"relation,apply"`
@@ -81,11 +125,74 @@ type Run struct {
 	Workspace            *Workspace            `jsonapi:"relation,workspace"`
 }
 
+// RunLabel represents a single key-value metadata entry of a run.
+type RunLabel struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// RunLabels converts the map to the list of run labels sorted by key.
+func RunLabels(labels map[string]string) []*RunLabel {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	result := make([]*RunLabel, len(keys))
+	for i, k := range keys {
+		result[i] = &RunLabel{Key: k, Value: labels[k]}
+	}
+	return result
+}
+
+// Label returns the value of the run label with the given key.
+func (r *Run) Label(key string) (string, bool) {
+	for _, l := range r.Labels {
+		if l.Key == key {
+			return l.Value, true
+		}
+	}
+	return "", false
+}
+
+// RunVariable represents an input variable passed to a single run, which
+// takes precedence over the workspace variable with the same key without
+// changing it. The value is an HCL expression, so strings must be quoted,
+// see RunVariableString.
+type RunVariable struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// RunVariableString returns a run variable with the string value quoted as HCL.
+func RunVariableString(key, value string) *RunVariable {
+	return &RunVariable{Key: key, Value: strconv.Quote(value)}
+}
+
 // RunCreateOptions represents the options for creating a new run.
 type RunCreateOptions struct {
 	// For internal use only!
 	ID string `jsonapi:"primary,runs"`
 
+	// The message to associate with the run.
+	Message *string `jsonapi:"attr,message,omitempty"`
+
+	// Whether the run should destroy all provisioned resources.
+	IsDestroy *bool `jsonapi:"attr,is-destroy,omitempty"`
+
+	// Whether the run only plans the changes and cannot be applied.
+	IsDry *bool `jsonapi:"attr,is-dry,omitempty"`
+
+	// Limits the run to the given list of resource addresses.
+	TargetAddrs []string `jsonapi:"attr,target-addrs,omitempty"`
+
+	// The metadata to stamp the run with, see RunLabels.
+	Labels []*RunLabel `jsonapi:"attr,labels,omitempty"`
+
+	// The input variables of the run, they do not change the workspace variables.
+	Variables []*RunVariable `jsonapi:"attr,variables,omitempty"`
+
 	// Specifies the configuration version to use for this run.
 	ConfigurationVersion *ConfigurationVersion `jsonapi:"relation,configuration-version"`
 	// Specifies the workspace where the run will be executed.
@@ -105,6 +212,26 @@ func (o RunCreateOptions) valid() error {
 	if !validStringID(&o.ConfigurationVersion.ID) {
 		return errors.New("invalid value for configuration-version ID")
 	}
+	keys := make(map[string]bool, len(o.Labels))
+	for _, l := range o.Labels {
+		if l == nil || !validString(&l.Key) {
+			return errors.New("label key is required")
+		}
+		if keys[l.Key] {
+			return fmt.Errorf("duplicate label key '%s'", l.Key)
+		}
+		keys[l.Key] = true
+	}
+	vars := make(map[string]bool, len(o.Variables))
+	for _, v := range o.Variables {
+		if v == nil || !validString(&v.Key) {
+			return errors.New("variable key is required")
+		}
+		if vars[v.Key] {
+			return fmt.Errorf("duplicate variable key '%s'", v.Key)
+		}
+		vars[v.Key] = true
+	}
 	return nil
 }
 
@@ -140,7 +267,7 @@ func (s *runs) Read(ctx context.Context, runID string) (*Run, error) {
 	options := struct {
 		Include string `url:"include"`
 	}{
-		Include: "vcs-revision",
+		Include: "vcs-revision,workspace",
 	}
 
 	u := fmt.Sprintf("runs/%s", url.QueryEscape(runID))
@@ -157,3 +284,435 @@ func (s *runs) Read(ctx context.Context, runID string) (*Run, error) {
 
 	return r, nil
 }
+
+// List of attributes runs can be sorted by.
+const (
+	RunSortByCreatedAt SortKey = "created-at"
+	RunSortByStatus    SortKey = "status"
+)
+
+// RunListOptions represents the options for listing runs.
+type RunListOptions struct {
+	ListOptions
+
+	Workspace   *string `url:"filter[workspace],omitempty"`
+	Environment *string `url:"filter[environment],omitempty"`
+
+	// Filter by the run status, use RunStatusesFilter to match any of several statuses.
+	Status *string `url:"filter[status],omitempty"`
+
+	Source *RunSource `url:"filter[source],omitempty"`
+
+	// Filter by the creation time.
+	CreatedAt *TimeRangeFilter `url:"filter[created-at],omitempty"`
+
+	// The comma-separated list of attributes, see SortBy.
+	Sort *string `url:"sort,omitempty"`
+
+	Include string `url:"include,omitempty"`
+}
+
+// RunStatusesFilter returns the status filter matching any of the given statuses,
+// or nil if none is given.
+func RunStatusesFilter(statuses ...RunStatus) *string {
+	return inFilter(statuses)
+}
+
+func (o RunListOptions) valid() error {
+	if o.Workspace != nil && !validStringID(o.Workspace) {
+		return errors.New("invalid value for workspace ID")
+	}
+	if o.Environment != nil && !validStringID(o.Environment) {
+		return errors.New("invalid value for environment ID")
+	}
+	return validSort(o.Sort, RunSortByCreatedAt, RunSortByStatus)
+}
+
+// List the runs matching the filters, e.g. the queued runs of a workspace
+// to build a dashboard of the run queue.
+func (s *runs) List(ctx context.Context, options RunListOptions) (*RunList, error) {
+	if err := options.valid(); err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.newRequest("GET", "runs", &options)
+	if err != nil {
+		return nil, err
+	}
+
+	rl := &RunList{}
+	err = s.client.do(ctx, req, rl)
+	if err != nil {
+		return nil, err
+	}
+
+	return rl, nil
+}
+
+// Retry creates a new run that reuses the configuration version, message,
+// targets, labels, variables and the destroy and dry flags of the given run.
+func (s *runs) Retry(ctx context.Context, runID string) (*Run, error) {
+	r, err := s.Read(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	if r.Workspace == nil || r.ConfigurationVersion == nil {
+		return nil, fmt.Errorf("run %s has no workspace or configuration version to retry with", runID)
+	}
+
+	options := RunCreateOptions{
+		Message:              String(r.Message),
+		IsDestroy:            Bool(r.IsDestroy),
+		IsDry:                Bool(r.IsDry),
+		TargetAddrs:          r.TargetAddrs,
+		Labels:               r.Labels,
+		Variables:            r.Variables,
+		ConfigurationVersion: &ConfigurationVersion{ID: r.ConfigurationVersion.ID},
+		Workspace:            &Workspace{ID: r.Workspace.ID},
+	}
+
+	return s.Create(ctx, options)
+}
+
+// RunEventAction represents the kind of a run timeline event.
+type RunEventAction string
+
+// List all available run event actions.
+const (
+	RunEventStatusChanged RunEventAction = "status-changed"
+	RunEventApproved      RunEventAction = "approved"
+	RunEventDiscarded     RunEventAction = "discarded"
+	RunEventCanceled      RunEventAction = "canceled"
+	RunEventPolicyChecked RunEventAction = "policy-checked"
+)
+
+// RunEventList represents a list of run events.
+type RunEventList struct {
+	*Pagination
+	Items []*RunEvent
+}
+
+// RunEvent represents a single entry of the run timeline.
+type RunEvent struct {
+	ID          string         `jsonapi:"primary,run-events"`
+	Action      RunEventAction `jsonapi:"attr,action"`
+	Description string         `jsonapi:"attr,description"`
+	CreatedAt   time.Time      `jsonapi:"attr,created-at,iso8601"`
+
+	// The status the run transitioned to.
+	Status RunStatus `jsonapi:"attr,status"`
+
+	// Relations
+	CreatedBy   *User        `jsonapi:"relation,created-by"`
+	PolicyCheck *PolicyCheck `jsonapi:"relation,policy-check"`
+}
+
+// RunEventListOptions represents the options for listing run events.
+type RunEventListOptions struct {
+	ListOptions
+
+	Include string `url:"include,omitempty"`
+}
+
+// ListEvents lists the timeline events of a run in chronological order.
+func (s *runs) ListEvents(ctx context.Context, runID string, options RunEventListOptions) (*RunEventList, error) {
+	if !validStringID(&runID) {
+		return nil, errors.New("invalid value for run ID")
+	}
+
+	u := fmt.Sprintf("runs/%s/events", url.QueryEscape(runID))
+	req, err := s.client.newRequest("GET", u, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	el := &RunEventList{}
+	err = s.client.do(ctx, req, el)
+	if err != nil {
+		return nil, err
+	}
+
+	return el, nil
+}
+
+// RunApprovers represents who is allowed to approve a run according to
+// the approval rules of its environment.
+type RunApprovers struct {
+	ID string `jsonapi:"primary,run-approvers"`
+
+	// The number of approvals the run needs to proceed.
+	RequiredApprovals int `jsonapi:"attr,required-approvals"`
+
+	// Relations
+	Run   *Run    `jsonapi:"relation,run"`
+	Teams []*Team `jsonapi:"relation,teams"`
+	Users []*User `jsonapi:"relation,users"`
+}
+
+// ReadApprovers reads the teams and users that may approve the run. Users are
+// the ones approval is granted to directly, not the members of the teams.
+func (s *runs) ReadApprovers(ctx context.Context, runID string) (*RunApprovers, error) {
+	if !validStringID(&runID) {
+		return nil, errors.New("invalid value for run ID")
+	}
+
+	options := struct {
+		Include string `url:"include"`
+	}{
+		Include: "teams,users",
+	}
+
+	u := fmt.Sprintf("runs/%s/approvers", url.QueryEscape(runID))
+	req, err := s.client.newRequest("GET", u, options)
+	if err != nil {
+		return nil, err
+	}
+
+	ra := &RunApprovers{}
+	err = s.client.do(ctx, req, ra)
+	if err != nil {
+		return nil, err
+	}
+
+	return ra, nil
+}
+
+// defaultRunPollInterval is how often the run status is checked while waiting
+// for it, unless the config sets PollInterval.
+const defaultRunPollInterval = 5 * time.Second
+
+// ErrRunApprovalTimeout is returned when the run does not require
+// approval within the given timeout.
+var ErrRunApprovalTimeout = errors.New("timed out waiting for the run to require approval")
+
+// IsWaitingForApproval reports whether the run waits for a confirmation to apply.
+//
+// The runs waiting for a policy override always do. The planned and cost estimated
+// statuses are only transient if a cost estimate or policy checks follow, and the
+// run proceeds to apply without a confirmation if its workspace has auto-apply
+// enabled, so the run must be read with its workspace included, as Read does.
+func (r *Run) IsWaitingForApproval() bool {
+	switch r.Status {
+	case RunPolicyOverride, RunPolicySoftFailed:
+		return true
+	case RunPlanned:
+		return !r.autoApply() && r.CostEstimate == nil && len(r.PolicyChecks) == 0
+	case RunCostEstimated:
+		return !r.autoApply() && len(r.PolicyChecks) == 0
+	case RunPolicyChecked:
+		return !r.autoApply()
+	}
+	return false
+}
+
+func (r *Run) autoApply() bool {
+	return r.Workspace != nil && r.Workspace.AutoApply
+}
+
+// IsFinal reports whether the run reached a status it does not leave.
+func (r *Run) IsFinal() bool {
+	switch r.Status {
+	case RunApplied, RunCanceled, RunDiscarded, RunErrored, RunPlannedAndFinished:
+		return true
+	}
+	return false
+}
+
+// WaitForApproval polls the run until it waits for a confirmation, then calls
+// onWaiting, if given, and returns the run. An error is returned if the run
+// finishes without requiring approval or the timeout expires first. A zero
+// timeout waits until ctx is done.
+func (s *runs) WaitForApproval(ctx context.Context, runID string, timeout time.Duration, onWaiting func(*Run)) (*Run, error) {
+	if !validStringID(&runID) {
+		return nil, errors.New("invalid value for run ID")
+	}
+
+	var r *Run
+	interval := s.client.pollInterval(defaultRunPollInterval)
+	err := poll(ctx, interval, timeout, ErrRunApprovalTimeout, func(ctx context.Context) (bool, error) {
+		var err error
+		r, err = s.Read(ctx, runID)
+		if err != nil {
+			return false, err
+		}
+		if r.IsFinal() {
+			return false, fmt.Errorf("run %s finished with status %s without requiring approval", runID, r.Status)
+		}
+		return r.IsWaitingForApproval(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if onWaiting != nil {
+		onWaiting(r)
+	}
+	return r, nil
+}
+
+// ErrInvalidRunTransition is returned when a run action is not allowed
+// in the current status of the run.
+var ErrInvalidRunTransition = errors.New("invalid run status transition")
+
+// RunActionError is returned when the API rejects a run action
+// because of the run status.
+type RunActionError struct {
+	RunID   string
+	Action  string
+	Message string
+}
+
+func (e *RunActionError) Error() string {
+	return fmt.Sprintf("unable to %s run %s: %s", e.Action, e.RunID, e.Message)
+}
+
+func (e *RunActionError) Unwrap() error {
+	return ErrInvalidRunTransition
+}
+
+// Apply confirms the run that waits for approval. The comment is optional.
+func (s *runs) Apply(ctx context.Context, runID string, comment string) error {
+	return s.action(ctx, runID, "apply", comment)
+}
+
+// Discard rejects the run that waits for approval. The comment is optional.
+func (s *runs) Discard(ctx context.Context, runID string, comment string) error {
+	return s.action(ctx, runID, "discard", comment)
+}
+
+// Cancel stops the run that is queued or in progress. The comment is optional.
+func (s *runs) Cancel(ctx context.Context, runID string, comment string) error {
+	return s.action(ctx, runID, "cancel", comment)
+}
+
+// Prioritize moves the queued run to the front of the run queue, so it starts
+// before the runs queued earlier. A RunActionError is returned if the run is
+// not queued.
+func (s *runs) Prioritize(ctx context.Context, runID string) error {
+	return s.action(ctx, runID, "prioritize", "")
+}
+
+// ErrRunCancelTimeout is returned when the canceled run does not stop
+// within the grace period and force-canceling was not requested.
+var ErrRunCancelTimeout = errors.New("timed out waiting for the run to be canceled")
+
+// RunCancelOptions represents the options for canceling a run.
+type RunCancelOptions struct {
+	// The reason of the cancellation, optional.
+	Comment string
+	// Force-cancel the run if it is still active when the grace period expires.
+	// The run is terminated without waiting for Terraform to exit gracefully.
+	Force bool
+	// The grace period to wait for the run to stop after the cancellation
+	// is requested. Zero does not wait, so Force escalates immediately.
+	Wait time.Duration
+}
+
+// CancelWithOptions requests the cancellation of the run, like the Cancel button in the UI.
+// If Wait is set, the run is polled until it stops; when the grace period expires the run
+// is force-canceled if Force is set, otherwise ErrRunCancelTimeout is returned.
+// The last read state of the run is returned.
+func (s *runs) CancelWithOptions(ctx context.Context, runID string, options RunCancelOptions) (*Run, error) {
+	if err := s.action(ctx, runID, "cancel", options.Comment); err != nil {
+		return nil, err
+	}
+
+	if options.Wait > 0 {
+		var r *Run
+		interval := s.client.pollInterval(defaultRunPollInterval)
+		err := poll(ctx, interval, options.Wait, ErrRunCancelTimeout, func(ctx context.Context) (bool, error) {
+			var err error
+			r, err = s.Read(ctx, runID)
+			if err != nil {
+				return false, err
+			}
+			return r.IsFinal(), nil
+		})
+		if err == nil {
+			return r, nil
+		}
+		if !errors.Is(err, ErrRunCancelTimeout) {
+			return nil, err
+		}
+	}
+
+	if options.Force {
+		if err := s.action(ctx, runID, "force-cancel", options.Comment); err != nil {
+			return nil, err
+		}
+	} else if options.Wait > 0 {
+		return nil, ErrRunCancelTimeout
+	}
+
+	return s.Read(ctx, runID)
+}
+
+func (s *runs) action(ctx context.Context, runID, action, comment string) error {
+	if !validStringID(&runID) {
+		return errors.New("invalid value for run ID")
+	}
+
+	options := struct {
+		Comment string `json:"comment,omitempty"`
+	}{
+		Comment: comment,
+	}
+
+	u := fmt.Sprintf("runs/%s/actions/%s", url.QueryEscape(runID), action)
+	req, err := s.client.newJsonRequest("POST", u, &options)
+	if err != nil {
+		return err
+	}
+
+	err = s.client.do(ctx, req, nil)
+	var conflict ResourceConflictError
+	if errors.As(err, &conflict) {
+		return &RunActionError{RunID: runID, Action: action, Message: conflict.Error()}
+	}
+
+	return err
+}
+
+// ApplyIfNonDestructive inspects the plan summary of the run that waits for
+// approval and applies it only if no resources are going to be destroyed.
+// It reports whether the run was applied, so the workspace can keep auto-apply
+// disabled while non-destructive changes still go through without a review.
+func (s *runs) ApplyIfNonDestructive(ctx context.Context, runID string) (bool, error) {
+	if !validStringID(&runID) {
+		return false, errors.New("invalid value for run ID")
+	}
+
+	options := struct {
+		Include string `url:"include"`
+	}{
+		Include: "plan,workspace",
+	}
+
+	u := fmt.Sprintf("runs/%s", url.QueryEscape(runID))
+	req, err := s.client.newRequest("GET", u, options)
+	if err != nil {
+		return false, err
+	}
+
+	r := &Run{}
+	err = s.client.do(ctx, req, r)
+	if err != nil {
+		return false, err
+	}
+
+	if !r.IsWaitingForApproval() {
+		return false, fmt.Errorf("run %s is not waiting for approval, its status is %s", runID, r.Status)
+	}
+	if r.Plan == nil {
+		return false, fmt.Errorf("plan of run %s is not available", runID)
+	}
+	if r.Plan.IsDestructive() {
+		return false, nil
+	}
+
+	if err := s.Apply(ctx, runID, ""); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
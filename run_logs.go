@@ -0,0 +1,121 @@
+package scalr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// RunLogFile describes a log file written by DownloadLogs.
+type RunLogFile struct {
+	// Stage is either "plan" or "apply".
+	Stage string
+	Path  string
+}
+
+// DownloadLogs fetches the plan and apply logs of the run concurrently and
+// writes them into dir as <run ID>-<stage>-<timestamp>.log files, e.g. to archive
+// them alongside CI artifacts. The logs of a run in progress are followed until
+// the stage is finished. The apply log is skipped if the run has no apply or is
+// waiting for approval, and it is no longer followed once the run waits for
+// approval or is final, since the apply does not progress then. If any download
+// fails, all the files written by the call are removed.
+func (s *runs) DownloadLogs(ctx context.Context, runID string, dir string) ([]*RunLogFile, error) {
+	if !validStringID(&runID) {
+		return nil, errors.New("invalid value for run ID")
+	}
+	if !validString(&dir) {
+		return nil, errors.New("invalid value for directory")
+	}
+
+	r, err := s.Read(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	var logs []*RunLogFile
+	var readers []func() (io.ReadCloser, error)
+	timestamp := time.Now().UTC().Format("20060102T150405Z")
+	if r.Plan != nil {
+		logs = append(logs, &RunLogFile{Stage: "plan"})
+		readers = append(readers, func() (io.ReadCloser, error) { return s.client.Plans.ReadLogs(ctx, r.Plan.ID) })
+	}
+	if r.Apply != nil && !r.IsWaitingForApproval() {
+		logs = append(logs, &RunLogFile{Stage: "apply"})
+		readers = append(readers, func() (io.ReadCloser, error) { return s.readApplyLogs(ctx, r) })
+	}
+	if len(logs) == 0 {
+		return nil, fmt.Errorf("run %s has no logs", runID)
+	}
+
+	errs := make([]error, len(logs))
+	var wg sync.WaitGroup
+	for i := range logs {
+		logs[i].Path = filepath.Join(dir, fmt.Sprintf("%s-%s-%s.log", runID, logs[i].Stage, timestamp))
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = downloadLog(readers[i], logs[i].Path)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			for _, l := range logs {
+				os.Remove(l.Path)
+			}
+			return nil, fmt.Errorf("error downloading %s log: %w", logs[i].Stage, err)
+		}
+	}
+
+	return logs, nil
+}
+
+// readApplyLogs returns a reader of the apply output of the run, which stops
+// once the apply is finished or the run waits for approval or is final.
+func (s *runs) readApplyLogs(ctx context.Context, r *Run) (io.ReadCloser, error) {
+	path := fmt.Sprintf("applies/%s/logs", url.QueryEscape(r.Apply.ID))
+	return newLogReader(ctx, s.client, path, func() (bool, error) {
+		a, err := s.client.Applies.Read(ctx, r.Apply.ID)
+		if err != nil {
+			return false, err
+		}
+		if isLogFinal(a.Status) {
+			return true, nil
+		}
+
+		r, err := s.Read(ctx, r.ID)
+		if err != nil {
+			return false, err
+		}
+		return r.IsFinal() || r.IsWaitingForApproval(), nil
+	}), nil
+}
+
+// downloadLog writes the whole log into the file.
+func downloadLog(readLogs func() (io.ReadCloser, error), filename string) error {
+	rc, err := readLogs()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(f, rc)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+
+	return err
+}
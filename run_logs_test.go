@@ -0,0 +1,125 @@
+package scalr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunsDownloadLogs(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/iacp/v3/runs/run-123":
+			w.Header().Set("Content-Type", "application/vnd.api+json")
+			fmt.Fprint(w, `{"data":{"id":"run-123","type":"runs","attributes":{"status":"applied"},"relationships":{`+
+				`"plan":{"data":{"id":"plan-123","type":"plans"}},"apply":{"data":{"id":"apply-123","type":"applies"}}}}}`)
+		case "/api/iacp/v3/runs/run-broken":
+			w.Header().Set("Content-Type", "application/vnd.api+json")
+			fmt.Fprint(w, `{"data":{"id":"run-broken","type":"runs","attributes":{"status":"errored"},"relationships":{`+
+				`"plan":{"data":{"id":"plan-broken","type":"plans"}}}}}`)
+		case "/api/iacp/v3/runs/run-approval", "/api/iacp/v3/runs/run-discarded", "/api/iacp/v3/runs/run-partial":
+			status := map[string]string{"run-approval": "planned", "run-discarded": "discarded", "run-partial": "applied"}
+			id := path.Base(r.URL.Path)
+			applyID := "apply-pending"
+			if id == "run-partial" {
+				applyID = "apply-missing"
+			}
+			w.Header().Set("Content-Type", "application/vnd.api+json")
+			fmt.Fprintf(w, `{"data":{"id":%q,"type":"runs","attributes":{"status":%q},"relationships":{`+
+				`"plan":{"data":{"id":"plan-123","type":"plans"}},"apply":{"data":{"id":%q,"type":"applies"}}}}}`,
+				id, status[id], applyID)
+		case "/api/iacp/v3/applies/apply-pending":
+			w.Header().Set("Content-Type", "application/vnd.api+json")
+			fmt.Fprint(w, `{"data":{"id":"apply-pending","type":"applies","attributes":{"status":"pending"}}}`)
+		case "/api/iacp/v3/applies/apply-pending/logs":
+		case "/api/iacp/v3/plans/plan-123":
+			w.Header().Set("Content-Type", "application/vnd.api+json")
+			fmt.Fprint(w, `{"data":{"id":"plan-123","type":"plans","attributes":{"status":"finished"}}}`)
+		case "/api/iacp/v3/applies/apply-123":
+			w.Header().Set("Content-Type", "application/vnd.api+json")
+			fmt.Fprint(w, `{"data":{"id":"apply-123","type":"applies","attributes":{"status":"finished"}}}`)
+		case "/api/iacp/v3/plans/plan-123/logs":
+			serveLogChunk(w, r, "Plan: 1 to add, 0 to change, 0 to destroy.")
+		case "/api/iacp/v3/applies/apply-123/logs":
+			serveLogChunk(w, r, "Apply complete! Resources: 1 added, 0 changed, 0 destroyed.")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("when the run is applied", func(t *testing.T) {
+		dir := t.TempDir()
+		logs, err := client.Runs.DownloadLogs(ctx, "run-123", dir)
+		require.NoError(t, err)
+		require.Len(t, logs, 2)
+
+		assert.Equal(t, "plan", logs[0].Stage)
+		assert.Equal(t, dir, filepath.Dir(logs[0].Path))
+		content, err := os.ReadFile(logs[0].Path)
+		require.NoError(t, err)
+		assert.Equal(t, "Plan: 1 to add, 0 to change, 0 to destroy.", string(content))
+
+		assert.Equal(t, "apply", logs[1].Stage)
+		content, err = os.ReadFile(logs[1].Path)
+		require.NoError(t, err)
+		assert.Equal(t, "Apply complete! Resources: 1 added, 0 changed, 0 destroyed.", string(content))
+	})
+
+	t.Run("when the run is waiting for approval", func(t *testing.T) {
+		logs, err := client.Runs.DownloadLogs(ctx, "run-approval", t.TempDir())
+		require.NoError(t, err)
+		require.Len(t, logs, 1)
+		assert.Equal(t, "plan", logs[0].Stage)
+	})
+
+	t.Run("when the run is discarded", func(t *testing.T) {
+		logs, err := client.Runs.DownloadLogs(ctx, "run-discarded", t.TempDir())
+		require.NoError(t, err)
+		require.Len(t, logs, 2)
+
+		content, err := os.ReadFile(logs[1].Path)
+		require.NoError(t, err)
+		assert.Empty(t, content)
+	})
+
+	t.Run("when one of the logs fails", func(t *testing.T) {
+		dir := t.TempDir()
+		logs, err := client.Runs.DownloadLogs(ctx, "run-partial", dir)
+		assert.Nil(t, logs)
+		assert.ErrorIs(t, err, ErrResourceNotFound)
+
+		files, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, files)
+	})
+
+	t.Run("when the log is not available", func(t *testing.T) {
+		dir := t.TempDir()
+		logs, err := client.Runs.DownloadLogs(ctx, "run-broken", dir)
+		assert.Nil(t, logs)
+		assert.ErrorIs(t, err, ErrResourceNotFound)
+
+		files, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, files)
+	})
+
+	t.Run("with invalid run ID", func(t *testing.T) {
+		logs, err := client.Runs.DownloadLogs(ctx, badIdentifier, t.TempDir())
+		assert.Nil(t, logs)
+		assert.EqualError(t, err, "invalid value for run ID")
+	})
+}
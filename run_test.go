@@ -2,8 +2,14 @@ package scalr
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -97,4 +103,498 @@ func TestRunsCreate(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, cvTest.ID, r.ConfigurationVersion.ID)
 	})
+
+	t.Run("with message and labels", func(t *testing.T) {
+		options := RunCreateOptions{
+			Message:              String("Triggered by build 42"),
+			Labels:               RunLabels(map[string]string{"build": "42", "ticket": "OPS-1"}),
+			ConfigurationVersion: cvTest,
+			Workspace:            wsTest,
+		}
+
+		r, err := client.Runs.Create(ctx, options)
+		require.NoError(t, err)
+		assert.Equal(t, *options.Message, r.Message)
+		build, ok := r.Label("build")
+		assert.True(t, ok)
+		assert.Equal(t, "42", build)
+	})
+
+	t.Run("with duplicate label keys", func(t *testing.T) {
+		options := RunCreateOptions{
+			Labels:               []*RunLabel{{Key: "build", Value: "1"}, {Key: "build", Value: "2"}},
+			ConfigurationVersion: cvTest,
+			Workspace:            wsTest,
+		}
+
+		r, err := client.Runs.Create(ctx, options)
+		assert.Nil(t, r)
+		assert.EqualError(t, err, "duplicate label key 'build'")
+	})
+
+	t.Run("with variables", func(t *testing.T) {
+		options := RunCreateOptions{
+			Variables: []*RunVariable{
+				RunVariableString("region", "us-east-1"),
+				{Key: "replicas", Value: "3"},
+			},
+			ConfigurationVersion: cvTest,
+			Workspace:            wsTest,
+		}
+
+		r, err := client.Runs.Create(ctx, options)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, options.Variables, r.Variables)
+	})
+
+	t.Run("with duplicate variable keys", func(t *testing.T) {
+		options := RunCreateOptions{
+			Variables:            []*RunVariable{{Key: "replicas", Value: "1"}, {Key: "replicas", Value: "2"}},
+			ConfigurationVersion: cvTest,
+			Workspace:            wsTest,
+		}
+
+		r, err := client.Runs.Create(ctx, options)
+		assert.Nil(t, r)
+		assert.EqualError(t, err, "duplicate variable key 'replicas'")
+	})
+}
+
+func TestRunVariableString(t *testing.T) {
+	assert.Equal(t, &RunVariable{Key: "region", Value: `"us-east-1"`}, RunVariableString("region", "us-east-1"))
+}
+
+func TestRunLabels(t *testing.T) {
+	labels := RunLabels(map[string]string{"ticket": "OPS-1", "build": "42"})
+	assert.Equal(t, []*RunLabel{{Key: "build", Value: "42"}, {Key: "ticket", Value: "OPS-1"}}, labels)
+
+	r := &Run{Labels: labels}
+	v, ok := r.Label("ticket")
+	assert.True(t, ok)
+	assert.Equal(t, "OPS-1", v)
+	_, ok = r.Label("missing")
+	assert.False(t, ok)
+}
+
+func TestRunsRetry(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	runTest, runTestCleanup := createRun(t, client, nil, nil)
+	defer runTestCleanup()
+
+	t.Run("when the run exists", func(t *testing.T) {
+		r, err := client.Runs.Retry(ctx, runTest.ID)
+		require.NoError(t, err)
+		assert.NotEqual(t, runTest.ID, r.ID)
+		assert.Equal(t, runTest.ConfigurationVersion.ID, r.ConfigurationVersion.ID)
+		assert.Equal(t, runTest.Workspace.ID, r.Workspace.ID)
+		assert.Equal(t, runTest.IsDestroy, r.IsDestroy)
+	})
+
+	t.Run("with invalid run ID", func(t *testing.T) {
+		r, err := client.Runs.Retry(ctx, badIdentifier)
+		assert.Nil(t, r)
+		assert.EqualError(t, err, "invalid value for run ID")
+	})
+}
+
+func TestRunsListEvents(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	runTest, runTestCleanup := createRun(t, client, nil, nil)
+	defer runTestCleanup()
+
+	t.Run("when the run exists", func(t *testing.T) {
+		el, err := client.Runs.ListEvents(ctx, runTest.ID, RunEventListOptions{Include: "created-by"})
+		require.NoError(t, err)
+		require.NotEmpty(t, el.Items)
+		assert.Equal(t, 1, el.CurrentPage)
+		for i := 1; i < len(el.Items); i++ {
+			assert.False(t, el.Items[i].CreatedAt.Before(el.Items[i-1].CreatedAt))
+		}
+	})
+
+	t.Run("with invalid run ID", func(t *testing.T) {
+		el, err := client.Runs.ListEvents(ctx, badIdentifier, RunEventListOptions{})
+		assert.Nil(t, el)
+		assert.EqualError(t, err, "invalid value for run ID")
+	})
+}
+
+func TestRunsWaitForApproval(t *testing.T) {
+	statuses := []RunStatus{RunPlanQueued, RunPlanning, RunPlanned}
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		var status RunStatus
+		switch r.URL.Path {
+		case "/api/iacp/v3/runs/run-approval":
+			n := int(atomic.AddInt32(&calls, 1)) - 1
+			if n >= len(statuses) {
+				n = len(statuses) - 1
+			}
+			status = statuses[n]
+		case "/api/iacp/v3/runs/run-errored":
+			status = RunErrored
+		default:
+			status = RunPlanning
+		}
+		fmt.Fprintf(w, `{"data":{"id":"run-123","type":"runs","attributes":{"status":%q}}}`, status)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client(), PollInterval: time.Millisecond})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("when the run requires approval", func(t *testing.T) {
+		var notified *Run
+		r, err := client.Runs.WaitForApproval(ctx, "run-approval", time.Second, func(r *Run) { notified = r })
+		require.NoError(t, err)
+		assert.Equal(t, RunPlanned, r.Status)
+		assert.Equal(t, r, notified)
+	})
+
+	t.Run("when the run finishes", func(t *testing.T) {
+		r, err := client.Runs.WaitForApproval(ctx, "run-errored", time.Second, nil)
+		assert.Nil(t, r)
+		assert.EqualError(t, err, "run run-errored finished with status errored without requiring approval")
+	})
+
+	t.Run("when the timeout expires", func(t *testing.T) {
+		r, err := client.Runs.WaitForApproval(ctx, "run-planning", 10*time.Millisecond, nil)
+		assert.Nil(t, r)
+		assert.ErrorIs(t, err, ErrRunApprovalTimeout)
+	})
+
+	t.Run("with invalid run ID", func(t *testing.T) {
+		r, err := client.Runs.WaitForApproval(ctx, badIdentifier, time.Second, nil)
+		assert.Nil(t, r)
+		assert.EqualError(t, err, "invalid value for run ID")
+	})
+}
+
+func TestRunIsWaitingForApproval(t *testing.T) {
+	autoApply := &Workspace{AutoApply: true}
+	cases := []struct {
+		name    string
+		run     Run
+		waiting bool
+	}{
+		{"planned", Run{Status: RunPlanned}, true},
+		{"planned before cost estimation", Run{Status: RunPlanned, CostEstimate: &CostEstimate{ID: "ce-1"}}, false},
+		{"planned before policy checks", Run{Status: RunPlanned, PolicyChecks: []*PolicyCheck{{ID: "pc-1"}}}, false},
+		{"planned with auto-apply", Run{Status: RunPlanned, Workspace: autoApply}, false},
+		{"cost estimated", Run{Status: RunCostEstimated, CostEstimate: &CostEstimate{ID: "ce-1"}}, true},
+		{"cost estimated before policy checks", Run{Status: RunCostEstimated, PolicyChecks: []*PolicyCheck{{ID: "pc-1"}}}, false},
+		{"policy checked", Run{Status: RunPolicyChecked, PolicyChecks: []*PolicyCheck{{ID: "pc-1"}}}, true},
+		{"policy checked with auto-apply", Run{Status: RunPolicyChecked, Workspace: autoApply}, false},
+		{"policy override", Run{Status: RunPolicyOverride, Workspace: autoApply}, true},
+		{"policy soft failed", Run{Status: RunPolicySoftFailed, Workspace: autoApply}, true},
+		{"cost estimating", Run{Status: RunCostEstimating}, false},
+		{"planned and finished", Run{Status: RunPlannedAndFinished}, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.waiting, c.run.IsWaitingForApproval())
+		})
+	}
+}
+
+func TestRunsReadApprovers(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/iacp/v3/runs/run-123/approvers", r.URL.Path)
+		assert.Equal(t, "teams,users", r.URL.Query().Get("include"))
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		fmt.Fprint(w, `{"data":{"id":"rapr-123","type":"run-approvers","attributes":{"required-approvals":2},`+
+			`"relationships":{"teams":{"data":[{"id":"team-1","type":"teams"}]},"users":{"data":[{"id":"user-1","type":"users"}]}}},`+
+			`"included":[{"id":"team-1","type":"teams","attributes":{"name":"platform"}},`+
+			`{"id":"user-1","type":"users","attributes":{"email":"ops@example.com"}}]}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("when the run exists", func(t *testing.T) {
+		ra, err := client.Runs.ReadApprovers(ctx, "run-123")
+		require.NoError(t, err)
+		assert.Equal(t, 2, ra.RequiredApprovals)
+		require.Len(t, ra.Teams, 1)
+		assert.Equal(t, "platform", ra.Teams[0].Name)
+		require.Len(t, ra.Users, 1)
+		assert.Equal(t, "ops@example.com", ra.Users[0].Email)
+	})
+
+	t.Run("with invalid run ID", func(t *testing.T) {
+		ra, err := client.Runs.ReadApprovers(ctx, badIdentifier)
+		assert.Nil(t, ra)
+		assert.EqualError(t, err, "invalid value for run ID")
+	})
+}
+
+func TestRunsApplyIfNonDestructive(t *testing.T) {
+	var applied []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		if r.Method == "POST" {
+			applied = append(applied, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		assert.Equal(t, "plan,workspace", r.URL.Query().Get("include"))
+		status, destructions := RunPlanned, 0
+		switch r.URL.Path {
+		case "/api/iacp/v3/runs/run-destructive":
+			destructions = 2
+		case "/api/iacp/v3/runs/run-applied":
+			status = RunApplied
+		case "/api/iacp/v3/runs/run-estimating":
+			fmt.Fprint(w, `{"data":{"id":"run-123","type":"runs","attributes":{"status":"planned"},"relationships":{`+
+				`"cost-estimate":{"data":{"id":"ce-123","type":"cost-estimates"}},"plan":{"data":{"id":"plan-123","type":"plans"}}}},`+
+				`"included":[{"id":"plan-123","type":"plans","attributes":{"resource-additions":1}}]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"data":{"id":"run-123","type":"runs","attributes":{"status":%q},`+
+			`"relationships":{"plan":{"data":{"id":"plan-123","type":"plans"}}}},`+
+			`"included":[{"id":"plan-123","type":"plans","attributes":{"resource-additions":1,"resource-destructions":%d}}]}`,
+			status, destructions)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("when the plan is non-destructive", func(t *testing.T) {
+		ok, err := client.Runs.ApplyIfNonDestructive(ctx, "run-safe")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []string{"/api/iacp/v3/runs/run-safe/actions/apply"}, applied)
+	})
+
+	t.Run("when the plan destroys resources", func(t *testing.T) {
+		applied = nil
+		ok, err := client.Runs.ApplyIfNonDestructive(ctx, "run-destructive")
+		require.NoError(t, err)
+		assert.False(t, ok)
+		assert.Empty(t, applied)
+	})
+
+	t.Run("when the run is not waiting for approval", func(t *testing.T) {
+		ok, err := client.Runs.ApplyIfNonDestructive(ctx, "run-applied")
+		assert.False(t, ok)
+		assert.EqualError(t, err, "run run-applied is not waiting for approval, its status is applied")
+	})
+
+	t.Run("when the cost estimation is pending", func(t *testing.T) {
+		applied = nil
+		ok, err := client.Runs.ApplyIfNonDestructive(ctx, "run-estimating")
+		assert.False(t, ok)
+		assert.EqualError(t, err, "run run-estimating is not waiting for approval, its status is planned")
+		assert.Empty(t, applied)
+	})
+
+	t.Run("with invalid run ID", func(t *testing.T) {
+		ok, err := client.Runs.ApplyIfNonDestructive(ctx, badIdentifier)
+		assert.False(t, ok)
+		assert.EqualError(t, err, "invalid value for run ID")
+	})
+}
+
+func TestRunsActions(t *testing.T) {
+	type request struct {
+		Path    string
+		Comment string
+	}
+	var got request
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Comment string `json:"comment"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		got = request{Path: r.URL.Path, Comment: body.Comment}
+		if strings.HasPrefix(r.URL.Path, "/api/iacp/v3/runs/run-applied/") {
+			w.Header().Set("Content-Type", "application/vnd.api+json")
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"errors":[{"status":"409","title":"Conflict","detail":"Run is already applied."}]}`)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	for action, call := range map[string]func(context.Context, string, string) error{
+		"apply":   client.Runs.Apply,
+		"discard": client.Runs.Discard,
+		"cancel":  client.Runs.Cancel,
+	} {
+		t.Run(action, func(t *testing.T) {
+			err := call(ctx, "run-123", "Triggered by CI")
+			require.NoError(t, err)
+			assert.Equal(t, request{Path: "/api/iacp/v3/runs/run-123/actions/" + action, Comment: "Triggered by CI"}, got)
+		})
+	}
+
+	t.Run("with invalid status transition", func(t *testing.T) {
+		err := client.Runs.Discard(ctx, "run-applied", "")
+		assert.ErrorIs(t, err, ErrInvalidRunTransition)
+		assert.EqualError(t, err, "unable to discard run run-applied: Conflict\n\nRun is already applied.")
+	})
+
+	t.Run("with invalid run ID", func(t *testing.T) {
+		err := client.Runs.Cancel(ctx, badIdentifier, "")
+		assert.EqualError(t, err, "invalid value for run ID")
+	})
+}
+
+func TestRunsCancelWithOptions(t *testing.T) {
+	var actions []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			var body struct {
+				Comment string `json:"comment"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			actions = append(actions, strings.TrimPrefix(r.URL.Path, "/api/iacp/v3/runs/")+": "+body.Comment)
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+
+		// The stuck run is only stopped by the force-cancel action.
+		status := "canceled"
+		if strings.HasSuffix(r.URL.Path, "/run-stuck") && len(actions) < 2 {
+			status = "applying"
+		}
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		fmt.Fprintf(w, `{"data":{"id":"%s","type":"runs","attributes":{"status":"%s"}}}`,
+			strings.TrimPrefix(r.URL.Path, "/api/iacp/v3/runs/"), status)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client(), PollInterval: 10 * time.Millisecond})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("stops within the grace period", func(t *testing.T) {
+		actions = nil
+		r, err := client.Runs.CancelWithOptions(ctx, "run-123", RunCancelOptions{
+			Comment: "Superseded", Force: true, Wait: time.Second,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, RunCanceled, r.Status)
+		assert.Equal(t, []string{"run-123/actions/cancel: Superseded"}, actions)
+	})
+
+	t.Run("force-cancels after the grace period", func(t *testing.T) {
+		actions = nil
+		r, err := client.Runs.CancelWithOptions(ctx, "run-stuck", RunCancelOptions{
+			Comment: "Superseded", Force: true, Wait: 50 * time.Millisecond,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, RunCanceled, r.Status)
+		assert.Equal(t, []string{
+			"run-stuck/actions/cancel: Superseded",
+			"run-stuck/actions/force-cancel: Superseded",
+		}, actions)
+	})
+
+	t.Run("times out without force", func(t *testing.T) {
+		actions = nil
+		r, err := client.Runs.CancelWithOptions(ctx, "run-stuck", RunCancelOptions{Wait: 50 * time.Millisecond})
+		assert.Nil(t, r)
+		assert.ErrorIs(t, err, ErrRunCancelTimeout)
+		assert.Equal(t, []string{"run-stuck/actions/cancel: "}, actions)
+	})
+
+	t.Run("with invalid run ID", func(t *testing.T) {
+		r, err := client.Runs.CancelWithOptions(ctx, badIdentifier, RunCancelOptions{})
+		assert.Nil(t, r)
+		assert.EqualError(t, err, "invalid value for run ID")
+	})
+}
+
+func TestRunsList(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/iacp/v3/runs", r.URL.Path)
+		assert.Equal(t, "env-123", r.URL.Query().Get("filter[environment]"))
+		assert.Equal(t, "in:pending,plan_queued", r.URL.Query().Get("filter[status]"))
+		assert.Equal(t, "vcs", r.URL.Query().Get("filter[source]"))
+		assert.Equal(t, "gte:2023-01-01T00:00:00Z", r.URL.Query().Get("filter[created-at]"))
+		assert.Equal(t, "-created-at", r.URL.Query().Get("sort"))
+
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		fmt.Fprint(w, `{"data":[{"id":"run-1","type":"runs","attributes":{"status":"pending","source":"vcs"}},`+
+			`{"id":"run-2","type":"runs","attributes":{"status":"plan_queued","source":"vcs"}}],`+
+			`"meta":{"pagination":{"current-page":1,"total-pages":1,"total-count":2}}}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("with filters", func(t *testing.T) {
+		rl, err := client.Runs.List(ctx, RunListOptions{
+			Environment: String("env-123"),
+			Status:      RunStatusesFilter(RunPending, RunPlanQueued),
+			Source:      Ptr(RunSourceVCS),
+			CreatedAt:   &TimeRangeFilter{After: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+			Sort:        SortBy(RunSortByCreatedAt.Desc()),
+		})
+		require.NoError(t, err)
+		require.Len(t, rl.Items, 2)
+		assert.Equal(t, "run-1", rl.Items[0].ID)
+		assert.Equal(t, RunPlanQueued, rl.Items[1].Status)
+		assert.Equal(t, 2, rl.TotalCount)
+	})
+
+	t.Run("with invalid workspace ID", func(t *testing.T) {
+		_, err := client.Runs.List(ctx, RunListOptions{Workspace: String(badIdentifier)})
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+
+	t.Run("with invalid sort", func(t *testing.T) {
+		_, err := client.Runs.List(ctx, RunListOptions{Sort: String("name")})
+		assert.Error(t, err)
+	})
+}
+
+func TestRunsPrioritize(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		switch r.URL.Path {
+		case "/api/iacp/v3/runs/run-queued/actions/prioritize":
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"errors":[{"status":"409","title":"Conflict","detail":"Run in status 'applied' cannot be prioritized."}]}`)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("when the run is queued", func(t *testing.T) {
+		assert.NoError(t, client.Runs.Prioritize(ctx, "run-queued"))
+	})
+
+	t.Run("when the run is finished", func(t *testing.T) {
+		err := client.Runs.Prioritize(ctx, "run-applied")
+		assert.ErrorIs(t, err, ErrInvalidRunTransition)
+	})
+
+	t.Run("with invalid run ID", func(t *testing.T) {
+		assert.EqualError(t, client.Runs.Prioritize(ctx, badIdentifier), "invalid value for run ID")
+	})
 }
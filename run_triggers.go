@@ -5,21 +5,32 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"sort"
+	"strings"
 	"time"
 )
 
 // Compile-time proof of interface implementation.
 var _ RunTriggers = (*runTriggers)(nil)
 
+// RunTriggers describes all the run trigger related methods that the Scalr API supports.
+// A run trigger queues a run in the downstream workspace when a run of the upstream
+// workspace is applied.
 type RunTriggers interface {
 	// Create is used to create a new run trigger.
 	Create(ctx context.Context, options RunTriggerCreateOptions) (*RunTrigger, error)
 
-	// Read RunTrigger by it's ID
+	// Read a run trigger by its ID.
 	Read(ctx context.Context, runTriggerID string) (*RunTrigger, error)
 
-	// Delete RunTrigger by it's ID
+	// Delete a run trigger by its ID.
 	Delete(ctx context.Context, runTriggerID string) error
+
+	// List the run triggers, filtered by the upstream or downstream workspace.
+	List(ctx context.Context, options RunTriggerListOptions) (*RunTriggerList, error)
+
+	// CreateBatch creates many run triggers concurrently, skipping the existing ones.
+	CreateBatch(ctx context.Context, options []RunTriggerCreateOptions, batch BatchOptions) ([]*RunTriggerBatchResult, error)
 }
 
 // runTriggers implements RunTriggers
@@ -27,6 +38,7 @@ type runTriggers struct {
 	client *Client
 }
 
+// RunTrigger represents a Scalr run trigger.
 type RunTrigger struct {
 	ID        string    `jsonapi:"primary,run-triggers"`
 	CreatedAt time.Time `jsonapi:"attr,created-at,iso8601"`
@@ -36,6 +48,7 @@ type RunTrigger struct {
 	Downstream *Downstream `jsonapi:"relation,downstream"`
 }
 
+// RunTriggerCreateOptions represents the options for creating a new run trigger.
 type RunTriggerCreateOptions struct {
 	// For internal use only!
 	ID string `jsonapi:"primary,run-triggers"`
@@ -44,10 +57,41 @@ type RunTriggerCreateOptions struct {
 	Upstream   *Upstream   `jsonapi:"relation,upstream"`
 }
 
+// RunTriggerList represents a list of run triggers.
+type RunTriggerList struct {
+	*Pagination
+	Items []*RunTrigger
+}
+
+// RunTriggerListOptions represents the options for listing run triggers.
+type RunTriggerListOptions struct {
+	ListOptions
+
+	// Filter by the workspace ID that triggers the runs.
+	Upstream *string `url:"filter[upstream],omitempty"`
+	// Filter by the workspace ID whose runs are triggered.
+	Downstream *string `url:"filter[downstream],omitempty"`
+
+	// The comma-separated list of relationships to include, e.g. "upstream,downstream".
+	Include *string `url:"include,omitempty"`
+}
+
+func (o RunTriggerListOptions) valid() error {
+	if o.Upstream != nil && !validStringID(o.Upstream) {
+		return errors.New("invalid value for upstream ID")
+	}
+	if o.Downstream != nil && !validStringID(o.Downstream) {
+		return errors.New("invalid value for downstream ID")
+	}
+	return nil
+}
+
+// Downstream references the workspace whose runs are triggered.
 type Downstream struct {
 	ID string `jsonapi:"primary,workspaces"`
 }
 
+// Upstream references the workspace that triggers the runs.
 type Upstream struct {
 	ID string `jsonapi:"primary,workspaces"`
 }
@@ -86,17 +130,18 @@ func (o RunTriggerCreateOptions) valid() error {
 		return errors.New("downstream ID is required")
 	}
 	if !validStringID(&o.Downstream.ID) {
-		return errors.New("invalid value for Downstream ID")
+		return errors.New("invalid value for downstream ID")
 	}
 	if !validString(&o.Upstream.ID) {
 		return errors.New("upstream ID is required")
 	}
 	if !validStringID(&o.Upstream.ID) {
-		return errors.New("invalid value for Upstream ID")
+		return errors.New("invalid value for upstream ID")
 	}
 	return nil
 }
 
+// Read a run trigger by its ID.
 func (s *runTriggers) Read(ctx context.Context, runTriggerID string) (*RunTrigger, error) {
 	if !validStringID(&runTriggerID) {
 		return nil, errors.New("invalid value for RunTrigger ID")
@@ -117,6 +162,7 @@ func (s *runTriggers) Read(ctx context.Context, runTriggerID string) (*RunTrigge
 	return runTrigger, nil
 }
 
+// Delete a run trigger by its ID.
 func (s *runTriggers) Delete(ctx context.Context, runTriggerID string) error {
 	if !validStringID(&runTriggerID) {
 		return errors.New("invalid value for RunTrigger ID")
@@ -130,3 +176,147 @@ func (s *runTriggers) Delete(ctx context.Context, runTriggerID string) error {
 
 	return s.client.do(ctx, req, nil)
 }
+
+// List the run triggers, filtered by the upstream or downstream workspace.
+func (s *runTriggers) List(ctx context.Context, options RunTriggerListOptions) (*RunTriggerList, error) {
+	if err := options.valid(); err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.newRequest("GET", "run-triggers", &options)
+	if err != nil {
+		return nil, err
+	}
+
+	rtl := &RunTriggerList{}
+	err = s.client.do(ctx, req, rtl)
+	if err != nil {
+		return nil, err
+	}
+
+	return rtl, nil
+}
+
+// RunTriggerBatchResult represents the outcome of a single run trigger of a batch.
+type RunTriggerBatchResult struct {
+	Options RunTriggerCreateOptions
+
+	// The created run trigger, or the existing one if it was skipped.
+	RunTrigger *RunTrigger
+
+	// Whether the run trigger already existed or was repeated in the batch.
+	Skipped bool
+
+	Err error
+}
+
+// RunTriggerBatchError aggregates the errors of a batch, keyed by the
+// index of the run trigger in the batch.
+type RunTriggerBatchError struct {
+	Errors map[int]error
+}
+
+func (e *RunTriggerBatchError) Error() string {
+	indexes := make([]int, 0, len(e.Errors))
+	for i := range e.Errors {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+
+	msgs := make([]string, len(indexes))
+	for n, i := range indexes {
+		msgs[n] = fmt.Sprintf("%d: %v", i, e.Errors[i])
+	}
+	return fmt.Sprintf("%d of the run triggers failed:\n%s", len(indexes), strings.Join(msgs, "\n"))
+}
+
+// CreateBatch validates all the options first and then creates the run triggers
+// concurrently. Run triggers between workspaces that are already connected, or
+// repeated in the batch, are skipped. A result is returned for each of the options,
+// in the same order, along with a *RunTriggerBatchError if any creation failed.
+// If the context is done, the run triggers not created yet are left out and the
+// context error is returned along with the results.
+func (s *runTriggers) CreateBatch(
+	ctx context.Context, options []RunTriggerCreateOptions, batch BatchOptions,
+) ([]*RunTriggerBatchResult, error) {
+	if err := batch.valid(); err != nil {
+		return nil, err
+	}
+	for i, o := range options {
+		if err := o.valid(); err != nil {
+			return nil, fmt.Errorf("run trigger %d: %w", i, err)
+		}
+	}
+
+	type pair struct{ upstream, downstream string }
+
+	existing := make(map[pair]*RunTrigger)
+	listed := make(map[string]bool)
+	for _, o := range options {
+		if listed[o.Downstream.ID] {
+			continue
+		}
+		listed[o.Downstream.ID] = true
+
+		listOptions := RunTriggerListOptions{Downstream: String(o.Downstream.ID)}
+		for {
+			rtl, err := s.List(ctx, listOptions)
+			if err != nil {
+				return nil, err
+			}
+			for _, rt := range rtl.Items {
+				if rt.Upstream != nil && rt.Downstream != nil {
+					existing[pair{rt.Upstream.ID, rt.Downstream.ID}] = rt
+				}
+			}
+			if rtl.Pagination == nil || rtl.NextPage == 0 {
+				break
+			}
+			listOptions.PageNumber = rtl.NextPage
+		}
+	}
+
+	results := make([]*RunTriggerBatchResult, len(options))
+	first := make(map[pair]int)
+	for i, o := range options {
+		results[i] = &RunTriggerBatchResult{Options: o}
+		p := pair{o.Upstream.ID, o.Downstream.ID}
+		if rt, ok := existing[p]; ok {
+			results[i].RunTrigger = rt
+			results[i].Skipped = true
+		} else if _, ok := first[p]; ok {
+			results[i].Skipped = true
+		} else {
+			first[p] = i
+		}
+	}
+
+	pending := make([]*RunTriggerBatchResult, 0, len(first))
+	for _, r := range results {
+		if !r.Skipped {
+			pending = append(pending, r)
+		}
+	}
+	err := runBatch(ctx, len(pending), batch.concurrency(), func(i int) {
+		pending[i].RunTrigger, pending[i].Err = s.Create(ctx, pending[i].Options)
+	})
+	if err != nil {
+		return results, err
+	}
+
+	errs := make(map[int]error)
+	for i, r := range results {
+		if r.Skipped && r.RunTrigger == nil {
+			created := results[first[pair{r.Options.Upstream.ID, r.Options.Downstream.ID}]]
+			r.RunTrigger, r.Err = created.RunTrigger, created.Err
+		}
+		if r.Err != nil {
+			errs[i] = r.Err
+		}
+	}
+	if len(errs) > 0 {
+		return results, &RunTriggerBatchError{Errors: errs}
+	}
+
+	return results, nil
+}
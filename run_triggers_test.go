@@ -3,6 +3,11 @@ package scalr
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -153,3 +158,145 @@ func TestRunTriggersDelete(t *testing.T) {
 	})
 
 }
+
+func TestRunTriggersList(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	envTest, envTestCleanup := createEnvironment(t, client)
+	defer envTestCleanup()
+
+	wsTest1, wsTest1Cleanup := createWorkspace(t, client, envTest)
+	defer wsTest1Cleanup()
+	wsTest2, wsTest2Cleanup := createWorkspace(t, client, envTest)
+	defer wsTest2Cleanup()
+
+	createdTrigger, err := client.RunTriggers.Create(ctx, RunTriggerCreateOptions{
+		Downstream: &Downstream{ID: wsTest1.ID},
+		Upstream:   &Upstream{ID: wsTest2.ID},
+	})
+	require.NoError(t, err)
+
+	t.Run("filtered by upstream", func(t *testing.T) {
+		rtl, err := client.RunTriggers.List(ctx, RunTriggerListOptions{Upstream: String(wsTest2.ID)})
+		require.NoError(t, err)
+		require.Len(t, rtl.Items, 1)
+		assert.Equal(t, createdTrigger.ID, rtl.Items[0].ID)
+	})
+
+	t.Run("filtered by downstream", func(t *testing.T) {
+		rtl, err := client.RunTriggers.List(ctx, RunTriggerListOptions{Downstream: String(wsTest2.ID)})
+		require.NoError(t, err)
+		assert.Empty(t, rtl.Items)
+	})
+
+	t.Run("with invalid upstream ID", func(t *testing.T) {
+		rtl, err := client.RunTriggers.List(ctx, RunTriggerListOptions{Upstream: String(badIdentifier)})
+		assert.Nil(t, rtl)
+		assert.EqualError(t, err, "invalid value for upstream ID")
+	})
+}
+
+func TestRunTriggersListInclude(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/iacp/v3/run-triggers", r.URL.Path)
+		assert.Equal(t, "ws-2", r.URL.Query().Get("filter[downstream]"))
+		assert.Equal(t, "upstream", r.URL.Query().Get("include"))
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		fmt.Fprint(w, `{"data":[{"id":"rt-1","type":"run-triggers","relationships":{`+
+			`"upstream":{"data":{"id":"ws-1","type":"workspaces"}},"downstream":{"data":{"id":"ws-2","type":"workspaces"}}}}]}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+
+	rtl, err := client.RunTriggers.List(context.Background(), RunTriggerListOptions{
+		Downstream: String("ws-2"),
+		Include:    String("upstream"),
+	})
+	require.NoError(t, err)
+	require.Len(t, rtl.Items, 1)
+	assert.Equal(t, "ws-1", rtl.Items[0].Upstream.ID)
+	assert.Equal(t, "ws-2", rtl.Items[0].Downstream.ID)
+}
+
+func TestRunTriggersCreateBatch(t *testing.T) {
+	var created int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		if r.Method == "GET" {
+			if r.URL.Query().Get("filter[downstream]") == "ws-down" {
+				fmt.Fprint(w, `{"data":[{"id":"rt-existing","type":"run-triggers","relationships":{`+
+					`"upstream":{"data":{"id":"ws-up1","type":"workspaces"}},`+
+					`"downstream":{"data":{"id":"ws-down","type":"workspaces"}}}}]}`)
+				return
+			}
+			fmt.Fprint(w, `{"data":[]}`)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		if strings.Contains(string(body), "ws-bad") {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"errors":[{"status":"422","title":"invalid","detail":"bad upstream"}]}`)
+			return
+		}
+		n := atomic.AddInt32(&created, 1)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"data":{"id":"rt-new%d","type":"run-triggers"}}`, n)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	trigger := func(upstream, downstream string) RunTriggerCreateOptions {
+		return RunTriggerCreateOptions{Upstream: &Upstream{ID: upstream}, Downstream: &Downstream{ID: downstream}}
+	}
+
+	t.Run("with existing, repeated and failing run triggers", func(t *testing.T) {
+		results, err := client.RunTriggers.CreateBatch(ctx, []RunTriggerCreateOptions{
+			trigger("ws-up1", "ws-down"),
+			trigger("ws-up2", "ws-down"),
+			trigger("ws-up2", "ws-down"),
+			trigger("ws-bad", "ws-down"),
+		}, BatchOptions{})
+		require.Len(t, results, 4)
+
+		var batchErr *RunTriggerBatchError
+		require.ErrorAs(t, err, &batchErr)
+		assert.Len(t, batchErr.Errors, 1)
+		assert.Contains(t, batchErr.Errors, 3)
+
+		assert.True(t, results[0].Skipped)
+		assert.Equal(t, "rt-existing", results[0].RunTrigger.ID)
+
+		assert.False(t, results[1].Skipped)
+		require.NoError(t, results[1].Err)
+		assert.True(t, results[2].Skipped)
+		assert.Equal(t, results[1].RunTrigger, results[2].RunTrigger)
+
+		assert.Error(t, results[3].Err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&created))
+	})
+
+	t.Run("with invalid options", func(t *testing.T) {
+		results, err := client.RunTriggers.CreateBatch(ctx, []RunTriggerCreateOptions{
+			trigger("ws-up1", "ws-down"),
+			{Upstream: &Upstream{ID: "ws-up1"}},
+		}, BatchOptions{})
+		assert.Nil(t, results)
+		assert.EqualError(t, err, "run trigger 1: downstream ID is required")
+	})
+
+	t.Run("with negative concurrency", func(t *testing.T) {
+		results, err := client.RunTriggers.CreateBatch(ctx, []RunTriggerCreateOptions{
+			trigger("ws-up1", "ws-down"),
+		}, BatchOptions{Concurrency: -1})
+		assert.Nil(t, results)
+		assert.EqualError(t, err, "invalid value for concurrency")
+	})
+}
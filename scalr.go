@@ -12,6 +12,8 @@ import (
 	"net/url"
 	"os"
 	"reflect"
+	"regexp"
+	"runtime/debug"
 	"strings"
 	"time"
 
@@ -21,9 +23,38 @@ import (
 	"github.com/svanharmelen/jsonapi"
 )
 
-const (
-	userAgent = "go-scalr"
+// userAgent identifies the client and, if known, the version of the module.
+var userAgent = moduleUserAgent(debug.ReadBuildInfo())
+
+// moduleUserAgent returns the User-Agent with the version of this module found
+// in the build info, e.g. "go-scalr/v2.3.0", or "go-scalr" if it is unknown.
+func moduleUserAgent(info *debug.BuildInfo, ok bool) string {
+	const product = "go-scalr"
+	if !ok {
+		return product
+	}
+
+	path := reflect.TypeOf(Client{}).PkgPath()
+	version := info.Main.Version
+	if info.Main.Path != path {
+		version = ""
+		for _, dep := range info.Deps {
+			if dep.Path == path {
+				if dep.Replace != nil {
+					dep = dep.Replace
+				}
+				version = dep.Version
+				break
+			}
+		}
+	}
+	if version == "" || version == "(devel)" {
+		return product
+	}
+	return product + "/" + version
+}
 
+const (
 	// DefaultAddress of Scalr.
 	DefaultAddress = "https://scalr.io"
 	// DefaultBasePath on which the API is served.
@@ -42,6 +73,20 @@ var (
 	ErrUnauthorized = errors.New("unauthorized")
 
 	ErrResourceNotFound = errors.New("resource not found")
+
+	// ErrMultipleResourcesFound is returned when a lookup expected
+	// to match a single resource matches several.
+	ErrMultipleResourcesFound = errors.New("multiple resources found")
+
+	// ErrResourceConflict is returned when a receiving a 409.
+	ErrResourceConflict = errors.New("resource conflict")
+
+	// ErrResourceAlreadyExists is returned when a strict create
+	// finds an existing resource with the same name.
+	ErrResourceAlreadyExists = errors.New("resource already exists")
+
+	// ErrForbidden is returned when receiving a 403.
+	ErrForbidden = errors.New("forbidden")
 )
 
 type ResourceNotFoundError struct {
@@ -60,6 +105,71 @@ func (e ResourceNotFoundError) Unwrap() error {
 	return ErrResourceNotFound
 }
 
+type ResourceConflictError struct {
+	Message string
+}
+
+func (e ResourceConflictError) Error() string {
+	if len(e.Message) == 0 {
+		return "resource conflict"
+	}
+	return e.Message
+}
+
+func (e ResourceConflictError) Unwrap() error {
+	return ErrResourceConflict
+}
+
+// ForbiddenError is returned when the access token lacks the permissions for the request.
+type ForbiddenError struct {
+	Message string
+	// The permission reported as missing by the API, e.g. "workspaces:update",
+	// empty if the API did not tell. The API returns no structured field for it,
+	// so it is the first permission name found in the error messages, on a
+	// best-effort basis: it is empty if the wording of the messages changes.
+	MissingPermission string
+}
+
+func (e ForbiddenError) Error() string {
+	return "The Scalr Terraform provider has been configured with an access token that lacks sufficient permissions." +
+		" If you are running remotely, follow the documentation (https://docs.scalr.io/docs/scalr) on how to " +
+		"enable the Scalr provider configuration in the remote workspace. " +
+		"If running locally, ensure you have enough permissions to perform actions." +
+		"\n Errors: " + e.Message
+}
+
+func (e ForbiddenError) Unwrap() error {
+	return ErrForbidden
+}
+
+// permissionRe matches the permission names, e.g. "workspaces:update" or "provider-configurations:read".
+var permissionRe = regexp.MustCompile(`\b[a-z][a-z_-]*:[a-z][a-z_-]*\b`)
+
+// newForbiddenError builds a ForbiddenError from the error messages of the response,
+// looking the missing permission up in their text.
+func newForbiddenError(errs []string) ForbiddenError {
+	message := strings.Join(errs, "\n")
+	return ForbiddenError{
+		Message:           message,
+		MissingPermission: permissionRe.FindString(message),
+	}
+}
+
+type MultipleResourcesFoundError struct {
+	Message string
+}
+
+func (e MultipleResourcesFoundError) Error() string {
+	if len(e.Message) == 0 {
+		return "multiple resources found"
+	}
+	return e.Message
+}
+
+func (e MultipleResourcesFoundError) Unwrap() error {
+	return ErrMultipleResourcesFound
+}
+
 // RetryLogHook allows a function to run before each retry.
 type RetryLogHook func(attemptNum int, resp *http.Response)
 
@@ -80,8 +190,59 @@ type Config struct {
 	// A custom HTTP client to use.
 	HTTPClient *http.Client
 
-	// RetryLogHook is invoked each time a request is retried.
+	// RetryLogHook is invoked each time a request is retried. The rate limit
+	// details of the response can be read with ParseRateLimitStatus.
 	RetryLogHook RetryLogHook
+
+	// AppName and AppVersion identify the application using the client.
+	// They are appended to the User-Agent header instead of replacing it,
+	// e.g. "go-scalr/v2.3.0 myapp/1.2".
+	AppName    string
+	AppVersion string
+
+	// RateLimit is the maximum number of requests per second the client
+	// sends, retries included, shared by all the goroutines using it.
+	// Zero means no limit.
+	RateLimit float64
+
+	// RateLimitBurst is the number of requests that can be sent at once
+	// before the RateLimit applies. Defaults to 1.
+	RateLimitBurst int
+
+	// WarningHandler is invoked when a response carries Warning,
+	// Deprecation or Sunset headers, so that breaking API changes can be
+	// noticed before they happen.
+	WarningHandler WarningHandler
+
+	// UnknownFieldHandler enables the strict decoding mode: it is invoked
+	// for each attribute or relationship of a response that the SDK structs
+	// do not declare, which helps to detect drift from new server versions.
+	UnknownFieldHandler UnknownFieldHandler
+
+	// Profile sets the API profile of all the requests, overriding the Prefer
+	// header. Defaults to ProfilePreview, see ContextWithProfile to override
+	// it for a single request.
+	Profile APIProfile
+
+	// ReadTimeout and WriteTimeout limit the duration of the requests reading
+	// resources and modifying them, retries included. UploadTimeout and
+	// DownloadTimeout limit the ones transferring files instead: the state
+	// uploads, and the downloads of the states, configuration archives and
+	// logs. Zero means no limit other than the one of the HTTPClient.
+	// See ContextWithRequestTimeout to override them for a single call.
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	UploadTimeout   time.Duration
+	DownloadTimeout time.Duration
+
+	// PollInterval is how often the methods waiting for a resource, e.g.
+	// Runs.WaitForApproval or the log readers, check its status. Defaults
+	// to an interval suited to each resource, from 2 to 10 seconds.
+	PollInterval time.Duration
+
+	// MaxRetryAfter caps the wait before retrying a rate limited request,
+	// however long the API asks to wait. Defaults to DefaultMaxRetryAfter.
+	MaxRetryAfter time.Duration
 }
 
 // DefaultConfig returns a default config structure.
@@ -102,7 +263,7 @@ func DefaultConfig() *Config {
 	// Set the default user agent.
 	config.Headers.Set("User-Agent", userAgent)
 	// Set the default API Profile.
-	config.Headers.Set("Prefer", "profile=preview")
+	config.Headers.Set("Prefer", ProfilePreview.preferHeader())
 
 	return config
 }
@@ -110,12 +271,18 @@ func DefaultConfig() *Config {
 // Client is the Scalr API client. It provides the basic
 // connectivity and configuration for accessing the Scalr API.
 type Client struct {
-	baseURL           *url.URL
-	token             string
-	headers           http.Header
-	http              *retryablehttp.Client
-	retryLogHook      RetryLogHook
-	retryServerErrors bool
+	baseURL             *url.URL
+	token               string
+	headers             http.Header
+	http                *retryablehttp.Client
+	retryLogHook        RetryLogHook
+	retryServerErrors   bool
+	limiter             *rateLimiter
+	rateLimits          rateLimitTracker
+	warningHandler      WarningHandler
+	unknownFieldHandler UnknownFieldHandler
+	timeouts            requestTimeouts
+	config              Config
 
 	AccessPolicies                  AccessPolicies
 	AccessTokens                    AccessTokens
@@ -123,23 +290,29 @@ type Client struct {
 	Accounts                        Accounts
 	AgentPoolTokens                 AgentPoolTokens
 	AgentPools                      AgentPools
+	Agents                          Agents
+	Applies                         Applies
 	ConfigurationVersions           ConfigurationVersions
 	Endpoints                       Endpoints
 	EnvironmentTags                 EnvironmentTags
 	Environments                    Environments
+	Imports                         Imports
 	ModuleVersions                  ModuleVersions
 	Modules                         Modules
+	Plans                           Plans
 	PolicyGroupEnvironments         PolicyGroupEnvironments
 	PolicyGroups                    PolicyGroups
 	ProviderConfigurationLinks      ProviderConfigurationLinks
 	ProviderConfigurationParameters ProviderConfigurationParameters
 	ProviderConfigurations          ProviderConfigurations
+	Provisioner                     Provisioner
 	Roles                           Roles
 	RunTriggers                     RunTriggers
 	Runs                            Runs
 	ServiceAccountTokens            ServiceAccountTokens
 	ServiceAccounts                 ServiceAccounts
 	SlackIntegrations               SlackIntegrations
+	StateVersions                   StateVersions
 	Tags                            Tags
 	Teams                           Teams
 	Users                           Users
@@ -152,32 +325,80 @@ type Client struct {
 	Workspaces                      Workspaces
 }
 
+// merge layers in the non-blank values of cfg.
+func (c *Config) merge(cfg *Config) {
+	if cfg.Address != "" {
+		c.Address = cfg.Address
+	}
+	if cfg.BasePath != "" {
+		c.BasePath = cfg.BasePath
+	}
+	if cfg.Token != "" {
+		c.Token = cfg.Token
+	}
+	for k, v := range cfg.Headers {
+		c.Headers[k] = v
+	}
+	if cfg.HTTPClient != nil {
+		c.HTTPClient = cfg.HTTPClient
+	}
+	if cfg.RetryLogHook != nil {
+		c.RetryLogHook = cfg.RetryLogHook
+	}
+	if cfg.AppName != "" {
+		c.AppName = cfg.AppName
+	}
+	if cfg.AppVersion != "" {
+		c.AppVersion = cfg.AppVersion
+	}
+	if cfg.RateLimit != 0 {
+		c.RateLimit = cfg.RateLimit
+	}
+	if cfg.RateLimitBurst != 0 {
+		c.RateLimitBurst = cfg.RateLimitBurst
+	}
+	if cfg.WarningHandler != nil {
+		c.WarningHandler = cfg.WarningHandler
+	}
+	if cfg.UnknownFieldHandler != nil {
+		c.UnknownFieldHandler = cfg.UnknownFieldHandler
+	}
+	if cfg.Profile != "" {
+		c.Profile = cfg.Profile
+	}
+	if cfg.ReadTimeout != 0 {
+		c.ReadTimeout = cfg.ReadTimeout
+	}
+	if cfg.WriteTimeout != 0 {
+		c.WriteTimeout = cfg.WriteTimeout
+	}
+	if cfg.UploadTimeout != 0 {
+		c.UploadTimeout = cfg.UploadTimeout
+	}
+	if cfg.DownloadTimeout != 0 {
+		c.DownloadTimeout = cfg.DownloadTimeout
+	}
+	if cfg.PollInterval != 0 {
+		c.PollInterval = cfg.PollInterval
+	}
+	if cfg.MaxRetryAfter != 0 {
+		c.MaxRetryAfter = cfg.MaxRetryAfter
+	}
+}
+
 // NewClient creates a new Scalr API client.
 func NewClient(cfg *Config) (*Client, error) {
 	config := DefaultConfig()
 
 	// Layer in the provided config for any non-blank values.
 	if cfg != nil {
-		if cfg.Address != "" {
-			config.Address = cfg.Address
-		}
-		if cfg.BasePath != "" {
-			config.BasePath = cfg.BasePath
-		}
-		if cfg.Token != "" {
-			config.Token = cfg.Token
-		}
-		for k, v := range cfg.Headers {
-			config.Headers[k] = v
-		}
-		if cfg.HTTPClient != nil {
-			config.HTTPClient = cfg.HTTPClient
-		}
-		if cfg.RetryLogHook != nil {
-			config.RetryLogHook = cfg.RetryLogHook
-		}
+		config.merge(cfg)
 	}
 
+	// Keep the config to derive new clients from it.
+	layered := *config
+	layered.Headers = config.Headers.Clone()
+
 	// Parse the address to make sure its a valid URL.
 	baseURL, err := url.Parse(config.Address)
 	if err != nil {
@@ -197,19 +418,62 @@ func NewClient(cfg *Config) (*Client, error) {
 		return nil, fmt.Errorf("missing API token")
 	}
 
+	if config.AppName != "" {
+		product := config.AppName
+		if config.AppVersion != "" {
+			product += "/" + config.AppVersion
+		}
+		config.Headers.Set("User-Agent", config.Headers.Get("User-Agent")+" "+product)
+	}
+
+	if config.Profile != "" {
+		config.Headers.Set("Prefer", config.Profile.preferHeader())
+	}
+
+	if config.RateLimit < 0 {
+		return nil, fmt.Errorf("invalid rate limit: %v", config.RateLimit)
+	}
+
+	if config.PollInterval < 0 {
+		return nil, fmt.Errorf("invalid poll interval: %v", config.PollInterval)
+	}
+	if config.MaxRetryAfter < 0 {
+		return nil, fmt.Errorf("invalid max retry after: %v", config.MaxRetryAfter)
+	}
+
+	timeouts := requestTimeouts{
+		read:     config.ReadTimeout,
+		write:    config.WriteTimeout,
+		upload:   config.UploadTimeout,
+		download: config.DownloadTimeout,
+	}
+	if err := timeouts.valid(); err != nil {
+		return nil, err
+	}
+
 	// Create the client.
 	client := &Client{
-		baseURL:      baseURL,
-		token:        config.Token,
-		headers:      config.Headers,
-		retryLogHook: config.RetryLogHook,
+		baseURL:             baseURL,
+		token:               config.Token,
+		headers:             config.Headers,
+		retryLogHook:        config.RetryLogHook,
+		warningHandler:      config.WarningHandler,
+		unknownFieldHandler: config.UnknownFieldHandler,
+		timeouts:            timeouts,
+		config:              layered,
+	}
+	// Every attempt of a request waits for the rate limiter, if configured.
+	httpClient := config.HTTPClient
+	if config.RateLimit > 0 {
+		client.limiter = newRateLimiter(config.RateLimit, config.RateLimitBurst)
+		httpClient = rateLimitedClient(httpClient, client.limiter)
 	}
 
 	client.http = &retryablehttp.Client{
-		Backoff:      retryablehttp.DefaultBackoff,
+		Backoff:      client.retryHTTPBackoff,
 		CheckRetry:   client.retryHTTPCheck,
 		ErrorHandler: retryablehttp.PassthroughErrorHandler,
-		HTTPClient:   config.HTTPClient,
+		HTTPClient:   httpClient,
 		RetryWaitMin: 100 * time.Millisecond,
 		RetryWaitMax: 400 * time.Millisecond,
 		RetryMax:     30,
@@ -222,23 +486,29 @@ func NewClient(cfg *Config) (*Client, error) {
 	client.Accounts = &accounts{client: client}
 	client.AgentPoolTokens = &agentPoolTokens{client: client}
 	client.AgentPools = &agentPools{client: client}
+	client.Agents = &agents{client: client}
+	client.Applies = &applies{client: client}
 	client.ConfigurationVersions = &configurationVersions{client: client}
 	client.Endpoints = &endpoints{client: client}
 	client.EnvironmentTags = &environmentTag{client: client}
 	client.Environments = &environments{client: client}
+	client.Imports = &imports{client: client}
 	client.ModuleVersions = &moduleVersions{client: client}
 	client.Modules = &modules{client: client}
+	client.Plans = &plans{client: client}
 	client.PolicyGroupEnvironments = &policyGroupEnvironment{client: client}
 	client.PolicyGroups = &policyGroups{client: client}
 	client.ProviderConfigurationLinks = &providerConfigurationLinks{client: client}
 	client.ProviderConfigurationParameters = &providerConfigurationParameters{client: client}
 	client.ProviderConfigurations = &providerConfigurations{client: client}
+	client.Provisioner = &provisioner{client: client}
 	client.Roles = &roles{client: client}
 	client.RunTriggers = &runTriggers{client: client}
 	client.Runs = &runs{client: client}
 	client.ServiceAccountTokens = &serviceAccountTokens{client: client}
 	client.ServiceAccounts = &serviceAccounts{client: client}
 	client.SlackIntegrations = &slackIntegrations{client: client}
+	client.StateVersions = &stateVersions{client: client}
 	client.Tags = &tags{client: client}
 	client.Teams = &teams{client: client}
 	client.Users = &users{client: client}
@@ -252,6 +522,33 @@ func NewClient(cfg *Config) (*Client, error) {
 	return client, nil
 }
 
+// BaseURL returns a copy of the URL the client sends the API requests to.
+func (c *Client) BaseURL() url.URL {
+	return *c.baseURL
+}
+
+// WithConfig returns a new client with the settings of this one, overridden
+// by the non-blank values of partial, e.g. a different token or headers.
+// Unless partial sets a HTTPClient, the new client shares the HTTP client and
+// its connection pool with this one. The rate limit is not shared.
+func (c *Client) WithConfig(partial Config) (*Client, error) {
+	cfg := c.config
+	cfg.Headers = c.config.Headers.Clone()
+	cfg.merge(&partial)
+
+	client, err := NewClient(&cfg)
+	if err != nil {
+		return nil, err
+	}
+	client.retryServerErrors = c.retryServerErrors
+	return client, nil
+}
+
+// Token returns the API token the client authenticates with.
+func (c *Client) Token() string {
+	return c.token
+}
+
 // RetryServerErrors configures the retry HTTP check to also retry
 // unexpected errors or requests that failed with a server error.
 func (c *Client) RetryServerErrors(retry bool) {
@@ -314,7 +611,7 @@ func (c *Client) newRequest(method, path string, v interface{}) (*retryablehttp.
 
 		if v != nil {
 			buf := bytes.NewBuffer(nil)
-			if err := jsonapi.MarshalPayloadWithoutIncluded(buf, v); err != nil {
+			if err := marshalPayload(buf, v); err != nil {
 				return nil, err
 			}
 			body = buf
@@ -383,9 +680,26 @@ func (c *Client) createRequest(method, url string, rawBody interface{}, reqHeade
 // The provided ctx must be non-nil. If it is canceled or times out, ctx.Err()
 // will be returned.
 func (c *Client) do(ctx context.Context, req *retryablehttp.Request, v interface{}) error {
+	// Limit the duration of the request, if configured.
+	if timeout := c.requestTimeout(ctx, req.Method); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
 	// Add the context to the request.
 	req = req.WithContext(ctx)
 
+	// Override the API profile for this request, if requested.
+	if profile, ok := ctx.Value(profileContextKey{}).(APIProfile); ok && profile != "" {
+		req.Header.Set("Prefer", profile.preferHeader())
+	}
+
+	// Tag the request with the metadata of the caller, if any.
+	if tag, ok := RequestTag(ctx); ok {
+		req.Header.Set(RequestTagHeader, tag)
+	}
+
 	// Execute the request and check the response.
 	resp, err := c.http.Do(req)
 	if err != nil {
@@ -400,6 +714,16 @@ func (c *Client) do(ctx context.Context, req *retryablehttp.Request, v interface
 	}
 	defer resp.Body.Close()
 
+	// Keep the remaining API quota.
+	c.rateLimits.update(resp)
+
+	// Report the deprecation notices and warnings, if any.
+	if c.warningHandler != nil {
+		if w := responseWarning(resp); w != nil {
+			c.warningHandler(w)
+		}
+	}
+
 	// Basic response checking.
 	if err := checkResponseCode(resp); err != nil {
 		return err
@@ -428,10 +752,26 @@ func (c *Client) do(ctx context.Context, req *retryablehttp.Request, v interface
 	items := dst.FieldByName("Items")
 	pagination := dst.FieldByName("Pagination")
 
+	// In the strict decoding mode, read the whole body to
+	// report the fields that are going to be dropped.
+	var respBody io.Reader = resp.Body
+	if c.unknownFieldHandler != nil {
+		raw, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		t := dst.Type()
+		if items.IsValid() && pagination.IsValid() {
+			t = items.Type()
+		}
+		c.reportUnknownFields(resp.Request, raw, t)
+		respBody = bytes.NewReader(raw)
+	}
+
 	// Unmarshal a single value if v does not contain the
 	// Items and Pagination struct fields.
 	if !items.IsValid() || !pagination.IsValid() {
-		return jsonapi.UnmarshalPayload(resp.Body, v)
+		return jsonapi.UnmarshalPayload(respBody, v)
 	}
 
 	// Return an error if v.Items is not a slice.
@@ -441,7 +781,7 @@ func (c *Client) do(ctx context.Context, req *retryablehttp.Request, v interface
 
 	// Create a temporary buffer and copy all the read data into it.
 	body := bytes.NewBuffer(nil)
-	reader := io.TeeReader(resp.Body, body)
+	reader := io.TeeReader(respBody, body)
 
 	// Unmarshal as a list of values as v.Items is a slice.
 	raw, err := jsonapi.UnmarshalManyPayload(reader, items.Type().Elem())
@@ -534,6 +874,10 @@ func checkResponseCode(r *http.Response) error {
 	if err != nil || len(errPayload.Errors) == 0 {
 		if r.StatusCode == 404 {
 			return ResourceNotFoundError{}
+		} else if r.StatusCode == 409 {
+			return ResourceConflictError{Message: r.Status}
+		} else if r.StatusCode == 403 {
+			return ForbiddenError{Message: r.Status}
 		} else {
 			return fmt.Errorf(r.Status)
 		}
@@ -555,14 +899,14 @@ func checkResponseCode(r *http.Response) error {
 		}
 	}
 
+	if r.StatusCode == 409 {
+		return ResourceConflictError{
+			Message: strings.Join(errs, "\n"),
+		}
+	}
+
 	if r.StatusCode == 403 {
-		return fmt.Errorf(
-			"The Scalr Terraform provider has been configured with an access token that lacks sufficient permissions." +
-				" If you are running remotely, follow the documentation (https://docs.scalr.io/docs/scalr) on how to " +
-				"enable the Scalr provider configuration in the remote workspace. " +
-				"If running locally, ensure you have enough permissions to perform actions." +
-				"\n Errors: " + strings.Join(errs, "\n"),
-		)
+		return newForbiddenError(errs)
 	}
 
 	return fmt.Errorf(strings.Join(errs, "\n"))
@@ -5,12 +5,15 @@ import (
 	"context"
 	"errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"runtime/debug"
 	"testing"
+	"time"
 )
 
 func TestClient_newClient(t *testing.T) {
@@ -70,9 +73,104 @@ func TestClient_newClient(t *testing.T) {
 		if ts.Client() != client.http.HTTPClient {
 			t.Fatal("unexpected HTTP client value")
 		}
+		if client.limiter != nil {
+			t.Fatal("unexpected rate limiter")
+		}
+	})
+
+	t.Run("makes a new client with a rate limit", func(t *testing.T) {
+		config := &Config{
+			Address:   ts.URL,
+			Token:     "abcd1234",
+			RateLimit: 5,
+		}
+
+		client, err := NewClient(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if client.limiter == nil || client.limiter.rate != 5 || client.limiter.burst != 1 {
+			t.Fatalf("unexpected rate limiter %+v", client.limiter)
+		}
+	})
+
+	t.Run("fails if rate limit is negative", func(t *testing.T) {
+		_, err := NewClient(&Config{Address: ts.URL, Token: "abcd1234", RateLimit: -1})
+		if err == nil || err.Error() != "invalid rate limit: -1" {
+			t.Fatalf("unexpected error: %v", err)
+		}
 	})
 }
 
+func TestClient_accessors(t *testing.T) {
+	client, err := NewClient(&Config{Address: "https://example.scalr.io", Token: "abcd1234"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.Token() != "abcd1234" {
+		t.Fatalf("unexpected token: %q", client.Token())
+	}
+
+	baseURL := client.BaseURL()
+	if baseURL.String() != "https://example.scalr.io"+DefaultBasePath {
+		t.Fatalf("unexpected base URL: %q", baseURL.String())
+	}
+
+	// Changing the returned URL must not affect the client.
+	baseURL.Host = "other.scalr.io"
+	if client.BaseURL().Host != "example.scalr.io" {
+		t.Fatalf("unexpected host: %q", client.BaseURL().Host)
+	}
+}
+
+func TestClient_withConfig(t *testing.T) {
+	var headers http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{
+		Address:    ts.URL,
+		Token:      "abcd1234",
+		HTTPClient: ts.Client(),
+		AppName:    "myapp",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clone, err := client.WithConfig(Config{
+		Token:   "efgh5678",
+		Headers: http.Header{"Prefer": []string{"profile=internal"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clone.Token() != "efgh5678" || client.Token() != "abcd1234" {
+		t.Fatalf("unexpected tokens: %q, %q", clone.Token(), client.Token())
+	}
+	if clone.http.HTTPClient != client.http.HTTPClient {
+		t.Fatal("expected the HTTP client to be shared")
+	}
+
+	_, _ = clone.Environments.Read(context.Background(), "environmentID")
+	if headers.Get("Authorization") != "Bearer efgh5678" {
+		t.Fatalf("unexpected authorization header: %q", headers.Get("Authorization"))
+	}
+	if headers.Get("Prefer") != "profile=internal" {
+		t.Fatalf("unexpected prefer header: %q", headers.Get("Prefer"))
+	}
+	if headers.Get("User-Agent") != "go-scalr myapp" {
+		t.Fatalf("unexpected user agent header: %q", headers.Get("User-Agent"))
+	}
+
+	_, _ = client.Environments.Read(context.Background(), "environmentID")
+	if headers.Get("Prefer") != "profile=preview" {
+		t.Fatalf("unexpected prefer header of the original client: %q", headers.Get("Prefer"))
+	}
+}
+
 func TestClient_defaultConfig(t *testing.T) {
 	t.Run("with no environment variables", func(t *testing.T) {
 		defer setupEnvVars("", "")()
@@ -169,6 +267,288 @@ func TestClient_userAgent(t *testing.T) {
 
 }
 
+func TestClient_appUserAgent(t *testing.T) {
+	var userAgent string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+	}))
+	defer ts.Close()
+
+	cases := []struct {
+		name     string
+		cfg      *Config
+		expected string
+	}{
+		{"with app name and version", &Config{AppName: "myapp", AppVersion: "1.2"}, "go-scalr myapp/1.2"},
+		{"with app name only", &Config{AppName: "myapp"}, "go-scalr myapp"},
+		{"with custom user agent", &Config{
+			AppName: "myapp", Headers: http.Header{"User-Agent": []string{"go-scalr-tester"}},
+		}, "go-scalr-tester myapp"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			c.cfg.Address = ts.URL
+			c.cfg.Token = "dummy-token"
+			c.cfg.HTTPClient = ts.Client()
+
+			client, err := NewClient(c.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, _ = client.Environments.Read(context.Background(), "environmentID")
+			if userAgent != c.expected {
+				t.Fatalf("unexpected user agent header: %q", userAgent)
+			}
+		})
+	}
+}
+
+func TestModuleUserAgent(t *testing.T) {
+	path := "github.com/mermoldy/go-scalr/v2"
+	cases := []struct {
+		name     string
+		info     *debug.BuildInfo
+		expected string
+	}{
+		{"as dependency", &debug.BuildInfo{
+			Main: debug.Module{Path: "example.com/app", Version: "(devel)"},
+			Deps: []*debug.Module{{Path: path, Version: "v2.3.0"}},
+		}, "go-scalr/v2.3.0"},
+		{"as replaced dependency", &debug.BuildInfo{
+			Main: debug.Module{Path: "example.com/app"},
+			Deps: []*debug.Module{{Path: path, Version: "v2.3.0", Replace: &debug.Module{Version: "v2.3.1"}}},
+		}, "go-scalr/v2.3.1"},
+		{"as main module", &debug.BuildInfo{Main: debug.Module{Path: path, Version: "(devel)"}}, "go-scalr"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, moduleUserAgent(c.info, true))
+		})
+	}
+	assert.Equal(t, "go-scalr", moduleUserAgent(nil, false))
+}
+
+func TestClient_profile(t *testing.T) {
+	var prefer string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		prefer = r.Header.Get("Prefer")
+	}))
+	defer ts.Close()
+
+	cases := []struct {
+		name     string
+		cfg      *Config
+		ctx      context.Context
+		expected string
+	}{
+		{"default", &Config{}, context.Background(), "profile=preview"},
+		{"with profile", &Config{Profile: ProfileInternal}, context.Background(), "profile=internal"},
+		{"profile over header", &Config{
+			Profile: ProfilePreview, Headers: http.Header{"Prefer": []string{"profile=internal"}},
+		}, context.Background(), "profile=preview"},
+		{"per request profile", &Config{}, ContextWithProfile(context.Background(), ProfileInternal), "profile=internal"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			c.cfg.Address = ts.URL
+			c.cfg.Token = "dummy-token"
+			c.cfg.HTTPClient = ts.Client()
+
+			client, err := NewClient(c.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, _ = client.Environments.Read(c.ctx, "environmentID")
+			if prefer != c.expected {
+				t.Fatalf("unexpected prefer header: %q", prefer)
+			}
+
+			// The per-request profile must not leak into the client headers.
+			_, _ = client.Environments.Read(context.Background(), "environmentID")
+			if c.cfg.Profile == "" && prefer != "profile=preview" {
+				t.Fatalf("unexpected prefer header of the next request: %q", prefer)
+			}
+		})
+	}
+}
+
+func TestClient_requestTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(200 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{
+		Address:       ts.URL,
+		Token:         "dummy-token",
+		HTTPClient:    ts.Client(),
+		ReadTimeout:   50 * time.Millisecond,
+		UploadTimeout: 50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("read timeout", func(t *testing.T) {
+		_, err := client.Environments.Read(ctx, "env-1")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("write without timeout", func(t *testing.T) {
+		err := client.Environments.Delete(ctx, "env-1")
+		assert.NoError(t, err)
+	})
+
+	t.Run("download without timeout", func(t *testing.T) {
+		_, err := client.StateVersions.Download(ctx, "sv-1")
+		assert.NoError(t, err)
+	})
+
+	t.Run("upload timeout", func(t *testing.T) {
+		options, err := NewStateVersionCreateOptions("ws-1", []byte(`{"serial": 1}`))
+		require.NoError(t, err)
+		_, err = client.StateVersions.Create(ctx, options)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("per request timeout", func(t *testing.T) {
+		err := client.Environments.Delete(ContextWithRequestTimeout(ctx, 50*time.Millisecond), "env-1")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("invalid timeout", func(t *testing.T) {
+		_, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", UploadTimeout: -time.Second})
+		assert.EqualError(t, err, "invalid upload timeout: -1s")
+	})
+}
+
+func TestClient_requestTag(t *testing.T) {
+	var tags []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tags = append(tags, r.Header.Get(RequestTagHeader))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := WithRequestTag(context.Background(), "reconcile-loop-7")
+
+	require.NoError(t, client.Environments.Delete(ctx, "env-1"))
+	require.NoError(t, client.Environments.Delete(WithRequestTag(ctx, "job\r\n42"), "env-1"))
+	require.NoError(t, client.Environments.Delete(context.Background(), "env-1"))
+	assert.Equal(t, []string{"reconcile-loop-7", "job  42", ""}, tags)
+
+	tag, ok := RequestTag(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "reconcile-loop-7", tag)
+	_, ok = RequestTag(context.Background())
+	assert.False(t, ok)
+}
+
+func TestClient_warningHandler(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/iacp/v3/environments/env-deprecated" {
+			w.Header().Add("Warning", `299 - "The environments endpoint is deprecated"`)
+			w.Header().Set("Deprecation", "true")
+			w.Header().Set("Sunset", "Wed, 01 Jan 2025 00:00:00 GMT")
+			w.Header().Set("Link", `<https://docs.scalr.io/changelog>; rel="deprecation"`)
+		}
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		_, _ = w.Write([]byte(`{"data":{"type":"environments","id":"env-1"}}`))
+	}))
+	defer ts.Close()
+
+	var warnings []*APIWarning
+	client, err := NewClient(&Config{
+		Address:    ts.URL,
+		Token:      "dummy-token",
+		HTTPClient: ts.Client(),
+		WarningHandler: func(w *APIWarning) {
+			warnings = append(warnings, w)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Environments.Read(context.Background(), "env-current")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) != 0 {
+		t.Fatalf("unexpected warnings: %v", warnings)
+	}
+
+	_, err = client.Environments.Read(context.Background(), "env-deprecated")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) != 1 {
+		t.Fatalf("expected 1 warning, got %d", len(warnings))
+	}
+
+	w := warnings[0]
+	if w.Method != "GET" || w.Path != "/api/iacp/v3/environments/env-deprecated" {
+		t.Fatalf("unexpected request: %s %s", w.Method, w.Path)
+	}
+	if len(w.Messages) != 1 || w.Messages[0] != "The environments endpoint is deprecated" {
+		t.Fatalf("unexpected messages: %v", w.Messages)
+	}
+	if !w.Deprecated {
+		t.Fatal("expected the endpoint to be deprecated")
+	}
+	if !w.Sunset.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected sunset: %v", w.Sunset)
+	}
+	if w.Link != "https://docs.scalr.io/changelog" {
+		t.Fatalf("unexpected link: %q", w.Link)
+	}
+}
+
+func TestClient_unknownFieldHandler(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		_, _ = w.Write([]byte(`{"data":{"type":"environments","id":"env-1",` +
+			`"attributes":{"name":"dev","brand-new-attr":true},` +
+			`"relationships":{"account":{"data":{"type":"accounts","id":"acc-1"}},"brand-new-rel":{"data":null}}},` +
+			`"included":[{"type":"accounts","id":"acc-1","attributes":{"name":"main","another-attr":1}}]}`))
+	}))
+	defer ts.Close()
+
+	var fields []UnknownField
+	client, err := NewClient(&Config{
+		Address:    ts.URL,
+		Token:      "dummy-token",
+		HTTPClient: ts.Client(),
+		UnknownFieldHandler: func(f *UnknownField) {
+			fields = append(fields, *f)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env, err := client.Environments.Read(context.Background(), "env-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if env.Name != "dev" || env.Account == nil || env.Account.Name != "main" {
+		t.Fatalf("unexpected environment: %+v", env)
+	}
+
+	path := "/api/iacp/v3/environments/env-1"
+	assert.ElementsMatch(t, []UnknownField{
+		{Method: "GET", Path: path, ResourceType: "environments", Name: "brand-new-attr"},
+		{Method: "GET", Path: path, ResourceType: "environments", Name: "brand-new-rel", Relationship: true},
+		{Method: "GET", Path: path, ResourceType: "accounts", Name: "another-attr"},
+	}, fields)
+}
+
 func TestClient_retryHTTPCheck(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/vnd.api+json")
@@ -286,6 +666,28 @@ func TestClient_errorWithoutMessage(t *testing.T) {
 	}
 }
 
+func TestClient_forbiddenError(t *testing.T) {
+	body := `{"errors":[{"status":"403","title":"Forbidden",` +
+		`"detail":"You do not have permission 'workspaces:update' to perform this action."}]}`
+	resp := &http.Response{StatusCode: 403, Body: ioutil.NopCloser(bytes.NewBufferString(body))}
+
+	err := checkResponseCode(resp)
+	assert.ErrorIs(t, err, ErrForbidden)
+
+	var forbidden ForbiddenError
+	require.True(t, errors.As(err, &forbidden))
+	assert.Equal(t, "workspaces:update", forbidden.MissingPermission)
+	assert.Contains(t, err.Error(), "lacks sufficient permissions")
+
+	resp = &http.Response{
+		StatusCode: 403,
+		Status:     "403 Forbidden",
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`{"errors":[{"title":"Forbidden"}]}`)),
+	}
+	require.True(t, errors.As(checkResponseCode(resp), &forbidden))
+	assert.Empty(t, forbidden.MissingPermission)
+}
+
 func setupEnvVars(token, address string) func() {
 	origToken := os.Getenv("SCALR_TOKEN")
 	origAddress := os.Getenv("SCALR_ADDRESS")
@@ -0,0 +1,218 @@
+// Package scalrtest provides a fake Scalr API server simulating the run
+// lifecycle, so the code polling and approving runs can be tested
+// deterministically without a live backend.
+package scalrtest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	scalr "github.com/mermoldy/go-scalr/v2"
+	"github.com/svanharmelen/jsonapi"
+)
+
+// Lifecycles of the runs, the last status of each is final.
+var (
+	// ApplyLifecycle is the lifecycle of a run that is applied after the approval.
+	ApplyLifecycle = []scalr.RunStatus{
+		scalr.RunPending, scalr.RunPlanQueued, scalr.RunPlanning, scalr.RunPlanned,
+		scalr.RunApplyQueued, scalr.RunApplying, scalr.RunApplied,
+	}
+
+	// PolicyCheckLifecycle is the lifecycle of a run that is cost estimated and policy
+	// checked before it waits for the approval, the run has a cost estimate and a policy check.
+	PolicyCheckLifecycle = []scalr.RunStatus{
+		scalr.RunPending, scalr.RunPlanQueued, scalr.RunPlanning, scalr.RunPlanned,
+		scalr.RunCostEstimating, scalr.RunCostEstimated, scalr.RunPolicyChecking, scalr.RunPolicyChecked,
+		scalr.RunApplyQueued, scalr.RunApplying, scalr.RunApplied,
+	}
+
+	// PlanOnlyLifecycle is the lifecycle of a run that has no changes to apply.
+	PlanOnlyLifecycle = []scalr.RunStatus{
+		scalr.RunPending, scalr.RunPlanQueued, scalr.RunPlanning, scalr.RunPlannedAndFinished,
+	}
+
+	// ErroredLifecycle is the lifecycle of a run that fails to plan.
+	ErroredLifecycle = []scalr.RunStatus{
+		scalr.RunPending, scalr.RunPlanQueued, scalr.RunPlanning, scalr.RunErrored,
+	}
+)
+
+// Server is a fake Scalr API server serving the simulated runs.
+//
+// Every read of a run returns its current status and moves the run to the next
+// status of its lifecycle, so the transitions follow the polling of the code under
+// test rather than the wall clock. A run stops at the status where it waits for
+// approval, see scalr.Run.IsWaitingForApproval, until it is applied, and the
+// discard and cancel actions end the run. The runs going through the cost
+// estimation or policy checks have a cost estimate or a policy check.
+type Server struct {
+	*httptest.Server
+
+	// OnTransition, if set, is called on every status change of a run, e.g. to
+	// deliver webhook notifications. It is called while the server is locked,
+	// so it must not send requests to the server.
+	OnTransition func(run *scalr.Run, from scalr.RunStatus)
+
+	mu   sync.Mutex
+	runs map[string]*simulatedRun
+}
+
+type simulatedRun struct {
+	run       *scalr.Run
+	lifecycle []scalr.RunStatus
+	step      int
+}
+
+// NewServer starts a new fake server, it must be closed when the test ends.
+func NewServer() *Server {
+	s := &Server{runs: make(map[string]*simulatedRun)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// NewClient returns a client sending the requests to the server, polling the
+// simulated runs every millisecond.
+func (s *Server) NewClient() (*scalr.Client, error) {
+	return scalr.NewClient(&scalr.Config{
+		Address:      s.URL,
+		Token:        "scalrtest-token",
+		HTTPClient:   s.Client(),
+		PollInterval: time.Millisecond,
+	})
+}
+
+// AddRun adds a run going through the lifecycle, starting at its first status.
+func (s *Server) AddRun(runID string, lifecycle ...scalr.RunStatus) *scalr.Run {
+	if len(lifecycle) == 0 {
+		lifecycle = ApplyLifecycle
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	run := &scalr.Run{
+		ID:        runID,
+		Status:    lifecycle[0],
+		CreatedAt: time.Now().UTC(),
+		Plan:      &scalr.Plan{ID: "plan-" + runID},
+	}
+	for _, status := range lifecycle {
+		switch status {
+		case scalr.RunCostEstimating:
+			run.CostEstimate = &scalr.CostEstimate{ID: "ce-" + runID}
+		case scalr.RunPolicyChecking:
+			run.PolicyChecks = []*scalr.PolicyCheck{{ID: "pc-" + runID}}
+		}
+	}
+	s.runs[runID] = &simulatedRun{run: run, lifecycle: lifecycle}
+	return run
+}
+
+// Status returns the current status of the run, or an empty one if the run does not exist.
+func (s *Server) Status(runID string) scalr.RunStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r, ok := s.runs[runID]; ok {
+		return r.run.Status
+	}
+	return ""
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/iacp/v3/")
+	parts := strings.Split(path, "/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(parts) < 2 || parts[0] != "runs" {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Endpoint %s is not simulated", r.URL.Path))
+		return
+	}
+	sr, ok := s.runs[parts[1]]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Run with ID '%s' not found or user unauthorized", parts[1]))
+		return
+	}
+
+	switch {
+	case r.Method == "GET" && len(parts) == 2:
+		run := *sr.run
+		s.advance(sr)
+		writeRun(w, &run)
+	case r.Method == "POST" && len(parts) == 4 && parts[2] == "actions":
+		if err := s.action(sr, parts[3]); err != "" {
+			writeError(w, http.StatusConflict, err)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Endpoint %s %s is not simulated", r.Method, r.URL.Path))
+	}
+}
+
+// advance moves the run to the next status of its lifecycle, unless it waits for approval.
+func (s *Server) advance(sr *simulatedRun) {
+	if sr.run.IsFinal() || sr.step == len(sr.lifecycle)-1 || sr.run.IsWaitingForApproval() {
+		return
+	}
+	sr.step++
+	s.transition(sr, sr.lifecycle[sr.step])
+}
+
+// action applies the run action and returns the conflict message if it is not allowed.
+func (s *Server) action(sr *simulatedRun, action string) string {
+	switch action {
+	case "apply":
+		if !sr.run.IsWaitingForApproval() || sr.step == len(sr.lifecycle)-1 {
+			return fmt.Sprintf("Run in status '%s' cannot be applied.", sr.run.Status)
+		}
+		sr.step++
+		s.transition(sr, sr.lifecycle[sr.step])
+	case "discard":
+		if !sr.run.IsWaitingForApproval() {
+			return fmt.Sprintf("Run in status '%s' cannot be discarded.", sr.run.Status)
+		}
+		s.transition(sr, scalr.RunDiscarded)
+	case "cancel", "force-cancel":
+		if sr.run.IsFinal() {
+			return fmt.Sprintf("Run in status '%s' cannot be canceled.", sr.run.Status)
+		}
+		s.transition(sr, scalr.RunCanceled)
+	default:
+		return fmt.Sprintf("Action '%s' is not simulated.", action)
+	}
+	return ""
+}
+
+func (s *Server) transition(sr *simulatedRun, to scalr.RunStatus) {
+	from := sr.run.Status
+	sr.run.Status = to
+	if s.OnTransition != nil {
+		run := *sr.run
+		s.OnTransition(&run, from)
+	}
+}
+
+func writeRun(w http.ResponseWriter, run *scalr.Run) {
+	w.Header().Set("Content-Type", "application/vnd.api+json")
+	if err := jsonapi.MarshalPayload(w, run); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, detail string) {
+	w.Header().Set("Content-Type", "application/vnd.api+json")
+	w.WriteHeader(status)
+	_ = jsonapi.MarshalErrors(w, []*jsonapi.ErrorObject{{
+		Status: fmt.Sprint(status),
+		Title:  http.StatusText(status),
+		Detail: detail,
+	}})
+}
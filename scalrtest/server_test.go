@@ -0,0 +1,91 @@
+package scalrtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	scalr "github.com/mermoldy/go-scalr/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer(t *testing.T) {
+	ts := NewServer()
+	defer ts.Close()
+
+	var transitions []scalr.RunStatus
+	ts.OnTransition = func(run *scalr.Run, from scalr.RunStatus) {
+		if run.ID == "run-apply" {
+			transitions = append(transitions, run.Status)
+		}
+	}
+
+	client, err := ts.NewClient()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("apply after approval", func(t *testing.T) {
+		ts.AddRun("run-apply", ApplyLifecycle...)
+
+		r, err := client.Runs.WaitForApproval(ctx, "run-apply", time.Second, nil)
+		require.NoError(t, err)
+		assert.Equal(t, scalr.RunPlanned, r.Status)
+
+		// The run waits for the approval however many times it is polled.
+		r, err = client.Runs.Read(ctx, "run-apply")
+		require.NoError(t, err)
+		assert.Equal(t, scalr.RunPlanned, r.Status)
+
+		require.NoError(t, client.Runs.Apply(ctx, "run-apply", ""))
+		for !r.IsFinal() {
+			r, err = client.Runs.Read(ctx, "run-apply")
+			require.NoError(t, err)
+		}
+		assert.Equal(t, scalr.RunApplied, r.Status)
+		assert.Equal(t, ApplyLifecycle[1:], transitions)
+	})
+
+	t.Run("apply after policy checks", func(t *testing.T) {
+		ts.AddRun("run-policy", PolicyCheckLifecycle...)
+
+		r, err := client.Runs.WaitForApproval(ctx, "run-policy", time.Second, nil)
+		require.NoError(t, err)
+		assert.Equal(t, scalr.RunPolicyChecked, r.Status)
+
+		ok, err := client.Runs.ApplyIfNonDestructive(ctx, "run-policy")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, scalr.RunApplyQueued, ts.Status("run-policy"))
+	})
+
+	t.Run("discard", func(t *testing.T) {
+		ts.AddRun("run-discard")
+		_, err := client.Runs.WaitForApproval(ctx, "run-discard", time.Second, nil)
+		require.NoError(t, err)
+
+		require.NoError(t, client.Runs.Discard(ctx, "run-discard", ""))
+		assert.Equal(t, scalr.RunDiscarded, ts.Status("run-discard"))
+
+		err = client.Runs.Apply(ctx, "run-discard", "")
+		assert.ErrorIs(t, err, scalr.ErrInvalidRunTransition)
+	})
+
+	t.Run("finished without approval", func(t *testing.T) {
+		ts.AddRun("run-errored", ErroredLifecycle...)
+		_, err := client.Runs.WaitForApproval(ctx, "run-errored", time.Second, nil)
+		assert.EqualError(t, err, "run run-errored finished with status errored without requiring approval")
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		ts.AddRun("run-cancel", PlanOnlyLifecycle...)
+		r, err := client.Runs.CancelWithOptions(ctx, "run-cancel", scalr.RunCancelOptions{Wait: time.Second})
+		require.NoError(t, err)
+		assert.Equal(t, scalr.RunCanceled, r.Status)
+	})
+
+	t.Run("unknown run", func(t *testing.T) {
+		_, err := client.Runs.Read(ctx, "run-unknown")
+		assert.ErrorIs(t, err, scalr.ErrResourceNotFound)
+	})
+}
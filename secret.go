@@ -0,0 +1,89 @@
+package scalr
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/svanharmelen/jsonapi"
+)
+
+// redactedSecret replaces the value of a Secret when it is formatted or encoded.
+const redactedSecret = "<redacted>"
+
+// Secret is a sensitive value, such as a token or a cloud credential. It is
+// redacted when formatted or encoded to JSON so it does not leak into logs,
+// while the client still sends the actual value to the API. Use Reveal to
+// read the value.
+type Secret string
+
+// SecretString returns a pointer to the given secret value.
+func SecretString(v string) *Secret {
+	s := Secret(v)
+	return &s
+}
+
+// Reveal returns the actual value of the secret.
+func (s Secret) Reveal() string {
+	return string(s)
+}
+
+// String returns the redacted value, or an empty string if the secret is empty.
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return redactedSecret
+}
+
+// GoString implements fmt.GoStringer, so the %#v verb does not reveal the secret either.
+func (s Secret) GoString() string {
+	return `"` + s.String() + `"`
+}
+
+// MarshalJSON encodes the redacted value.
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// secretHolder is implemented by the attribute structs with secret fields,
+// revealSecrets returns the attribute value to send to the API, or nil.
+type secretHolder interface {
+	revealSecrets() interface{}
+}
+
+// marshalPayload encodes the JSON:API request payload of the model, like
+// jsonapi.MarshalPayloadWithoutIncluded, revealing the secret attributes.
+func marshalPayload(w io.Writer, model interface{}) error {
+	payload, err := jsonapi.Marshal(model)
+	if err != nil {
+		return err
+	}
+
+	var nodes []*jsonapi.Node
+	switch p := payload.(type) {
+	case *jsonapi.OnePayload:
+		p.Included = nil
+		if p.Data != nil {
+			nodes = append(nodes, p.Data)
+		}
+	case *jsonapi.ManyPayload:
+		p.Included = nil
+		nodes = p.Data
+	}
+	for _, node := range nodes {
+		for name, value := range node.Attributes {
+			switch v := value.(type) {
+			case Secret:
+				node.Attributes[name] = v.Reveal()
+			case *Secret:
+				if v != nil {
+					node.Attributes[name] = v.Reveal()
+				}
+			case secretHolder:
+				node.Attributes[name] = v.revealSecrets()
+			}
+		}
+	}
+
+	return json.NewEncoder(w).Encode(payload)
+}
@@ -0,0 +1,111 @@
+package scalr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecret(t *testing.T) {
+	t.Run("is redacted", func(t *testing.T) {
+		opts := ProviderConfigurationCreateOptions{ScalrToken: SecretString("s3cr3t")}
+		pcfg := ProviderConfiguration{ID: "pcfg-1", ScalrToken: "s3cr3t"}
+
+		assert.Equal(t, "<redacted>", opts.ScalrToken.String())
+		assert.NotContains(t, fmt.Sprintf("%v %+v %#v", pcfg, pcfg, pcfg), "s3cr3t")
+		assert.NotContains(t, fmt.Sprintf("%s", opts.ScalrToken), "s3cr3t")
+
+		b, err := json.Marshal(pcfg)
+		require.NoError(t, err)
+		assert.NotContains(t, string(b), "s3cr3t")
+		assert.Equal(t, "s3cr3t", pcfg.ScalrToken.Reveal())
+	})
+
+	t.Run("empty is not redacted", func(t *testing.T) {
+		assert.Equal(t, "", Secret("").String())
+	})
+
+	t.Run("is sent to the API", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(body), `"token":"s3cr3t"`)
+			assert.NotContains(t, string(body), "redacted")
+
+			w.Header().Set("Content-Type", "application/vnd.api+json")
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"data":{"id":"vcs-1","type":"vcs-providers","attributes":{"name":"github"}}}`)
+		}))
+		defer ts.Close()
+
+		client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+		require.NoError(t, err)
+
+		vcs, err := client.VcsProviders.Create(context.Background(), VcsProviderCreateOptions{
+			Name:     String("github"),
+			VcsType:  Github,
+			AuthType: PersonalToken,
+			Token:    "s3cr3t",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "vcs-1", vcs.ID)
+	})
+	t.Run("is decoded from the API", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/vnd.api+json")
+			switch r.URL.Path {
+			case "/api/iacp/v3/vcs-providers/vcs-1":
+				fmt.Fprint(w, `{"data":{"id":"vcs-1","type":"vcs-providers","attributes":{"name":"github",`+
+					`"token":"masked","oauth":{"client-id":"id","client-secret":"masked"}}}}`)
+			case "/api/iacp/v3/vars/var-1":
+				fmt.Fprint(w, `{"data":{"id":"var-1","type":"vars","attributes":{"key":"k","value":"v"}}}`)
+			}
+		}))
+		defer ts.Close()
+
+		client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+		require.NoError(t, err)
+
+		vcs, err := client.VcsProviders.Read(context.Background(), "vcs-1")
+		require.NoError(t, err)
+		assert.Equal(t, "masked", vcs.Token.Reveal())
+		assert.Equal(t, "masked", vcs.OAuth.ClientSecret.Reveal())
+
+		v, err := client.Variables.Read(context.Background(), "var-1")
+		require.NoError(t, err)
+		assert.Equal(t, "v", v.Value.Reveal())
+		assert.NotContains(t, fmt.Sprintf("%v", v), `"v"`)
+	})
+
+	t.Run("nested secrets are sent to the API", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(body), `"client-secret":"s3cr3t"`)
+			assert.NotContains(t, string(body), "redacted")
+
+			w.Header().Set("Content-Type", "application/vnd.api+json")
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"data":{"id":"vcs-1","type":"vcs-providers","attributes":{"name":"github"}}}`)
+		}))
+		defer ts.Close()
+
+		client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+		require.NoError(t, err)
+
+		_, err = client.VcsProviders.Create(context.Background(), VcsProviderCreateOptions{
+			Name:     String("github"),
+			VcsType:  Github,
+			AuthType: Oauth2,
+			OAuth:    &OAuth{ClientId: "id", ClientSecret: "s3cr3t"},
+		})
+		require.NoError(t, err)
+	})
+}
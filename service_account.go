@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 	"time"
 )
 
@@ -19,6 +20,8 @@ type ServiceAccounts interface {
 	Create(ctx context.Context, options ServiceAccountCreateOptions) (*ServiceAccount, error)
 	// Read reads a service account by its ID.
 	Read(ctx context.Context, serviceAccountID string) (*ServiceAccount, error)
+	// ReadByEmail reads a service account of the account by its email.
+	ReadByEmail(ctx context.Context, accountID, email string) (*ServiceAccount, error)
 	// Update existing service account by its ID.
 	Update(ctx context.Context, serviceAccountID string, options ServiceAccountUpdateOptions) (*ServiceAccount, error)
 	// Delete service account by its ID.
@@ -56,6 +59,10 @@ type ServiceAccount struct {
 	// Relations
 	Account   *Account `jsonapi:"relation,account,omitempty"`
 	CreatedBy *User    `jsonapi:"relation,created-by,omitempty"`
+
+	// The access policies created together with the service account,
+	// see ServiceAccountCreateOptions.AccessPolicies.
+	AccessPolicies []*AccessPolicy
 }
 
 // ServiceAccountListOptions represents the options for listing service accounts.
@@ -79,6 +86,26 @@ type ServiceAccountCreateOptions struct {
 	Description *string               `jsonapi:"attr,description,omitempty"`
 	Status      *ServiceAccountStatus `jsonapi:"attr,status,omitempty"`
 	Account     *Account              `jsonapi:"relation,account"`
+
+	// The access policies to grant to the service account right after it is created.
+	// If any of them fails to be created, the service account is deleted.
+	AccessPolicies []*ServiceAccountAccessPolicyOptions
+}
+
+// ServiceAccountAccessPolicyOptions represents an access policy to create for a new service account.
+type ServiceAccountAccessPolicyOptions struct {
+	Scope AccessPolicyScope
+	Roles []*Role
+}
+
+func (o ServiceAccountAccessPolicyOptions) createOptions(sa *ServiceAccount) AccessPolicyCreateOptions {
+	return AccessPolicyCreateOptions{
+		Roles:          o.Roles,
+		ServiceAccount: sa,
+		Account:        o.Scope.Account,
+		Environment:    o.Scope.Environment,
+		Workspace:      o.Scope.Workspace,
+	}
 }
 
 func (o ServiceAccountCreateOptions) valid() error {
@@ -91,6 +118,15 @@ func (o ServiceAccountCreateOptions) valid() error {
 	if o.Name == nil {
 		return errors.New("name is required")
 	}
+	for i, ap := range o.AccessPolicies {
+		if ap == nil {
+			return fmt.Errorf("access policy %d: scope and roles are required", i)
+		}
+		// The service account doesn't exist yet, validate against a placeholder.
+		if err := ap.createOptions(&ServiceAccount{ID: "sa-new"}).valid(); err != nil {
+			return fmt.Errorf("access policy %d: %v", i, err)
+		}
+	}
 	return nil
 }
 
@@ -145,6 +181,35 @@ func (s *serviceAccounts) List(ctx context.Context, options ServiceAccountListOp
 	return sal, nil
 }
 
+// ReadByEmail reads a service account of the account by its email, which is
+// the identifier used in access policies. A ResourceNotFoundError is returned
+// if there is no service account with the email.
+func (s *serviceAccounts) ReadByEmail(ctx context.Context, accountID, email string) (*ServiceAccount, error) {
+	if !validStringID(&accountID) {
+		return nil, errors.New("invalid value for account ID")
+	}
+	if !validString(&email) {
+		return nil, errors.New("email is required")
+	}
+
+	sal, err := s.List(ctx, ServiceAccountListOptions{
+		Account: String(accountID),
+		Email:   String(email),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, sa := range sal.Items {
+		if strings.EqualFold(sa.Email, email) {
+			return sa, nil
+		}
+	}
+
+	return nil, ResourceNotFoundError{
+		Message: fmt.Sprintf("ServiceAccount with email '%s' not found or user unauthorized", email),
+	}
+}
+
 // Create is used to create a new service account.
 func (s *serviceAccounts) Create(ctx context.Context, options ServiceAccountCreateOptions) (*ServiceAccount, error) {
 	if err := options.valid(); err != nil {
@@ -164,6 +229,19 @@ func (s *serviceAccounts) Create(ctx context.Context, options ServiceAccountCrea
 		return nil, err
 	}
 
+	for i, apOptions := range options.AccessPolicies {
+		ap, err := s.client.AccessPolicies.Create(ctx, apOptions.createOptions(&ServiceAccount{ID: sa.ID}))
+		if err != nil {
+			err = fmt.Errorf("access policy %d: %w", i, err)
+			// Deleting the service account also removes the policies created so far.
+			if dErr := s.Delete(ctx, sa.ID); dErr != nil {
+				return nil, fmt.Errorf("%w; rollback failed: service account %s: %v", err, sa.ID, dErr)
+			}
+			return nil, err
+		}
+		sa.AccessPolicies = append(sa.AccessPolicies, ap)
+	}
+
 	return sa, nil
 }
 
@@ -98,6 +98,39 @@ func TestServiceAccountsCreate(t *testing.T) {
 		}
 	})
 
+	t.Run("with access policies", func(t *testing.T) {
+		envTest, envTestCleanup := createEnvironment(t, client)
+		defer envTestCleanup()
+
+		role, roleCleanup := createRole(t, client, readPermissions)
+		defer roleCleanup()
+
+		sa, err := client.ServiceAccounts.Create(ctx, ServiceAccountCreateOptions{
+			Name:    String("tst-" + randomString(t)),
+			Account: &Account{ID: defaultAccountID},
+			AccessPolicies: []*ServiceAccountAccessPolicyOptions{
+				{Scope: AccessPolicyScope{Environment: envTest}, Roles: []*Role{role}},
+			},
+		})
+		require.NoError(t, err)
+		defer func() { _ = client.ServiceAccounts.Delete(ctx, sa.ID) }()
+
+		require.Len(t, sa.AccessPolicies, 1)
+		assert.Equal(t, sa.ID, sa.AccessPolicies[0].ServiceAccount.ID)
+		assert.Equal(t, envTest.ID, sa.AccessPolicies[0].Environment.ID)
+	})
+
+	t.Run("when an access policy has no roles", func(t *testing.T) {
+		_, err := client.ServiceAccounts.Create(ctx, ServiceAccountCreateOptions{
+			Name:    String("tst-" + randomString(t)),
+			Account: &Account{ID: defaultAccountID},
+			AccessPolicies: []*ServiceAccountAccessPolicyOptions{
+				{Scope: AccessPolicyScope{Account: &Account{ID: defaultAccountID}}},
+			},
+		})
+		assert.EqualError(t, err, "access policy 0: at least one role must be provided")
+	})
+
 	t.Run("when options has name missing", func(t *testing.T) {
 		_, err := client.ServiceAccounts.Create(ctx, ServiceAccountCreateOptions{
 			Account: &Account{ID: defaultAccountID},
@@ -175,6 +208,30 @@ func TestServiceAccountsRead(t *testing.T) {
 		_, err := client.ServiceAccounts.Read(ctx, badIdentifier)
 		assert.EqualError(t, err, "invalid value for service account ID")
 	})
+
+	t.Run("by email when the service account exists", func(t *testing.T) {
+		sa, err := client.ServiceAccounts.ReadByEmail(ctx, defaultAccountID, saTest.Email)
+		require.NoError(t, err)
+		assert.Equal(t, saTest.ID, sa.ID)
+	})
+
+	t.Run("by email when the service account does not exist", func(t *testing.T) {
+		email := "nonexisting@example.com"
+		_, err := client.ServiceAccounts.ReadByEmail(ctx, defaultAccountID, email)
+		assert.ErrorIs(t, err, ErrResourceNotFound)
+		assert.EqualError(
+			t,
+			err,
+			ResourceNotFoundError{
+				Message: fmt.Sprintf("ServiceAccount with email '%s' not found or user unauthorized", email),
+			}.Error(),
+		)
+	})
+
+	t.Run("by email without an email", func(t *testing.T) {
+		_, err := client.ServiceAccounts.ReadByEmail(ctx, defaultAccountID, "")
+		assert.EqualError(t, err, "email is required")
+	})
 }
 
 func TestServiceAccountsUpdate(t *testing.T) {
@@ -0,0 +1,56 @@
+package scalr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SortKey represents an attribute the API can sort a list by.
+// A key prefixed with "-" sorts in the descending order.
+type SortKey string
+
+// Asc returns the key sorting in the ascending order.
+func (k SortKey) Asc() SortKey {
+	return SortKey(strings.TrimPrefix(string(k), "-"))
+}
+
+// Desc returns the key sorting in the descending order.
+func (k SortKey) Desc() SortKey {
+	return "-" + k.Asc()
+}
+
+// SortDesc returns the given key sorting in the descending order.
+func SortDesc(k SortKey) SortKey {
+	return k.Desc()
+}
+
+// SortBy renders the keys in the API's sort syntax, ready to be
+// used as the Sort value of the list options.
+func SortBy(keys ...SortKey) *string {
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = string(k)
+	}
+	return String(strings.Join(parts, ","))
+}
+
+// validSort checks that every key of the sort expression is one of the allowed keys.
+func validSort(sort *string, allowed ...SortKey) error {
+	if sort == nil {
+		return nil
+	}
+	for _, part := range strings.Split(*sort, ",") {
+		key := SortKey(strings.TrimSpace(part)).Asc()
+		found := false
+		for _, a := range allowed {
+			if key == a {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("invalid value for sort: '%s'", part)
+		}
+	}
+	return nil
+}
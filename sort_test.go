@@ -0,0 +1,50 @@
+package scalr
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortBy(t *testing.T) {
+	t.Run("single key", func(t *testing.T) {
+		assert.Equal(t, "name", *SortBy(WorkspaceSortByName))
+	})
+
+	t.Run("descending keys", func(t *testing.T) {
+		assert.Equal(t, "-created-at,name", *SortBy(SortDesc(WorkspaceSortByCreatedAt), WorkspaceSortByName))
+		assert.Equal(t, SortKey("-name"), WorkspaceSortByName.Desc().Desc())
+		assert.Equal(t, WorkspaceSortByName, WorkspaceSortByName.Desc().Asc())
+	})
+}
+
+func TestValidSort(t *testing.T) {
+	assert.NoError(t, validSort(nil, WorkspaceSortByName))
+	assert.NoError(t, validSort(String("-name,created-at"), WorkspaceSortByName, WorkspaceSortByCreatedAt))
+	assert.EqualError(t, validSort(String("name,foo"), WorkspaceSortByName), "invalid value for sort: 'foo'")
+}
+
+func TestListSortValidation(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+	sort := String("unknown")
+
+	_, err = client.ProviderConfigurations.List(ctx, ProviderConfigurationsListOptions{Sort: sort})
+	assert.EqualError(t, err, "invalid value for sort: 'unknown'")
+	_, err = client.Teams.List(ctx, TeamListOptions{Sort: sort})
+	assert.EqualError(t, err, "invalid value for sort: 'unknown'")
+	_, err = client.Users.List(ctx, UserListOptions{Sort: sort})
+	assert.EqualError(t, err, "invalid value for sort: 'unknown'")
+	_, err = client.Variables.List(ctx, VariableListOptions{Sort: sort})
+	assert.EqualError(t, err, "invalid value for sort: 'unknown'")
+}
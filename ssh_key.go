@@ -0,0 +1,7 @@
+package scalr
+
+// SSHKey represents a Scalr SSH key used to fetch private Terraform modules.
+type SSHKey struct {
+	ID   string `jsonapi:"primary,ssh-keys"`
+	Name string `jsonapi:"attr,name"`
+}
@@ -0,0 +1,286 @@
+package scalr
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Compile-time proof of interface implementation.
+var _ StateVersions = (*stateVersions)(nil)
+
+// StateVersions describes all the state version related methods that the Scalr API supports.
+type StateVersions interface {
+	// List the state versions of a workspace, the newest first.
+	List(ctx context.Context, options StateVersionListOptions) (*StateVersionList, error)
+	// Create uploads a new state version of a workspace.
+	Create(ctx context.Context, options StateVersionCreateOptions) (*StateVersion, error)
+	// Read a state version by its ID.
+	Read(ctx context.Context, stateVersionID string) (*StateVersion, error)
+	// ReadCurrentForWorkspace reads the current state version of the workspace.
+	ReadCurrentForWorkspace(ctx context.Context, workspaceID string) (*StateVersion, error)
+	// Watch sends each new current state version of the workspace to ch until ctx is done.
+	Watch(ctx context.Context, workspaceID string, ch chan<- *StateVersion) error
+	// Download the raw Terraform state of the state version.
+	Download(ctx context.Context, stateVersionID string) ([]byte, error)
+}
+
+// stateVersions implements StateVersions.
+type stateVersions struct {
+	client *Client
+}
+
+// defaultStateVersionWatchInterval is how often Watch polls for a new state
+// version, unless the config sets PollInterval.
+const defaultStateVersionWatchInterval = 10 * time.Second
+
+// StateVersionOutput represents a single output of a state version.
+type StateVersionOutput struct {
+	Name      string      `json:"name"`
+	Value     interface{} `json:"value"`
+	Sensitive bool        `json:"sensitive"`
+}
+
+// StateVersion represents a Scalr state version.
+type StateVersion struct {
+	ID        string                `jsonapi:"primary,state-versions"`
+	Serial    int                   `jsonapi:"attr,serial"`
+	CreatedAt time.Time             `jsonapi:"attr,created-at,iso8601"`
+	Outputs   []*StateVersionOutput `jsonapi:"attr,outputs"`
+
+	// Relations
+	Run       *Run       `jsonapi:"relation,run"`
+	Workspace *Workspace `jsonapi:"relation,workspace"`
+}
+
+// StateVersionList represents a list of state versions.
+type StateVersionList struct {
+	*Pagination
+	Items []*StateVersion
+}
+
+// StateVersionListOptions represents the options for listing state versions.
+type StateVersionListOptions struct {
+	ListOptions
+
+	// The workspace to list the state versions of, required.
+	Workspace string `url:"filter[workspace]"`
+}
+
+// List the state versions of a workspace, the newest first.
+func (s *stateVersions) List(ctx context.Context, options StateVersionListOptions) (*StateVersionList, error) {
+	if !validStringID(&options.Workspace) {
+		return nil, errors.New("invalid value for workspace ID")
+	}
+
+	req, err := s.client.newRequest("GET", "state-versions", &options)
+	if err != nil {
+		return nil, err
+	}
+
+	svl := &StateVersionList{}
+	err = s.client.do(ctx, req, svl)
+	if err != nil {
+		return nil, err
+	}
+
+	return svl, nil
+}
+
+// StateVersionCreateOptions represents the options for uploading a state version,
+// see NewStateVersionCreateOptions.
+type StateVersionCreateOptions struct {
+	// For internal use only!
+	ID string `jsonapi:"primary,state-versions"`
+
+	// The serial of the state, it must be greater than the one of the current state.
+	Serial *int `jsonapi:"attr,serial"`
+
+	// The MD5 hash of the raw state.
+	MD5 *string `jsonapi:"attr,md5"`
+
+	// The base64 encoded raw state.
+	State *string `jsonapi:"attr,state"`
+
+	// The workspace the state belongs to.
+	Workspace *Workspace `jsonapi:"relation,workspace"`
+}
+
+// NewStateVersionCreateOptions returns the options uploading the raw Terraform
+// state, e.g. the local terraform.tfstate file, to the workspace.
+func NewStateVersionCreateOptions(workspaceID string, state []byte) (StateVersionCreateOptions, error) {
+	var parsed struct {
+		Serial *int `json:"serial"`
+	}
+	if err := json.Unmarshal(state, &parsed); err != nil {
+		return StateVersionCreateOptions{}, fmt.Errorf("invalid terraform state: %v", err)
+	}
+	if parsed.Serial == nil {
+		return StateVersionCreateOptions{}, errors.New("invalid terraform state: serial is missing")
+	}
+
+	return StateVersionCreateOptions{
+		Serial:    parsed.Serial,
+		MD5:       String(fmt.Sprintf("%x", md5.Sum(state))),
+		State:     String(base64.StdEncoding.EncodeToString(state)),
+		Workspace: &Workspace{ID: workspaceID},
+	}, nil
+}
+
+func (o StateVersionCreateOptions) valid() error {
+	if o.Workspace == nil {
+		return errors.New("workspace is required")
+	}
+	if !validStringID(&o.Workspace.ID) {
+		return errors.New("invalid value for workspace ID")
+	}
+	if o.Serial == nil {
+		return errors.New("serial is required")
+	}
+	if !validString(o.MD5) {
+		return errors.New("md5 is required")
+	}
+	if !validString(o.State) {
+		return errors.New("state is required")
+	}
+	return nil
+}
+
+// Create uploads a new state version of a workspace, it becomes the current state.
+func (s *stateVersions) Create(ctx context.Context, options StateVersionCreateOptions) (*StateVersion, error) {
+	if err := options.valid(); err != nil {
+		return nil, err
+	}
+
+	// Make sure we don't send a user provided ID.
+	options.ID = ""
+
+	req, err := s.client.newRequest("POST", "state-versions", &options)
+	if err != nil {
+		return nil, err
+	}
+
+	sv := &StateVersion{}
+	err = s.client.do(withTransfer(ctx, transferUpload), req, sv)
+	if err != nil {
+		return nil, err
+	}
+
+	return sv, nil
+}
+
+// Read a state version by its ID.
+func (s *stateVersions) Read(ctx context.Context, stateVersionID string) (*StateVersion, error) {
+	if !validStringID(&stateVersionID) {
+		return nil, errors.New("invalid value for state version ID")
+	}
+
+	u := fmt.Sprintf("state-versions/%s", url.QueryEscape(stateVersionID))
+	req, err := s.client.newRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sv := &StateVersion{}
+	err = s.client.do(ctx, req, sv)
+	if err != nil {
+		return nil, err
+	}
+
+	return sv, nil
+}
+
+// ReadCurrentForWorkspace reads the current state version of the workspace.
+func (s *stateVersions) ReadCurrentForWorkspace(ctx context.Context, workspaceID string) (*StateVersion, error) {
+	if !validStringID(&workspaceID) {
+		return nil, errors.New("invalid value for workspace ID")
+	}
+
+	u := fmt.Sprintf("workspaces/%s/current-state-version", url.QueryEscape(workspaceID))
+	req, err := s.client.newRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sv := &StateVersion{}
+	err = s.client.do(ctx, req, sv)
+	if err != nil {
+		return nil, err
+	}
+
+	return sv, nil
+}
+
+// Watch polls the current state version of the workspace and sends it to ch
+// each time it changes. The state version that is current when Watch starts is
+// not sent. Watch blocks until ctx is done or a request fails; a workspace that
+// has no state yet is not an error.
+func (s *stateVersions) Watch(ctx context.Context, workspaceID string, ch chan<- *StateVersion) error {
+	if !validStringID(&workspaceID) {
+		return errors.New("invalid value for workspace ID")
+	}
+
+	current := func() (string, *StateVersion, error) {
+		sv, err := s.ReadCurrentForWorkspace(ctx, workspaceID)
+		if errors.Is(err, ErrResourceNotFound) {
+			return "", nil, nil
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		return sv.ID, sv, nil
+	}
+
+	var lastID string
+	started := false
+
+	interval := s.client.pollInterval(defaultStateVersionWatchInterval)
+	return poll(ctx, interval, 0, nil, func(ctx context.Context) (bool, error) {
+		id, sv, err := current()
+		if err != nil {
+			return false, err
+		}
+		if !started {
+			started, lastID = true, id
+			return false, nil
+		}
+		if sv == nil || id == lastID {
+			return false, nil
+		}
+		lastID = id
+
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case ch <- sv:
+		}
+		return false, nil
+	})
+}
+
+// Download the raw Terraform state of the state version.
+func (s *stateVersions) Download(ctx context.Context, stateVersionID string) ([]byte, error) {
+	if !validStringID(&stateVersionID) {
+		return nil, errors.New("invalid value for state version ID")
+	}
+
+	u := fmt.Sprintf("state-versions/%s/download", url.QueryEscape(stateVersionID))
+	req, err := s.client.newRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = s.client.do(withTransfer(ctx, transferDownload), req, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
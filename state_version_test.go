@@ -0,0 +1,123 @@
+package scalr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateVersionsReadCurrentForWorkspace(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	wsTest, wsTestCleanup := createWorkspace(t, client, nil)
+	defer wsTestCleanup()
+
+	t.Run("when the workspace has no state", func(t *testing.T) {
+		sv, err := client.StateVersions.ReadCurrentForWorkspace(ctx, wsTest.ID)
+		assert.Nil(t, sv)
+		assert.ErrorIs(t, err, ErrResourceNotFound)
+	})
+
+	t.Run("with invalid workspace ID", func(t *testing.T) {
+		sv, err := client.StateVersions.ReadCurrentForWorkspace(ctx, badIdentifier)
+		assert.Nil(t, sv)
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+}
+
+func TestStateVersionsWatch(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		if n == 1 {
+			w.WriteHeader(404)
+			return
+		}
+		// Switch to a new state version every second poll.
+		id := fmt.Sprintf("sv-%d", n/2)
+		fmt.Fprintf(w, `{"data":{"id":%q,"type":"state-versions","attributes":{"serial":%d}}}`, id, n/2)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client(), PollInterval: time.Millisecond})
+	require.NoError(t, err)
+
+	t.Run("sends new state versions", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := make(chan *StateVersion)
+		done := make(chan error)
+		go func() { done <- client.StateVersions.Watch(ctx, "ws-123", ch) }()
+
+		first := <-ch
+		second := <-ch
+		cancel()
+
+		assert.Equal(t, "sv-1", first.ID)
+		assert.Equal(t, "sv-2", second.ID)
+		assert.Equal(t, 2, second.Serial)
+		assert.Equal(t, context.Canceled, <-done)
+	})
+
+	t.Run("with invalid workspace ID", func(t *testing.T) {
+		err := client.StateVersions.Watch(context.Background(), badIdentifier, nil)
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+}
+
+func TestStateVersionsListAndDownload(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/iacp/v3/state-versions":
+			assert.Equal(t, "ws-123", r.URL.Query().Get("filter[workspace]"))
+			w.Header().Set("Content-Type", "application/vnd.api+json")
+			fmt.Fprint(w, `{"data":[`+
+				`{"id":"sv-2","type":"state-versions","attributes":{"serial":2}},`+
+				`{"id":"sv-1","type":"state-versions","attributes":{"serial":1}}],`+
+				`"meta":{"pagination":{"current-page":1,"total-pages":1,"total-count":2}}}`)
+		case "/api/iacp/v3/state-versions/sv-2/download":
+			fmt.Fprint(w, `{"version":4,"serial":2,"resources":[]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("list the workspace state versions", func(t *testing.T) {
+		svl, err := client.StateVersions.List(ctx, StateVersionListOptions{Workspace: "ws-123"})
+		require.NoError(t, err)
+		require.Len(t, svl.Items, 2)
+		assert.Equal(t, 2, svl.Items[0].Serial)
+		assert.Equal(t, 2, svl.TotalCount)
+	})
+
+	t.Run("list without workspace", func(t *testing.T) {
+		svl, err := client.StateVersions.List(ctx, StateVersionListOptions{})
+		assert.Nil(t, svl)
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+
+	t.Run("download the state", func(t *testing.T) {
+		state, err := client.StateVersions.Download(ctx, "sv-2")
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"version":4,"serial":2,"resources":[]}`, string(state))
+	})
+
+	t.Run("download with invalid state version ID", func(t *testing.T) {
+		state, err := client.StateVersions.Download(ctx, badIdentifier)
+		assert.Nil(t, state)
+		assert.EqualError(t, err, "invalid value for state version ID")
+	})
+}
@@ -0,0 +1,224 @@
+package scalr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SweepResult represents the outcome of SweepStaleTestResources.
+type SweepResult struct {
+	// The deleted resources, as "<type>/<ID>".
+	Deleted []string
+
+	// The resources that failed to be deleted, keyed by "<type>/<ID>".
+	Errors map[string]error
+}
+
+// sweepItem is a resource that may be swept.
+type sweepItem struct {
+	id   string
+	name string
+}
+
+// sweeper lists and deletes the resources of a single type.
+type sweeper struct {
+	kind   string
+	list   func(ctx context.Context, page int) ([]sweepItem, *Pagination, error)
+	delete func(ctx context.Context, id string) error
+}
+
+// SweepStaleTestResources deletes the workspaces, environments, service accounts,
+// teams, roles, agent pools and tags of the account whose names start with the
+// prefix, e.g. "tst-". It is meant for the accounts used to run integration tests,
+// where failed runs leave dangling resources behind. The tokens of the swept
+// service accounts and agent pools are deleted along with them. The standalone
+// access tokens are not swept, the API has no way to list them by account; they
+// have to be deleted with AccessTokens.Delete.
+//
+// A failure to delete a resource does not stop the sweep, it is recorded in the
+// result instead. An error is only returned if the resources can not be listed.
+func SweepStaleTestResources(ctx context.Context, client *Client, accountID, prefix string) (*SweepResult, error) {
+	if client == nil {
+		return nil, errors.New("client is required")
+	}
+	if !validStringID(&accountID) {
+		return nil, errors.New("invalid value for account ID")
+	}
+	if !validString(&prefix) {
+		return nil, errors.New("prefix is required")
+	}
+
+	result := &SweepResult{Errors: make(map[string]error)}
+	for _, s := range sweepers(client, accountID) {
+		var stale []sweepItem
+		page := 0
+		for {
+			items, p, err := s.list(ctx, page)
+			if err != nil {
+				return result, fmt.Errorf("listing %s: %w", s.kind, err)
+			}
+			for _, item := range items {
+				if strings.HasPrefix(item.name, prefix) {
+					stale = append(stale, item)
+				}
+			}
+			if p == nil || p.NextPage == 0 {
+				break
+			}
+			page = p.NextPage
+		}
+
+		// Delete after listing, so the pages do not shift.
+		for _, item := range stale {
+			key := s.kind + "/" + item.id
+			err := s.delete(ctx, item.id)
+			switch {
+			case err == nil, errors.Is(err, ErrResourceNotFound):
+				result.Deleted = append(result.Deleted, key)
+			default:
+				result.Errors[key] = err
+			}
+		}
+	}
+	sort.Strings(result.Deleted)
+
+	return result, nil
+}
+
+// sweepers returns the sweepers of the account in the deletion order:
+// the workspaces go before their environments.
+func sweepers(client *Client, accountID string) []sweeper {
+	return []sweeper{
+		{
+			kind: "workspaces",
+			list: func(ctx context.Context, page int) ([]sweepItem, *Pagination, error) {
+				l, err := client.Workspaces.List(ctx, WorkspaceListOptions{
+					ListOptions: ListOptions{PageNumber: page},
+					Filter:      &WorkspaceFilter{Account: &accountID},
+				})
+				if err != nil {
+					return nil, nil, err
+				}
+				items := make([]sweepItem, len(l.Items))
+				for i, v := range l.Items {
+					items[i] = sweepItem{v.ID, v.Name}
+				}
+				return items, l.Pagination, nil
+			},
+			delete: client.Workspaces.Delete,
+		},
+		{
+			kind: "environments",
+			list: func(ctx context.Context, page int) ([]sweepItem, *Pagination, error) {
+				l, err := client.Environments.List(ctx, EnvironmentListOptions{
+					ListOptions: ListOptions{PageNumber: page},
+					Filter:      &EnvironmentFilter{Account: &accountID},
+				})
+				if err != nil {
+					return nil, nil, err
+				}
+				items := make([]sweepItem, len(l.Items))
+				for i, v := range l.Items {
+					items[i] = sweepItem{v.ID, v.Name}
+				}
+				return items, l.Pagination, nil
+			},
+			delete: client.Environments.Delete,
+		},
+		{
+			kind: "service-accounts",
+			list: func(ctx context.Context, page int) ([]sweepItem, *Pagination, error) {
+				l, err := client.ServiceAccounts.List(ctx, ServiceAccountListOptions{
+					ListOptions: ListOptions{PageNumber: page},
+					Account:     &accountID,
+				})
+				if err != nil {
+					return nil, nil, err
+				}
+				items := make([]sweepItem, len(l.Items))
+				for i, v := range l.Items {
+					items[i] = sweepItem{v.ID, v.Name}
+				}
+				return items, l.Pagination, nil
+			},
+			delete: client.ServiceAccounts.Delete,
+		},
+		{
+			kind: "teams",
+			list: func(ctx context.Context, page int) ([]sweepItem, *Pagination, error) {
+				l, err := client.Teams.List(ctx, TeamListOptions{
+					ListOptions: ListOptions{PageNumber: page},
+					Account:     &accountID,
+				})
+				if err != nil {
+					return nil, nil, err
+				}
+				items := make([]sweepItem, len(l.Items))
+				for i, v := range l.Items {
+					items[i] = sweepItem{v.ID, v.Name}
+				}
+				return items, l.Pagination, nil
+			},
+			delete: client.Teams.Delete,
+		},
+		{
+			kind: "roles",
+			list: func(ctx context.Context, page int) ([]sweepItem, *Pagination, error) {
+				l, err := client.Roles.List(ctx, RoleListOptions{
+					ListOptions: ListOptions{PageNumber: page},
+					Account:     &accountID,
+				})
+				if err != nil {
+					return nil, nil, err
+				}
+				var items []sweepItem
+				for _, v := range l.Items {
+					if !v.IsSystem {
+						items = append(items, sweepItem{v.ID, v.Name})
+					}
+				}
+				return items, l.Pagination, nil
+			},
+			delete: client.Roles.Delete,
+		},
+		{
+			kind: "agent-pools",
+			list: func(ctx context.Context, page int) ([]sweepItem, *Pagination, error) {
+				l, err := client.AgentPools.List(ctx, AgentPoolListOptions{
+					ListOptions: ListOptions{PageNumber: page},
+					Account:     &accountID,
+				})
+				if err != nil {
+					return nil, nil, err
+				}
+				items := make([]sweepItem, len(l.Items))
+				for i, v := range l.Items {
+					items[i] = sweepItem{v.ID, v.Name}
+				}
+				return items, l.Pagination, nil
+			},
+			delete: client.AgentPools.Delete,
+		},
+		{
+			kind: "tags",
+			list: func(ctx context.Context, page int) ([]sweepItem, *Pagination, error) {
+				l, err := client.Tags.List(ctx, TagListOptions{
+					ListOptions: ListOptions{PageNumber: page},
+					Account:     &accountID,
+				})
+				if err != nil {
+					return nil, nil, err
+				}
+				items := make([]sweepItem, len(l.Items))
+				for i, v := range l.Items {
+					items[i] = sweepItem{v.ID, v.Name}
+				}
+				return items, l.Pagination, nil
+			},
+			delete: client.Tags.Delete,
+		},
+	}
+}
@@ -0,0 +1,73 @@
+package scalr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSweepStaleTestResources(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		deleted []string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			mu.Lock()
+			deleted = append(deleted, r.URL.Path)
+			mu.Unlock()
+			if r.URL.Path == "/api/iacp/v3/teams/team-locked" {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				fmt.Fprint(w, `{"errors":[{"status":"422","title":"locked","detail":"team is in use"}]}`)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		assert.Equal(t, "acc-123", r.URL.Query().Get("filter[account]"))
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		switch r.URL.Path {
+		case "/api/iacp/v3/workspaces":
+			fmt.Fprint(w, `{"data":[{"id":"ws-1","type":"workspaces","attributes":{"name":"tst-a"}},`+
+				`{"id":"ws-2","type":"workspaces","attributes":{"name":"production"}}]}`)
+		case "/api/iacp/v3/teams":
+			fmt.Fprint(w, `{"data":[{"id":"team-locked","type":"teams","attributes":{"name":"tst-b"}}]}`)
+		case "/api/iacp/v3/roles":
+			fmt.Fprint(w, `{"data":[{"id":"role-1","type":"roles","attributes":{"name":"tst-c"}},`+
+				`{"id":"role-system","type":"roles","attributes":{"name":"tst-system","is-system":true}}]}`)
+		default:
+			fmt.Fprint(w, `{"data":[]}`)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("with stale resources", func(t *testing.T) {
+		result, err := SweepStaleTestResources(ctx, client, "acc-123", "tst-")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"roles/role-1", "workspaces/ws-1"}, result.Deleted)
+		require.Len(t, result.Errors, 1)
+		assert.Contains(t, result.Errors, "teams/team-locked")
+		assert.ElementsMatch(t, []string{
+			"/api/iacp/v3/workspaces/ws-1",
+			"/api/iacp/v3/teams/team-locked",
+			"/api/iacp/v3/roles/role-1",
+		}, deleted)
+	})
+
+	t.Run("without a prefix", func(t *testing.T) {
+		result, err := SweepStaleTestResources(ctx, client, "acc-123", "")
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "prefix is required")
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"regexp"
+	"strings"
 )
 
 // Compile-time proof of interface implementation.
@@ -22,6 +24,13 @@ type Tags interface {
 	Update(ctx context.Context, tagID string, options TagUpdateOptions) (*Tag, error)
 	// Delete deletes a tag by its ID.
 	Delete(ctx context.Context, tagID string) error
+	// Rename changes the name of an existing tag.
+	Rename(ctx context.Context, tagID, newName string) (*Tag, error)
+	// Merge re-assigns all workspaces and environments from one tag to another
+	// and deletes the source tag.
+	Merge(ctx context.Context, fromTagID, toTagID string) error
+	// BulkRename renames all the tags matching the list options with the rename function.
+	BulkRename(ctx context.Context, options TagListOptions, rename func(name string) string) ([]*TagRenameResult, error)
 }
 
 // tags implements Tags.
@@ -43,10 +52,69 @@ type Tag struct {
 	Account *Account `jsonapi:"relation,account"`
 }
 
+// TagsFilter returns the value of a tag filter matching resources with any
+// of the given tags, or nil if none is given.
+func TagsFilter(tagIDs ...string) *string {
+	return inFilter(tagIDs)
+}
+
+// MaxTagNameLength is the maximum length of a tag name.
+const MaxTagNameLength = 128
+
+var (
+	tagNameRe       = regexp.MustCompile(`^[A-Za-z0-9 _.:/=@+-]+$`)
+	tagWhitespaceRe = regexp.MustCompile(`\s+`)
+)
+
+// ValidateTagName checks that the tag name is not blank, is at most MaxTagNameLength
+// characters long and only consists of letters, digits, spaces and the `_.:/=@+-` characters.
+func ValidateTagName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("tag name is required")
+	}
+	if len(name) > MaxTagNameLength {
+		return fmt.Errorf("tag name %q is longer than %d characters", name, MaxTagNameLength)
+	}
+	if !tagNameRe.MatchString(name) {
+		return fmt.Errorf("tag name %q contains invalid characters", name)
+	}
+	return nil
+}
+
+// NormalizeTagName returns the canonical form of the tag name: trimmed, lowercased and
+// with every run of whitespace replaced by a single hyphen, e.g. " Cost Center" becomes
+// "cost-center". It does not validate the result, see ValidateTagName.
+func NormalizeTagName(name string) string {
+	return tagWhitespaceRe.ReplaceAllString(strings.ToLower(strings.TrimSpace(name)), "-")
+}
+
+// TagRelation references a tag in the tag relationship of a workspace or environment.
 type TagRelation struct {
 	ID string `jsonapi:"primary,tags"`
 }
 
+// TagRelations returns the relations referencing the tags with the given IDs.
+func TagRelations(tagIDs ...string) []*TagRelation {
+	trs := make([]*TagRelation, 0, len(tagIDs))
+	for _, id := range tagIDs {
+		trs = append(trs, &TagRelation{ID: id})
+	}
+	return trs
+}
+
+// validTagRelations checks the tag relations, requiring at least one unless allowEmpty is set.
+func validTagRelations(trs []*TagRelation, allowEmpty bool) error {
+	if len(trs) == 0 && !allowEmpty {
+		return errors.New("at least one tag is required")
+	}
+	for _, tr := range trs {
+		if tr == nil || !validStringID(&tr.ID) {
+			return errors.New("invalid value for tag ID")
+		}
+	}
+	return nil
+}
+
 // TagListOptions represents the options for listing tags.
 type TagListOptions struct {
 	ListOptions
@@ -65,6 +133,10 @@ type TagCreateOptions struct {
 	Name *string `jsonapi:"attr,name"`
 	// Specifies the Account for the tag.
 	Account *Account `jsonapi:"relation,account"`
+
+	// Normalize makes Create normalize the name with NormalizeTagName
+	// and validate it with ValidateTagName before sending it.
+	Normalize bool
 }
 
 // TagUpdateOptions represents the options for updating a tag.
@@ -122,11 +194,17 @@ func (o TagCreateOptions) valid() error {
 	if o.Name == nil {
 		return errors.New("name is required")
 	}
+	if o.Normalize {
+		return ValidateTagName(*o.Name)
+	}
 	return nil
 }
 
 // Create is used to create a new tag.
 func (s *tags) Create(ctx context.Context, options TagCreateOptions) (*Tag, error) {
+	if options.Normalize && options.Name != nil {
+		options.Name = String(NormalizeTagName(*options.Name))
+	}
 	if err := options.valid(); err != nil {
 		return nil, err
 	}
@@ -185,3 +263,141 @@ func (s *tags) Delete(ctx context.Context, tagID string) error {
 
 	return s.client.do(ctx, req, nil)
 }
+
+// Rename changes the name of an existing tag.
+func (s *tags) Rename(ctx context.Context, tagID, newName string) (*Tag, error) {
+	if !validString(&newName) {
+		return nil, errors.New("name is required")
+	}
+
+	return s.Update(ctx, tagID, TagUpdateOptions{Name: &newName})
+}
+
+// Merge re-assigns all workspaces and environments tagged with the source tag
+// to the target tag, then deletes the source tag.
+func (s *tags) Merge(ctx context.Context, fromTagID, toTagID string) error {
+	if !validStringID(&fromTagID) {
+		return errors.New("invalid value for source tag ID")
+	}
+	if !validStringID(&toTagID) {
+		return errors.New("invalid value for target tag ID")
+	}
+	if fromTagID == toTagID {
+		return errors.New("source and target tags must be different")
+	}
+
+	// Make sure both tags exist before touching anything.
+	if _, err := s.Read(ctx, fromTagID); err != nil {
+		return err
+	}
+	if _, err := s.Read(ctx, toTagID); err != nil {
+		return err
+	}
+
+	// Collect the tagged resources first, as re-tagging them
+	// changes the filtered result set while paginating.
+	var workspaceIDs []string
+	wsOptions := WorkspaceListOptions{Filter: &WorkspaceFilter{Tag: &fromTagID}}
+	for {
+		wl, err := s.client.Workspaces.List(ctx, wsOptions)
+		if err != nil {
+			return err
+		}
+		for _, ws := range wl.Items {
+			workspaceIDs = append(workspaceIDs, ws.ID)
+		}
+		if wl.Pagination == nil || wl.NextPage == 0 {
+			break
+		}
+		wsOptions.PageNumber = wl.NextPage
+	}
+
+	var environmentIDs []string
+	envOptions := EnvironmentListOptions{Filter: &EnvironmentFilter{Tag: &fromTagID}}
+	for {
+		el, err := s.client.Environments.List(ctx, envOptions)
+		if err != nil {
+			return err
+		}
+		for _, env := range el.Items {
+			environmentIDs = append(environmentIDs, env.ID)
+		}
+		if el.Pagination == nil || el.NextPage == 0 {
+			break
+		}
+		envOptions.PageNumber = el.NextPage
+	}
+
+	from := []*TagRelation{{ID: fromTagID}}
+	to := []*TagRelation{{ID: toTagID}}
+
+	for _, wsID := range workspaceIDs {
+		if err := s.client.WorkspaceTags.Add(ctx, wsID, to); err != nil {
+			return fmt.Errorf("error adding tag %s to workspace %s: %v", toTagID, wsID, err)
+		}
+		if err := s.client.WorkspaceTags.Delete(ctx, wsID, from); err != nil {
+			return fmt.Errorf("error removing tag %s from workspace %s: %v", fromTagID, wsID, err)
+		}
+	}
+
+	for _, envID := range environmentIDs {
+		if err := s.client.EnvironmentTags.Add(ctx, envID, to); err != nil {
+			return fmt.Errorf("error adding tag %s to environment %s: %v", toTagID, envID, err)
+		}
+		if err := s.client.EnvironmentTags.Delete(ctx, envID, from); err != nil {
+			return fmt.Errorf("error removing tag %s from environment %s: %v", fromTagID, envID, err)
+		}
+	}
+
+	return s.Delete(ctx, fromTagID)
+}
+
+// TagRenameResult represents the outcome of renaming a single tag.
+type TagRenameResult struct {
+	Tag     *Tag
+	OldName string
+	Err     error
+}
+
+// BulkRename lists all the tags matching the options and renames each of them to the name
+// returned by the rename function, e.g. NormalizeTagName. The tags keeping their name are
+// skipped. A result is returned for each renamed tag, in the listing order; the error is only
+// returned if the tags can not be listed.
+func (s *tags) BulkRename(ctx context.Context, options TagListOptions, rename func(name string) string) ([]*TagRenameResult, error) {
+	if rename == nil {
+		return nil, errors.New("rename function is required")
+	}
+
+	// Collect the tags first, as renaming them may change
+	// the filtered result set while paginating.
+	var tags []*Tag
+	for {
+		tl, err := s.List(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tl.Items...)
+		if tl.Pagination == nil || tl.NextPage == 0 {
+			break
+		}
+		options.PageNumber = tl.NextPage
+	}
+
+	var results []*TagRenameResult
+	for _, tag := range tags {
+		newName := rename(tag.Name)
+		if newName == tag.Name {
+			continue
+		}
+
+		r := &TagRenameResult{Tag: tag, OldName: tag.Name}
+		if renamed, err := s.Rename(ctx, tag.ID, newName); err != nil {
+			r.Err = err
+		} else {
+			r.Tag = renamed
+		}
+		results = append(results, r)
+	}
+
+	return results, nil
+}
@@ -2,7 +2,11 @@ package scalr
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -195,3 +199,206 @@ func TestTagsDelete(t *testing.T) {
 		)
 	})
 }
+
+func TestTagsRename(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	tagTest, tagTestCleanup := createTag(t, client)
+	defer tagTestCleanup()
+
+	t.Run("with valid name", func(t *testing.T) {
+		name := "tst-" + randomString(t)
+		tag, err := client.Tags.Rename(ctx, tagTest.ID, name)
+		require.NoError(t, err)
+		assert.Equal(t, name, tag.Name)
+	})
+
+	t.Run("with empty name", func(t *testing.T) {
+		tag, err := client.Tags.Rename(ctx, tagTest.ID, " ")
+		assert.Nil(t, tag)
+		assert.EqualError(t, err, "name is required")
+	})
+
+	t.Run("with invalid tag ID", func(t *testing.T) {
+		tag, err := client.Tags.Rename(ctx, badIdentifier, "tst-"+randomString(t))
+		assert.Nil(t, tag)
+		assert.EqualError(t, err, "invalid value for tag ID")
+	})
+}
+
+func TestTagsMerge(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	envTest, envTestCleanup := createEnvironment(t, client)
+	defer envTestCleanup()
+	wsTest, wsTestCleanup := createWorkspace(t, client, envTest)
+	defer wsTestCleanup()
+
+	toTag, toTagCleanup := createTag(t, client)
+	defer toTagCleanup()
+
+	t.Run("with valid tags", func(t *testing.T) {
+		fromTag, _ := createTag(t, client)
+		assignTagsToWorkspace(t, client, wsTest, []*Tag{fromTag})
+		assignTagsToEnvironment(t, client, envTest, []*Tag{fromTag})
+
+		err := client.Tags.Merge(ctx, fromTag.ID, toTag.ID)
+		require.NoError(t, err)
+
+		ws, err := client.Workspaces.ReadByID(ctx, wsTest.ID)
+		require.NoError(t, err)
+		require.Len(t, ws.Tags, 1)
+		assert.Equal(t, toTag.ID, ws.Tags[0].ID)
+
+		env, err := client.Environments.Read(ctx, envTest.ID)
+		require.NoError(t, err)
+		require.Len(t, env.Tags, 1)
+		assert.Equal(t, toTag.ID, env.Tags[0].ID)
+
+		_, err = client.Tags.Read(ctx, fromTag.ID)
+		assert.Error(t, err)
+	})
+
+	t.Run("with the same tags", func(t *testing.T) {
+		err := client.Tags.Merge(ctx, toTag.ID, toTag.ID)
+		assert.EqualError(t, err, "source and target tags must be different")
+	})
+
+	t.Run("with invalid source tag ID", func(t *testing.T) {
+		err := client.Tags.Merge(ctx, badIdentifier, toTag.ID)
+		assert.EqualError(t, err, "invalid value for source tag ID")
+	})
+
+	t.Run("with invalid target tag ID", func(t *testing.T) {
+		err := client.Tags.Merge(ctx, toTag.ID, badIdentifier)
+		assert.EqualError(t, err, "invalid value for target tag ID")
+	})
+}
+
+func TestTagsFilter(t *testing.T) {
+	assert.Equal(t, "tag-1", *TagsFilter("tag-1"))
+	assert.Equal(t, "in:tag-1,tag-2", *TagsFilter("tag-1", "tag-2"))
+	assert.Nil(t, TagsFilter())
+}
+
+func TestTagNameNormalization(t *testing.T) {
+	assert.Equal(t, "cost-center", NormalizeTagName(" Cost  Center\t"))
+	assert.Equal(t, "env:prod", NormalizeTagName("ENV:Prod"))
+
+	assert.NoError(t, ValidateTagName("team/platform=core"))
+	assert.EqualError(t, ValidateTagName("  "), "tag name is required")
+	assert.EqualError(t, ValidateTagName("cost$center"), `tag name "cost$center" contains invalid characters`)
+	assert.EqualError(
+		t, ValidateTagName(strings.Repeat("a", MaxTagNameLength+1)),
+		fmt.Sprintf("tag name %q is longer than %d characters", strings.Repeat("a", MaxTagNameLength+1), MaxTagNameLength),
+	)
+
+	var name string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Data struct {
+				Attributes struct {
+					Name string `json:"name"`
+				} `json:"attributes"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		name = body.Data.Attributes.Name
+
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"data":{"id":"tag-1","type":"tags","attributes":{"name":%q}}}`, name)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("create normalizes the name", func(t *testing.T) {
+		tag, err := client.Tags.Create(ctx, TagCreateOptions{
+			Name:      String("Cost Center"),
+			Account:   &Account{ID: "acc-1"},
+			Normalize: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "cost-center", name)
+		assert.Equal(t, "cost-center", tag.Name)
+	})
+
+	t.Run("create validates the normalized name", func(t *testing.T) {
+		tag, err := client.Tags.Create(ctx, TagCreateOptions{
+			Name:      String("cost#center"),
+			Account:   &Account{ID: "acc-1"},
+			Normalize: true,
+		})
+		assert.Nil(t, tag)
+		assert.EqualError(t, err, `tag name "cost#center" contains invalid characters`)
+	})
+}
+
+func TestTagsBulkRename(t *testing.T) {
+	var renamed []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		switch r.Method {
+		case "GET":
+			assert.Equal(t, "acc-1", r.URL.Query().Get("filter[account]"))
+			if r.URL.Query().Get("page[number]") == "2" {
+				fmt.Fprint(w, `{"data":[{"id":"tag-3","type":"tags","attributes":{"name":"Cost Center"}}],`+
+					`"meta":{"pagination":{"current-page":2,"prev-page":1,"total-pages":2,"total-count":3}}}`)
+				return
+			}
+			fmt.Fprint(w, `{"data":[`+
+				`{"id":"tag-1","type":"tags","attributes":{"name":"Team Platform"}},`+
+				`{"id":"tag-2","type":"tags","attributes":{"name":"prod"}}],`+
+				`"meta":{"pagination":{"current-page":1,"next-page":2,"total-pages":2,"total-count":3}}}`)
+		case "PATCH":
+			var body struct {
+				Data struct {
+					Attributes struct {
+						Name string `json:"name"`
+					} `json:"attributes"`
+				} `json:"data"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			name := body.Data.Attributes.Name
+			renamed = append(renamed, r.URL.Path+" "+name)
+			if name == "cost-center" {
+				w.WriteHeader(http.StatusConflict)
+				fmt.Fprint(w, `{"errors":[{"status":"409","title":"Conflict","detail":"Tag already exists."}]}`)
+				return
+			}
+			fmt.Fprintf(w, `{"data":{"id":"tag-1","type":"tags","attributes":{"name":%q}}}`, name)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("renames the changed tags", func(t *testing.T) {
+		results, err := client.Tags.BulkRename(ctx, TagListOptions{Account: String("acc-1")}, NormalizeTagName)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"/api/iacp/v3/tags/tag-1 team-platform",
+			"/api/iacp/v3/tags/tag-3 cost-center",
+		}, renamed)
+
+		require.Len(t, results, 2)
+		assert.Equal(t, "Team Platform", results[0].OldName)
+		assert.Equal(t, "team-platform", results[0].Tag.Name)
+		assert.NoError(t, results[0].Err)
+		assert.Equal(t, "tag-3", results[1].Tag.ID)
+		assert.ErrorIs(t, results[1].Err, ErrResourceConflict)
+	})
+
+	t.Run("without rename function", func(t *testing.T) {
+		results, err := client.Tags.BulkRename(ctx, TagListOptions{}, nil)
+		assert.Nil(t, results)
+		assert.EqualError(t, err, "rename function is required")
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 )
 
 // Compile-time proof of interface implementation.
@@ -16,6 +17,7 @@ type Teams interface {
 	List(ctx context.Context, options TeamListOptions) (*TeamList, error)
 	Create(ctx context.Context, options TeamCreateOptions) (*Team, error)
 	Read(ctx context.Context, teamID string) (*Team, error)
+	ReadByName(ctx context.Context, accountID, name string) (*Team, error)
 	Update(ctx context.Context, teamID string, options TeamUpdateOptions) (*Team, error)
 	Delete(ctx context.Context, teamID string) error
 }
@@ -42,6 +44,11 @@ type TeamList struct {
 	Items []*Team
 }
 
+// List of attributes teams can be sorted by.
+const (
+	TeamSortByName SortKey = "name"
+)
+
 // TeamListOptions represents the options for listing teams.
 type TeamListOptions struct {
 	ListOptions
@@ -65,6 +72,10 @@ type TeamCreateOptions struct {
 	Account          *Account          `jsonapi:"relation,account,omitempty"`
 	IdentityProvider *IdentityProvider `jsonapi:"relation,identity-provider,omitempty"`
 	Users            []*User           `jsonapi:"relation,users,omitempty"`
+
+	// Strict makes Create fail if a team with the same name already exists
+	// in the account. The check is done client-side before the team is created.
+	Strict bool
 }
 
 func (o TeamCreateOptions) valid() error {
@@ -93,6 +104,10 @@ type TeamUpdateOptions struct {
 
 // List all the teams.
 func (s *teams) List(ctx context.Context, options TeamListOptions) (*TeamList, error) {
+	if err := validSort(options.Sort, TeamSortByName); err != nil {
+		return nil, err
+	}
+
 	req, err := s.client.newRequest("GET", "teams", &options)
 	if err != nil {
 		return nil, err
@@ -112,6 +127,21 @@ func (s *teams) Create(ctx context.Context, options TeamCreateOptions) (*Team, e
 	if err := options.valid(); err != nil {
 		return nil, err
 	}
+	if options.Strict {
+		var accountID string
+		if options.Account != nil {
+			accountID = options.Account.ID
+		}
+		existing, err := s.ReadByName(ctx, accountID, *options.Name)
+		switch {
+		case err == nil:
+			return nil, fmt.Errorf("%w: team with name '%s' (%s)", ErrResourceAlreadyExists, existing.Name, existing.ID)
+		case errors.Is(err, ErrMultipleResourcesFound):
+			return nil, fmt.Errorf("%w: %v", ErrResourceAlreadyExists, err)
+		case !errors.Is(err, ErrResourceNotFound):
+			return nil, err
+		}
+	}
 	// Make sure we don't send a user provided ID.
 	options.ID = ""
 	req, err := s.client.newRequest("POST", "teams", &options)
@@ -149,6 +179,56 @@ func (s *teams) Read(ctx context.Context, teamID string) (*Team, error) {
 	return t, nil
 }
 
+// ReadByName reads a team by its name within the account. An empty account ID
+// searches all the teams available to the user.
+func (s *teams) ReadByName(ctx context.Context, accountID, name string) (*Team, error) {
+	if accountID != "" && !validStringID(&accountID) {
+		return nil, errors.New("invalid value for account ID")
+	}
+	if !validString(&name) {
+		return nil, errors.New("name is required")
+	}
+
+	options := TeamListOptions{Name: String(name)}
+	if accountID != "" {
+		options.Account = String(accountID)
+	}
+
+	var found []*Team
+	for {
+		tl, err := s.List(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tl.Items {
+			if t.Name == name {
+				found = append(found, t)
+			}
+		}
+		if tl.Pagination == nil || tl.NextPage == 0 {
+			break
+		}
+		options.PageNumber = tl.NextPage
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, ResourceNotFoundError{
+			Message: fmt.Sprintf("Team with name '%s' not found or user unauthorized", name),
+		}
+	case 1:
+		return found[0], nil
+	default:
+		ids := make([]string, len(found))
+		for i, t := range found {
+			ids[i] = t.ID
+		}
+		return nil, MultipleResourcesFoundError{
+			Message: fmt.Sprintf("found %d teams with name '%s': %s", len(found), name, strings.Join(ids, ", ")),
+		}
+	}
+}
+
 // Update settings of an existing team.
 func (s *teams) Update(ctx context.Context, teamID string, options TeamUpdateOptions) (*Team, error) {
 	if !validStringID(&teamID) {
@@ -200,6 +200,51 @@ func TestTeamsCreate(t *testing.T) {
 		assert.Nil(t, team)
 		assert.EqualError(t, err, "invalid value for identity provider ID")
 	})
+
+	t.Run("in strict mode with an existing name", func(t *testing.T) {
+		testTeam, testTeamCleanup := createTeam(t, client, nil)
+		defer testTeamCleanup()
+
+		team, err := client.Teams.Create(ctx, TeamCreateOptions{
+			Account: &Account{ID: defaultAccountID},
+			Name:    String(testTeam.Name),
+			Strict:  true,
+		})
+		assert.Nil(t, team)
+		assert.ErrorIs(t, err, ErrResourceAlreadyExists)
+	})
+}
+
+func TestTeamsReadByName(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	testTeam, testTeamCleanup := createTeam(t, client, nil)
+	defer testTeamCleanup()
+
+	t.Run("when the team exists", func(t *testing.T) {
+		team, err := client.Teams.ReadByName(ctx, defaultAccountID, testTeam.Name)
+		require.NoError(t, err)
+		assert.Equal(t, testTeam.ID, team.ID)
+	})
+
+	t.Run("when the team does not exist", func(t *testing.T) {
+		team, err := client.Teams.ReadByName(ctx, defaultAccountID, "team-nonexisting")
+		assert.Nil(t, team)
+		assert.ErrorIs(t, err, ErrResourceNotFound)
+	})
+
+	t.Run("without a name", func(t *testing.T) {
+		team, err := client.Teams.ReadByName(ctx, defaultAccountID, "")
+		assert.Nil(t, team)
+		assert.EqualError(t, err, "name is required")
+	})
+
+	t.Run("without a valid account ID", func(t *testing.T) {
+		team, err := client.Teams.ReadByName(ctx, badIdentifier, testTeam.Name)
+		assert.Nil(t, team)
+		assert.EqualError(t, err, "invalid value for account ID")
+	})
 }
 
 func TestTeamsRead(t *testing.T) {
@@ -0,0 +1,321 @@
+package scalr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+
+	cleanhttp "github.com/hashicorp/go-cleanhttp"
+	"github.com/svanharmelen/jsonapi"
+)
+
+// DefaultTFCAddress of Terraform Cloud.
+const DefaultTFCAddress = "https://app.terraform.io"
+
+// TFCConfig provides configuration details to access the Terraform Cloud
+// or Terraform Enterprise API the workspaces are imported from.
+type TFCConfig struct {
+	// The address of Terraform Cloud or Terraform Enterprise, defaults to DefaultTFCAddress.
+	Address string
+
+	// API token used to read the workspaces.
+	Token string
+
+	// A custom HTTP client to use.
+	HTTPClient *http.Client
+}
+
+// TFCImporter copies Terraform Cloud workspaces into Scalr.
+type TFCImporter struct {
+	client  *Client
+	baseURL *url.URL
+	token   string
+	http    *http.Client
+}
+
+// TFCImportOptions represents the options for importing a Terraform Cloud workspace.
+type TFCImportOptions struct {
+	// The environment to create the workspace in.
+	Environment *Environment
+
+	// The VCS provider to use for a workspace connected to a VCS repo.
+	// Required if the imported workspace has a VCS repo.
+	VcsProvider *VcsProvider
+
+	// The name of the new workspace, defaults to the name of the imported one.
+	Name *string
+}
+
+func (o TFCImportOptions) valid() error {
+	if o.Environment == nil {
+		return errors.New("environment is required")
+	}
+	if !validStringID(&o.Environment.ID) {
+		return errors.New("invalid value for environment ID")
+	}
+	if o.VcsProvider != nil && !validStringID(&o.VcsProvider.ID) {
+		return errors.New("invalid value for vcs provider ID")
+	}
+	return nil
+}
+
+// TFCImportResult represents the outcome of a workspace import.
+type TFCImportResult struct {
+	Workspace *Workspace
+
+	// Keys of the sensitive variables that were not copied, as their
+	// values cannot be read from Terraform Cloud.
+	SkippedVariables []string
+}
+
+type tfcWorkspace struct {
+	ID               string           `jsonapi:"primary,workspaces"`
+	Name             string           `jsonapi:"attr,name"`
+	AutoApply        bool             `jsonapi:"attr,auto-apply"`
+	ExecutionMode    string           `jsonapi:"attr,execution-mode"`
+	TerraformVersion string           `jsonapi:"attr,terraform-version"`
+	WorkingDirectory string           `jsonapi:"attr,working-directory"`
+	TriggerPrefixes  []string         `jsonapi:"attr,trigger-prefixes"`
+	VCSRepo          *tfcWorkspaceVCS `jsonapi:"attr,vcs-repo"`
+}
+
+type tfcWorkspaceVCS struct {
+	Branch            string `json:"branch"`
+	Identifier        string `json:"identifier"`
+	IngressSubmodules bool   `json:"ingress-submodules"`
+}
+
+type tfcVariable struct {
+	ID          string `jsonapi:"primary,vars"`
+	Key         string `jsonapi:"attr,key"`
+	Value       string `jsonapi:"attr,value"`
+	Description string `jsonapi:"attr,description"`
+	Category    string `jsonapi:"attr,category"`
+	HCL         bool   `jsonapi:"attr,hcl"`
+	Sensitive   bool   `jsonapi:"attr,sensitive"`
+}
+
+// NewTFCImporter creates an importer that reads workspaces from Terraform Cloud
+// and creates them with the given Scalr client.
+func NewTFCImporter(client *Client, cfg *TFCConfig) (*TFCImporter, error) {
+	if client == nil {
+		return nil, errors.New("client is required")
+	}
+	if cfg == nil || cfg.Token == "" {
+		return nil, errors.New("missing Terraform Cloud API token")
+	}
+
+	address := cfg.Address
+	if address == "" {
+		address = DefaultTFCAddress
+	}
+	baseURL, err := url.Parse(strings.TrimSuffix(address, "/") + "/api/v2/")
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %v", err)
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = cleanhttp.DefaultPooledClient()
+	}
+
+	return &TFCImporter{
+		client:  client,
+		baseURL: baseURL,
+		token:   cfg.Token,
+		http:    httpClient,
+	}, nil
+}
+
+// ImportWorkspace reads the workspace of the Terraform Cloud organization and
+// creates an equivalent Scalr workspace with its settings, VCS repo and
+// non-sensitive variables. If any of the resources fails to be created,
+// the new workspace is removed.
+func (i *TFCImporter) ImportWorkspace(ctx context.Context, organization, workspaceName string, options TFCImportOptions) (*TFCImportResult, error) {
+	if !validStringID(&organization) {
+		return nil, errors.New("invalid value for organization")
+	}
+	if !validStringID(&workspaceName) {
+		return nil, errors.New("invalid value for workspace name")
+	}
+	if err := options.valid(); err != nil {
+		return nil, err
+	}
+
+	tw := &tfcWorkspace{}
+	u := fmt.Sprintf("organizations/%s/workspaces/%s", url.QueryEscape(organization), url.QueryEscape(workspaceName))
+	if err := i.get(ctx, u, tw); err != nil {
+		return nil, fmt.Errorf("reading Terraform Cloud workspace: %w", err)
+	}
+
+	vars, err := i.listVariables(ctx, tw.ID)
+	if err != nil {
+		return nil, fmt.Errorf("reading Terraform Cloud variables: %w", err)
+	}
+
+	spec, skipped, err := tfcWorkspaceSpec(tw, vars, options)
+	if err != nil {
+		return nil, err
+	}
+
+	ws, err := i.client.Provisioner.CreateWorkspace(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TFCImportResult{Workspace: ws, SkippedVariables: skipped}, nil
+}
+
+// tfcWorkspaceSpec maps the Terraform Cloud workspace and its variables to the Scalr workspace spec.
+func tfcWorkspaceSpec(tw *tfcWorkspace, vars []*tfcVariable, options TFCImportOptions) (WorkspaceSpec, []string, error) {
+	name := tw.Name
+	if options.Name != nil {
+		name = *options.Name
+	}
+
+	mode := WorkspaceExecutionModeRemote
+	if tw.ExecutionMode == "local" {
+		mode = WorkspaceExecutionModeLocal
+	}
+
+	spec := WorkspaceSpec{
+		Workspace: WorkspaceCreateOptions{
+			Name:          String(name),
+			AutoApply:     Bool(tw.AutoApply),
+			ExecutionMode: WorkspaceExecutionModePtr(mode),
+			Environment:   options.Environment,
+		},
+	}
+	if tw.TerraformVersion != "" {
+		spec.Workspace.TerraformVersion = String(tw.TerraformVersion)
+	}
+	if tw.WorkingDirectory != "" {
+		spec.Workspace.WorkingDirectory = String(tw.WorkingDirectory)
+	}
+
+	if tw.VCSRepo != nil && tw.VCSRepo.Identifier != "" {
+		if options.VcsProvider == nil {
+			return WorkspaceSpec{}, nil, fmt.Errorf("vcs provider is required to import the VCS repo %s", tw.VCSRepo.Identifier)
+		}
+		spec.Workspace.VcsProvider = options.VcsProvider
+		spec.Workspace.VCSRepo = &WorkspaceVCSRepoOptions{
+			Identifier:        String(tw.VCSRepo.Identifier),
+			IngressSubmodules: Bool(tw.VCSRepo.IngressSubmodules),
+		}
+		if tw.VCSRepo.Branch != "" {
+			spec.Workspace.VCSRepo.Branch = String(tw.VCSRepo.Branch)
+		}
+		if len(tw.TriggerPrefixes) > 0 {
+			prefixes := tw.TriggerPrefixes
+			spec.Workspace.VCSRepo.TriggerPrefixes = &prefixes
+		}
+	}
+
+	var skipped []string
+	for _, v := range vars {
+		if v.Sensitive {
+			skipped = append(skipped, v.Key)
+			continue
+		}
+		category := CategoryTerraform
+		if v.Category == "env" {
+			category = CategoryEnv
+		}
+		spec.Variables = append(spec.Variables, VariableCreateOptions{
+			Key:         String(v.Key),
+			Value:       SecretString(v.Value),
+			Description: String(v.Description),
+			Category:    Category(category),
+			HCL:         Bool(v.HCL),
+		})
+	}
+
+	return spec, skipped, nil
+}
+
+// listVariables reads all the variables of the Terraform Cloud workspace.
+func (i *TFCImporter) listVariables(ctx context.Context, workspaceID string) ([]*tfcVariable, error) {
+	u := fmt.Sprintf("workspaces/%s/vars", url.QueryEscape(workspaceID))
+	req, err := i.newRequest(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := i.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := checkTFCResponseCode(resp); err != nil {
+		return nil, err
+	}
+
+	items, err := jsonapi.UnmarshalManyPayload(resp.Body, reflect.TypeOf(&tfcVariable{}))
+	if err != nil {
+		return nil, err
+	}
+
+	vars := make([]*tfcVariable, 0, len(items))
+	for _, item := range items {
+		vars = append(vars, item.(*tfcVariable))
+	}
+	return vars, nil
+}
+
+// get reads a single resource from the Terraform Cloud API into v.
+func (i *TFCImporter) get(ctx context.Context, path string, v interface{}) error {
+	req, err := i.newRequest(ctx, path)
+	if err != nil {
+		return err
+	}
+
+	resp, err := i.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := checkTFCResponseCode(resp); err != nil {
+		return err
+	}
+
+	return jsonapi.UnmarshalPayload(resp.Body, v)
+}
+
+func (i *TFCImporter) newRequest(ctx context.Context, path string) (*http.Request, error) {
+	u, err := i.baseURL.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+i.token)
+	req.Header.Set("Accept", "application/vnd.api+json")
+	req.Header.Set("User-Agent", userAgent)
+
+	return req, nil
+}
+
+// checkTFCResponseCode converts an unsuccessful Terraform Cloud response to an error.
+func checkTFCResponseCode(r *http.Response) error {
+	switch {
+	case r.StatusCode >= 200 && r.StatusCode <= 299:
+		return nil
+	case r.StatusCode == 401:
+		return ErrUnauthorized
+	case r.StatusCode == 404:
+		return ResourceNotFoundError{
+			Message: fmt.Sprintf("%s not found or user unauthorized", r.Request.URL.Path),
+		}
+	default:
+		return fmt.Errorf(r.Status)
+	}
+}
@@ -0,0 +1,112 @@
+package scalr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTFCWorkspaceSpec(t *testing.T) {
+	env := &Environment{ID: "env-123"}
+	tw := &tfcWorkspace{
+		ID:               "ws-tfc",
+		Name:             "network",
+		AutoApply:        true,
+		ExecutionMode:    "agent",
+		TerraformVersion: "1.3.0",
+		WorkingDirectory: "infra",
+		TriggerPrefixes:  []string{"modules"},
+		VCSRepo:          &tfcWorkspaceVCS{Identifier: "org/repo", Branch: "main"},
+	}
+	vars := []*tfcVariable{
+		{Key: "region", Value: "us-east-1", Category: "terraform"},
+		{Key: "AWS_SECRET_ACCESS_KEY", Category: "env", Sensitive: true},
+		{Key: "TF_LOG", Value: "DEBUG", Category: "env"},
+	}
+
+	t.Run("with a vcs provider", func(t *testing.T) {
+		spec, skipped, err := tfcWorkspaceSpec(tw, vars, TFCImportOptions{
+			Environment: env,
+			VcsProvider: &VcsProvider{ID: "vcs-123"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "network", *spec.Workspace.Name)
+		assert.Equal(t, WorkspaceExecutionModeRemote, *spec.Workspace.ExecutionMode)
+		assert.Equal(t, "1.3.0", *spec.Workspace.TerraformVersion)
+		assert.Equal(t, "infra", *spec.Workspace.WorkingDirectory)
+		assert.Equal(t, "org/repo", *spec.Workspace.VCSRepo.Identifier)
+		assert.Equal(t, "main", *spec.Workspace.VCSRepo.Branch)
+		assert.Equal(t, []string{"modules"}, *spec.Workspace.VCSRepo.TriggerPrefixes)
+
+		require.Len(t, spec.Variables, 2)
+		assert.Equal(t, CategoryTerraform, *spec.Variables[0].Category)
+		assert.Equal(t, CategoryEnv, *spec.Variables[1].Category)
+		assert.Equal(t, []string{"AWS_SECRET_ACCESS_KEY"}, skipped)
+	})
+
+	t.Run("without a vcs provider", func(t *testing.T) {
+		_, _, err := tfcWorkspaceSpec(tw, vars, TFCImportOptions{Environment: env})
+		assert.EqualError(t, err, "vcs provider is required to import the VCS repo org/repo")
+	})
+}
+
+func TestTFCImporterRead(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer tfc-token" {
+			w.WriteHeader(401)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		switch r.URL.Path {
+		case "/api/v2/organizations/acme/workspaces/network":
+			fmt.Fprint(w, `{"data":{"id":"ws-tfc","type":"workspaces","attributes":{"name":"network","auto-apply":true,"vcs-repo":{"identifier":"org/repo","branch":"main"}}}}`)
+		case "/api/v2/workspaces/ws-tfc/vars":
+			fmt.Fprint(w, `{"data":[{"id":"var-1","type":"vars","attributes":{"key":"region","value":"us-east-1","category":"terraform"}}]}`)
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token"})
+	require.NoError(t, err)
+
+	importer, err := NewTFCImporter(client, &TFCConfig{Address: ts.URL, Token: "tfc-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	t.Run("reads the workspace and variables", func(t *testing.T) {
+		tw := &tfcWorkspace{}
+		require.NoError(t, importer.get(ctx, "organizations/acme/workspaces/network", tw))
+		assert.Equal(t, "ws-tfc", tw.ID)
+		assert.True(t, tw.AutoApply)
+		assert.Equal(t, "org/repo", tw.VCSRepo.Identifier)
+
+		vars, err := importer.listVariables(ctx, tw.ID)
+		require.NoError(t, err)
+		require.Len(t, vars, 1)
+		assert.Equal(t, "region", vars[0].Key)
+	})
+
+	t.Run("when the workspace does not exist", func(t *testing.T) {
+		_, err := importer.ImportWorkspace(ctx, "acme", "missing", TFCImportOptions{Environment: &Environment{ID: "env-123"}})
+		assert.ErrorIs(t, err, ErrResourceNotFound)
+	})
+
+	t.Run("without an environment", func(t *testing.T) {
+		_, err := importer.ImportWorkspace(ctx, "acme", "network", TFCImportOptions{})
+		assert.EqualError(t, err, "environment is required")
+	})
+
+	t.Run("without a token", func(t *testing.T) {
+		_, err := NewTFCImporter(client, &TFCConfig{})
+		assert.EqualError(t, err, "missing Terraform Cloud API token")
+	})
+}
@@ -1,5 +1,16 @@
 package scalr
 
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Ptr returns a pointer to the given value of any type, e.g. Ptr(WorkspaceExecutionModeRemote).
+func Ptr[T any](v T) *T {
+	return &v
+}
+
 // Bool returns a pointer to the given bool
 func Bool(v bool) *bool {
 	return &v
@@ -39,3 +50,57 @@ func AutoQueueRunsModePtr(v WorkspaceAutoQueueRuns) *WorkspaceAutoQueueRuns {
 func ServiceAccountStatusPtr(v ServiceAccountStatus) *ServiceAccountStatus {
 	return &v
 }
+
+// AccountUserStatusPtr returns a pointer to the given account user status value.
+func AccountUserStatusPtr(v AccountUserStatus) *AccountUserStatus {
+	return &v
+}
+
+// inFilter returns the filter value matching any of the given values,
+// or nil if there are none.
+func inFilter[T ~string](values []T) *string {
+	switch len(values) {
+	case 0:
+		return nil
+	case 1:
+		return String(string(values[0]))
+	}
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = string(v)
+	}
+	return String("in:" + strings.Join(parts, ","))
+}
+
+// TimeRange returns a filter matching the times between from and to, inclusive.
+// A zero time leaves that side of the range open.
+func TimeRange(from, to time.Time) *TimeRangeFilter {
+	return &TimeRangeFilter{After: from, Before: to}
+}
+
+// TimeRangeFilter is a list option matching the times between After and Before, inclusive.
+// A zero time leaves that side of the range open.
+type TimeRangeFilter struct {
+	After  time.Time
+	Before time.Time
+}
+
+// String renders the filter the way the API expects, e.g. "gte:2022-01-02T03:04:05Z".
+func (f TimeRangeFilter) String() string {
+	var parts []string
+	if !f.After.IsZero() {
+		parts = append(parts, "gte:"+f.After.UTC().Format(time.RFC3339))
+	}
+	if !f.Before.IsZero() {
+		parts = append(parts, "lte:"+f.Before.UTC().Format(time.RFC3339))
+	}
+	return strings.Join(parts, ",")
+}
+
+// EncodeValues implements query.Encoder. An open range on both sides is omitted.
+func (f TimeRangeFilter) EncodeValues(key string, v *url.Values) error {
+	if s := f.String(); s != "" {
+		v.Set(key, s)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package scalr
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UnknownFieldHandler is invoked for each attribute or relationship of an
+// API response that has no corresponding field in the SDK struct it is
+// decoded into.
+type UnknownFieldHandler func(f *UnknownField)
+
+// UnknownField represents a response field the SDK does not know about.
+type UnknownField struct {
+	// The method and path of the request.
+	Method string
+	Path   string
+
+	// The JSON:API type of the resource, e.g. "workspaces".
+	ResourceType string
+
+	// The name of the attribute or relationship.
+	Name string
+
+	// Relationship is true if the field is a relationship, not an attribute.
+	Relationship bool
+}
+
+// jsonapiFields holds the attribute and relationship names of a struct.
+type jsonapiFields struct {
+	attrs     map[string]bool
+	relations map[string]bool
+}
+
+// jsonapiModels maps the JSON:API types of the struct and the structs
+// reachable through its relations to their fields.
+func jsonapiModels(t reflect.Type, models map[string]*jsonapiFields) {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+
+	fields := &jsonapiFields{attrs: map[string]bool{}, relations: map[string]bool{}}
+	var related []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := strings.Split(f.Tag.Get("jsonapi"), ",")
+		if len(tag) < 2 {
+			continue
+		}
+		switch tag[0] {
+		case "primary":
+			if _, ok := models[tag[1]]; ok {
+				return
+			}
+			models[tag[1]] = fields
+		case "attr":
+			fields.attrs[tag[1]] = true
+		case "relation":
+			fields.relations[tag[1]] = true
+			related = append(related, f.Type)
+		}
+	}
+	for _, r := range related {
+		jsonapiModels(r, models)
+	}
+}
+
+// reportUnknownFields decodes the raw response body of the request and
+// reports the fields that are absent from the destination type t.
+func (c *Client) reportUnknownFields(req *http.Request, body []byte, t reflect.Type) {
+	var payload struct {
+		Data     json.RawMessage   `json:"data"`
+		Included []json.RawMessage `json:"included"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return
+	}
+
+	var resources []json.RawMessage
+	if len(payload.Data) > 0 && payload.Data[0] == '[' {
+		if err := json.Unmarshal(payload.Data, &resources); err != nil {
+			return
+		}
+	} else if len(payload.Data) > 0 {
+		resources = append(resources, payload.Data)
+	}
+	resources = append(resources, payload.Included...)
+
+	models := map[string]*jsonapiFields{}
+	jsonapiModels(t, models)
+
+	reported := map[UnknownField]bool{}
+	for _, raw := range resources {
+		var resource struct {
+			Type          string                     `json:"type"`
+			Attributes    map[string]json.RawMessage `json:"attributes"`
+			Relationships map[string]json.RawMessage `json:"relationships"`
+		}
+		if err := json.Unmarshal(raw, &resource); err != nil {
+			continue
+		}
+		fields, ok := models[resource.Type]
+		if !ok {
+			continue
+		}
+
+		var unknown []UnknownField
+		for name := range resource.Attributes {
+			if !fields.attrs[name] {
+				unknown = append(unknown, UnknownField{ResourceType: resource.Type, Name: name})
+			}
+		}
+		for name := range resource.Relationships {
+			if !fields.relations[name] {
+				unknown = append(unknown, UnknownField{ResourceType: resource.Type, Name: name, Relationship: true})
+			}
+		}
+		sort.Slice(unknown, func(i, j int) bool { return unknown[i].Name < unknown[j].Name })
+
+		for _, f := range unknown {
+			if reported[f] {
+				continue
+			}
+			reported[f] = true
+
+			f.Method = req.Method
+			f.Path = req.URL.Path
+			c.unknownFieldHandler(&f)
+		}
+	}
+}
@@ -54,6 +54,13 @@ type UserList struct {
 	Items []*User
 }
 
+// List of attributes users can be sorted by.
+const (
+	UserSortByEmail    SortKey = "email"
+	UserSortByUsername SortKey = "username"
+	UserSortByFullName SortKey = "full-name"
+)
+
 // UserListOptions represents the options for listing users.
 type UserListOptions struct {
 	ListOptions
@@ -68,6 +75,10 @@ type UserListOptions struct {
 
 // List all the users.
 func (s *users) List(ctx context.Context, options UserListOptions) (*UserList, error) {
+	if err := validSort(options.Sort, UserSortByEmail, UserSortByUsername, UserSortByFullName); err != nil {
+		return nil, err
+	}
+
 	req, err := s.client.newRequest("GET", "users", &options)
 	if err != nil {
 		return nil, err
@@ -8,6 +8,9 @@ import (
 // A regular expression used to validate common string ID patterns.
 var reStringID = regexp.MustCompile(`^[a-zA-Z0-9\-\._]+$`)
 
+// A regular expression used to validate a single version constraint, e.g. ">= 5.0".
+var reVersionConstraint = regexp.MustCompile(`^(=|!=|>|>=|<|<=|~>)?\s*v?\d+(\.\d+){0,2}(-[0-9A-Za-z.\-]+)?$`)
+
 // validString checks if the given input is present and non-empty.
 func validString(v *string) bool {
 	return v != nil && strings.TrimSpace(*v) != ""
@@ -18,3 +21,14 @@ func validString(v *string) bool {
 func validStringID(v *string) bool {
 	return v != nil && reStringID.MatchString(*v)
 }
+
+// validVersionConstraint checks if the given string is a comma-separated
+// list of version constraints, e.g. ">= 5.0, < 6.0".
+func validVersionConstraint(v string) bool {
+	for _, c := range strings.Split(v, ",") {
+		if !reVersionConstraint.MatchString(strings.TrimSpace(c)) {
+			return false
+		}
+	}
+	return true
+}
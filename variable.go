@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"sort"
 
 	"github.com/google/go-querystring/query"
 )
@@ -28,6 +29,13 @@ type Variables interface {
 
 	// Delete a variable by its ID.
 	Delete(ctx context.Context, variableID string) error
+
+	// Explain reports every definition of a variable key visible to the workspace
+	// and which one of them is used by its runs.
+	Explain(ctx context.Context, workspaceID, key string) (*VariableExplanation, error)
+
+	// ShadowReport lists the environment variables that shadow account variables with the same key.
+	ShadowReport(ctx context.Context, environmentID string) ([]*VariableShadow, error)
 }
 
 // variables implements Variables.
@@ -45,6 +53,16 @@ const (
 	CategoryShell     CategoryType = "shell"
 )
 
+// VariableScope represents a scope the variable is defined on.
+type VariableScope string
+
+// List all available variable scopes, from the least to the most specific one.
+const (
+	VariableScopeAccount     VariableScope = "account"
+	VariableScopeEnvironment VariableScope = "environment"
+	VariableScopeWorkspace   VariableScope = "workspace"
+)
+
 // VariableList represents a list of variables.
 type VariableList struct {
 	*Pagination
@@ -55,7 +73,7 @@ type VariableList struct {
 type Variable struct {
 	ID          string       `jsonapi:"primary,vars"`
 	Key         string       `jsonapi:"attr,key"`
-	Value       string       `jsonapi:"attr,value"`
+	Value       Secret       `jsonapi:"attr,value"`
 	Category    CategoryType `jsonapi:"attr,category"`
 	Description string       `jsonapi:"attr,description"`
 	HCL         bool         `jsonapi:"attr,hcl"`
@@ -68,6 +86,11 @@ type Variable struct {
 	Account     *Account     `jsonapi:"relation,account"`
 }
 
+// List of attributes variables can be sorted by.
+const (
+	VariableSortByKey SortKey = "key"
+)
+
 // VariableListOptions represents the options for listing variables.
 type VariableListOptions struct {
 	ListOptions
@@ -100,6 +123,10 @@ type VariableFilter struct {
 
 // List the variables.
 func (s *variables) List(ctx context.Context, options VariableListOptions) (*VariableList, error) {
+	if err := validSort(options.Sort, VariableSortByKey); err != nil {
+		return nil, err
+	}
+
 	req, err := s.client.newRequest("GET", "vars", &options)
 	if err != nil {
 		return nil, err
@@ -127,7 +154,7 @@ type VariableCreateOptions struct {
 	Key *string `jsonapi:"attr,key"`
 
 	// The value of the variable.
-	Value *string `jsonapi:"attr,value,omitempty"`
+	Value *Secret `jsonapi:"attr,value,omitempty"`
 
 	// Whether this is a Terraform or environment variable.
 	Category *CategoryType `jsonapi:"attr,category"`
@@ -154,6 +181,28 @@ type VariableCreateOptions struct {
 	Account *Account `jsonapi:"relation,account,omitempty"`
 
 	QueryOptions *VariableWriteQueryOptions
+
+	// EnsureUnique makes Create fail with ErrVariableExists if a variable with
+	// the same key and category is already defined in the same scope.
+	EnsureUnique bool
+}
+
+// ErrVariableExists is returned by Create with EnsureUnique when the variable
+// key is already defined in the scope, so the existing variable can be updated
+// instead.
+type ErrVariableExists struct {
+	Key string
+
+	// The ID of the existing variable.
+	ExistingID string
+}
+
+func (e *ErrVariableExists) Error() string {
+	return fmt.Sprintf("variable with key '%s' already exists (%s)", e.Key, e.ExistingID)
+}
+
+func (e *ErrVariableExists) Unwrap() error {
+	return ErrResourceAlreadyExists
 }
 
 func (o VariableCreateOptions) valid() error {
@@ -171,6 +220,15 @@ func (s *variables) Create(ctx context.Context, options VariableCreateOptions) (
 	if err := options.valid(); err != nil {
 		return nil, err
 	}
+	if options.EnsureUnique {
+		existing, err := s.findInScope(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, &ErrVariableExists{Key: existing.Key, ExistingID: existing.ID}
+		}
+	}
 
 	// Make sure we don't send a user provided ID.
 	options.ID = ""
@@ -198,6 +256,58 @@ func (s *variables) Create(ctx context.Context, options VariableCreateOptions) (
 	return v, nil
 }
 
+// findInScope finds the variable with the key and category of the options
+// defined exactly in the scope of the options, it returns nil if there is none.
+func (s *variables) findInScope(ctx context.Context, options VariableCreateOptions) (*Variable, error) {
+	filter := &VariableFilter{Key: options.Key, Category: String(string(*options.Category))}
+	scope, scopeID := VariableScopeAccount, ""
+	switch {
+	case options.Workspace != nil:
+		scope, scopeID = VariableScopeWorkspace, options.Workspace.ID
+		filter.Workspace = String(scopeID)
+	case options.Environment != nil:
+		scope, scopeID = VariableScopeEnvironment, options.Environment.ID
+		filter.Environment = String(scopeID)
+	case options.Account != nil:
+		scopeID = options.Account.ID
+		filter.Account = String(scopeID)
+	}
+	if scopeID != "" && !validStringID(&scopeID) {
+		return nil, fmt.Errorf("invalid value for %s ID", scope)
+	}
+
+	listOptions := VariableListOptions{Filter: filter}
+	for {
+		vl, err := s.List(ctx, listOptions)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range vl.Items {
+			if v.Key != *options.Key || v.Category != *options.Category || variableScope(v) != scope {
+				continue
+			}
+			switch scope {
+			case VariableScopeWorkspace:
+				if v.Workspace.ID == scopeID {
+					return v, nil
+				}
+			case VariableScopeEnvironment:
+				if v.Environment.ID == scopeID {
+					return v, nil
+				}
+			default:
+				return v, nil
+			}
+		}
+		if vl.Pagination == nil || vl.NextPage == 0 {
+			break
+		}
+		listOptions.PageNumber = vl.NextPage
+	}
+
+	return nil, nil
+}
+
 // Read a variable by its ID.
 func (s *variables) Read(ctx context.Context, variableID string) (*Variable, error) {
 	if !validStringID(&variableID) {
@@ -228,7 +338,7 @@ type VariableUpdateOptions struct {
 	Key *string `jsonapi:"attr,key,omitempty"`
 
 	// The value of the variable.
-	Value *string `jsonapi:"attr,value,omitempty"`
+	Value *Secret `jsonapi:"attr,value,omitempty"`
 
 	// The description of the variable.
 	Description *string `jsonapi:"attr,description,omitempty"`
@@ -290,3 +400,246 @@ func (s *variables) Delete(ctx context.Context, variableID string) error {
 
 	return s.client.do(ctx, req, nil)
 }
+
+// VariableExplanation describes how a variable key is resolved for a workspace.
+type VariableExplanation struct {
+	Key string
+
+	// All definitions of the key visible to the workspace, ordered
+	// from the least to the most specific scope.
+	Definitions []*VariableDefinition
+}
+
+// Effective returns the definition used by the workspace runs for the given category,
+// or nil if the key is not defined in that category.
+func (e *VariableExplanation) Effective(category CategoryType) *VariableDefinition {
+	for _, d := range e.Definitions {
+		if d.Effective && d.Variable.Category == category {
+			return d
+		}
+	}
+	return nil
+}
+
+// VariableDefinition represents a single definition of a variable key.
+type VariableDefinition struct {
+	Variable *Variable
+	Scope    VariableScope
+
+	// Whether this definition is the one used by the workspace runs.
+	Effective bool
+
+	// Explains why the definition is or is not used.
+	Reason string
+}
+
+// variableScope returns the scope the variable is defined on.
+func variableScope(v *Variable) VariableScope {
+	switch {
+	case v.Workspace != nil:
+		return VariableScopeWorkspace
+	case v.Environment != nil:
+		return VariableScopeEnvironment
+	default:
+		return VariableScopeAccount
+	}
+}
+
+// variableScopeRank returns the position of the scope in the precedence order.
+func variableScopeRank(scope VariableScope) int {
+	switch scope {
+	case VariableScopeAccount:
+		return 0
+	case VariableScopeEnvironment:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Explain reports every definition of a variable key across the account, environment
+// and workspace scopes, and which one wins. A more specific scope overrides a less
+// specific one unless the latter is final. Each category is resolved independently.
+func (s *variables) Explain(ctx context.Context, workspaceID, key string) (*VariableExplanation, error) {
+	if !validStringID(&workspaceID) {
+		return nil, errors.New("invalid value for workspace ID")
+	}
+	if !validString(&key) {
+		return nil, errors.New("key is required")
+	}
+
+	ws, err := s.client.Workspaces.ReadByID(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if ws.Environment == nil {
+		return nil, fmt.Errorf("workspace %s has no environment", workspaceID)
+	}
+	env, err := s.client.Environments.Read(ctx, ws.Environment.ID)
+	if err != nil {
+		return nil, err
+	}
+	if env.Account == nil {
+		return nil, fmt.Errorf("environment %s has no account", env.ID)
+	}
+
+	options := VariableListOptions{
+		Filter: &VariableFilter{
+			Key:         String(key),
+			Workspace:   String("in:null," + ws.ID),
+			Environment: String("in:null," + env.ID),
+			Account:     String(env.Account.ID),
+		},
+	}
+
+	var definitions []*VariableDefinition
+	for {
+		vl, err := s.List(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range vl.Items {
+			definitions = append(definitions, &VariableDefinition{Variable: v, Scope: variableScope(v)})
+		}
+		if vl.Pagination == nil || vl.NextPage == 0 {
+			break
+		}
+		options.PageNumber = vl.NextPage
+	}
+
+	sort.SliceStable(definitions, func(i, j int) bool {
+		return variableScopeRank(definitions[i].Scope) < variableScopeRank(definitions[j].Scope)
+	})
+
+	// Resolve each category separately: the first final definition wins,
+	// otherwise the most specific one does.
+	winners := make(map[CategoryType]*VariableDefinition)
+	for _, d := range definitions {
+		w, ok := winners[d.Variable.Category]
+		if ok && w.Variable.Final {
+			continue
+		}
+		winners[d.Variable.Category] = d
+	}
+
+	for _, d := range definitions {
+		w := winners[d.Variable.Category]
+		switch {
+		case d == w && d.Variable.Final:
+			d.Effective = true
+			d.Reason = fmt.Sprintf("final on the %s scope and cannot be overridden", d.Scope)
+		case d == w:
+			d.Effective = true
+			d.Reason = fmt.Sprintf("defined on the most specific scope (%s)", d.Scope)
+		case variableScopeRank(d.Scope) > variableScopeRank(w.Scope):
+			d.Reason = fmt.Sprintf("shadowed by the final variable on the %s scope", w.Scope)
+		default:
+			d.Reason = fmt.Sprintf("overridden by the variable on the %s scope", w.Scope)
+		}
+	}
+
+	return &VariableExplanation{Key: key, Definitions: definitions}, nil
+}
+
+// MaskedVariableValue replaces the values of sensitive variables in reports.
+const MaskedVariableValue = "<sensitive>"
+
+// VariableShadow represents an environment variable that shadows an account
+// variable with the same key and category.
+type VariableShadow struct {
+	Key      string
+	Category CategoryType
+
+	EnvironmentVariableID string
+	EnvironmentValue      string
+
+	AccountVariableID string
+	AccountValue      string
+
+	// Whether the account variable is final, so the environment
+	// variable does not take effect.
+	AccountFinal bool
+}
+
+// maskedValue returns the value of the variable, masked if it is sensitive.
+func maskedValue(v *Variable) string {
+	if v.Sensitive {
+		return MaskedVariableValue
+	}
+	return v.Value.Reveal()
+}
+
+// ShadowReport lists the variables defined on the environment scope that shadow
+// account-scope variables of the same key and category, sorted by key. The values
+// of sensitive variables are masked.
+func (s *variables) ShadowReport(ctx context.Context, environmentID string) ([]*VariableShadow, error) {
+	if !validStringID(&environmentID) {
+		return nil, errors.New("invalid value for environment ID")
+	}
+
+	env, err := s.client.Environments.Read(ctx, environmentID)
+	if err != nil {
+		return nil, err
+	}
+	if env.Account == nil {
+		return nil, fmt.Errorf("environment %s has no account", env.ID)
+	}
+
+	options := VariableListOptions{
+		Filter: &VariableFilter{
+			Workspace:   String("null"),
+			Environment: String("in:null," + env.ID),
+			Account:     String(env.Account.ID),
+		},
+	}
+
+	type scopedKey struct {
+		key      string
+		category CategoryType
+	}
+	accountVars := make(map[scopedKey]*Variable)
+	var envVars []*Variable
+	for {
+		vl, err := s.List(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range vl.Items {
+			switch variableScope(v) {
+			case VariableScopeAccount:
+				accountVars[scopedKey{v.Key, v.Category}] = v
+			case VariableScopeEnvironment:
+				envVars = append(envVars, v)
+			}
+		}
+		if vl.Pagination == nil || vl.NextPage == 0 {
+			break
+		}
+		options.PageNumber = vl.NextPage
+	}
+
+	var report []*VariableShadow
+	for _, ev := range envVars {
+		av, ok := accountVars[scopedKey{ev.Key, ev.Category}]
+		if !ok {
+			continue
+		}
+		report = append(report, &VariableShadow{
+			Key:                   ev.Key,
+			Category:              ev.Category,
+			EnvironmentVariableID: ev.ID,
+			EnvironmentValue:      maskedValue(ev),
+			AccountVariableID:     av.ID,
+			AccountValue:          maskedValue(av),
+			AccountFinal:          av.Final,
+		})
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Key != report[j].Key {
+			return report[i].Key < report[j].Key
+		}
+		return report[i].Category < report[j].Category
+	})
+
+	return report, nil
+}
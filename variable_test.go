@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -22,7 +24,7 @@ func TestVariablesCreate(t *testing.T) {
 	t.Run("when options has an empty string value", func(t *testing.T) {
 		options := VariableCreateOptions{
 			Key:         String(randomVariableKey(t)),
-			Value:       String(""),
+			Value:       SecretString(""),
 			Category:    Category(CategoryShell),
 			Description: String("random variable test"),
 			Workspace:   wsTest,
@@ -50,13 +52,13 @@ func TestVariablesCreate(t *testing.T) {
 
 		assert.NotEmpty(t, v.ID)
 		assert.Equal(t, *options.Key, v.Key)
-		assert.Equal(t, "", v.Value)
+		assert.Empty(t, v.Value)
 		assert.Equal(t, *options.Category, v.Category)
 	})
 
 	t.Run("when options is missing key", func(t *testing.T) {
 		options := VariableCreateOptions{
-			Value:     String(randomString(t)),
+			Value:     SecretString(randomString(t)),
 			Category:  Category(CategoryShell),
 			Workspace: wsTest,
 		}
@@ -68,7 +70,7 @@ func TestVariablesCreate(t *testing.T) {
 	t.Run("when options has an empty key", func(t *testing.T) {
 		options := VariableCreateOptions{
 			Key:       String(""),
-			Value:     String(randomString(t)),
+			Value:     SecretString(randomString(t)),
 			Category:  Category(CategoryShell),
 			Workspace: wsTest,
 		}
@@ -80,7 +82,7 @@ func TestVariablesCreate(t *testing.T) {
 	t.Run("when options is missing category", func(t *testing.T) {
 		options := VariableCreateOptions{
 			Key:       String(randomVariableKey(t)),
-			Value:     String(randomString(t)),
+			Value:     SecretString(randomString(t)),
 			Workspace: wsTest,
 		}
 
@@ -91,7 +93,7 @@ func TestVariablesCreate(t *testing.T) {
 	t.Run("when options is missing account", func(t *testing.T) {
 		options := VariableCreateOptions{
 			Key:         String(randomVariableKey(t)),
-			Value:       String(randomString(t)),
+			Value:       SecretString(randomString(t)),
 			Category:    Category(CategoryShell),
 			Environment: wsTest.Environment,
 			Workspace:   wsTest,
@@ -104,7 +106,7 @@ func TestVariablesCreate(t *testing.T) {
 	t.Run("when options is missing environment", func(t *testing.T) {
 		options := VariableCreateOptions{
 			Key:       String(randomVariableKey(t)),
-			Value:     String(randomString(t)),
+			Value:     SecretString(randomString(t)),
 			Category:  Category(CategoryShell),
 			Account:   account,
 			Workspace: wsTest,
@@ -117,7 +119,7 @@ func TestVariablesCreate(t *testing.T) {
 	t.Run("when options is missing workspace", func(t *testing.T) {
 		options := VariableCreateOptions{
 			Key:         String(randomVariableKey(t)),
-			Value:       String(randomString(t)),
+			Value:       SecretString(randomString(t)),
 			Category:    Category(CategoryShell),
 			Account:     account,
 			Environment: wsTest.Environment,
@@ -130,7 +132,7 @@ func TestVariablesCreate(t *testing.T) {
 	t.Run("when options is missing account, environment, workspace", func(t *testing.T) {
 		options := VariableCreateOptions{
 			Key:       String(randomVariableKey(t)),
-			Value:     String(randomString(t)),
+			Value:     SecretString(randomString(t)),
 			Category:  Category(CategoryShell),
 			Workspace: wsTest,
 		}
@@ -196,7 +198,7 @@ func TestVariablesUpdate(t *testing.T) {
 	t.Run("with valid options", func(t *testing.T) {
 		options := VariableUpdateOptions{
 			Key:         String("newname"),
-			Value:       String("newvalue"),
+			Value:       SecretString("newvalue"),
 			Description: String("newdescription"),
 			HCL:         Bool(true),
 		}
@@ -350,7 +352,7 @@ func TestVariablesList(t *testing.T) {
 
 		terraformVariable, err := client.Variables.Create(ctx, VariableCreateOptions{
 			Key:       String(randomVariableKey(t)),
-			Value:     String(randomString(t)),
+			Value:     SecretString(randomString(t)),
 			Category:  Category(CategoryTerraform),
 			Workspace: workspace,
 		})
@@ -367,7 +369,7 @@ func TestVariablesList(t *testing.T) {
 
 		envVariable, err := client.Variables.Create(ctx, VariableCreateOptions{
 			Key:       String(randomVariableKey(t)),
-			Value:     String(randomString(t)),
+			Value:     SecretString(randomString(t)),
 			Category:  Category(CategoryEnv),
 			Workspace: workspace,
 		})
@@ -419,7 +421,7 @@ func TestVariablesList(t *testing.T) {
 
 		fooVariable, err := client.Variables.Create(ctx, VariableCreateOptions{
 			Key:       String("foo"),
-			Value:     String(randomString(t)),
+			Value:     SecretString(randomString(t)),
 			Category:  Category(CategoryTerraform),
 			Workspace: workspace,
 		})
@@ -436,7 +438,7 @@ func TestVariablesList(t *testing.T) {
 
 		barVariable, err := client.Variables.Create(ctx, VariableCreateOptions{
 			Key:       String("bar"),
-			Value:     String(randomString(t)),
+			Value:     SecretString(randomString(t)),
 			Category:  Category(CategoryTerraform),
 			Workspace: workspace,
 		})
@@ -453,7 +455,7 @@ func TestVariablesList(t *testing.T) {
 
 		bazVariable, err := client.Variables.Create(ctx, VariableCreateOptions{
 			Key:       String("baz"),
-			Value:     String(randomString(t)),
+			Value:     SecretString(randomString(t)),
 			Category:  Category(CategoryEnv),
 			Workspace: workspace,
 		})
@@ -485,3 +487,189 @@ func TestVariablesList(t *testing.T) {
 		assert.ElementsMatch(t, expectedIds, responseIds)
 	})
 }
+
+func TestVariablesExplain(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	envTest, envTestCleanup := createEnvironment(t, client)
+	defer envTestCleanup()
+
+	wsTest, wsTestCleanup := createWorkspace(t, client, envTest)
+	defer wsTestCleanup()
+
+	envVariable, envVariableCleanup := createVariable(t, client, nil, envTest, nil)
+	defer envVariableCleanup()
+
+	wsVariable, err := client.Variables.Create(ctx, VariableCreateOptions{
+		Key:       String(envVariable.Key),
+		Value:     SecretString(randomString(t)),
+		Category:  Category(CategoryEnv),
+		Workspace: wsTest,
+	})
+	require.NoError(t, err)
+	defer func() {
+		if err := client.Variables.Delete(ctx, wsVariable.ID); err != nil {
+			t.Errorf("Error destroying variable! WARNING: Dangling resources\n"+
+				"may exist! The full error is shown below.\n\n"+
+				"Variable: %s\nError: %s", wsVariable.Key, err)
+		}
+	}()
+
+	t.Run("when the workspace overrides the environment", func(t *testing.T) {
+		e, err := client.Variables.Explain(ctx, wsTest.ID, envVariable.Key)
+		require.NoError(t, err)
+		require.Len(t, e.Definitions, 2)
+
+		assert.Equal(t, VariableScopeEnvironment, e.Definitions[0].Scope)
+		assert.Equal(t, envVariable.ID, e.Definitions[0].Variable.ID)
+		assert.False(t, e.Definitions[0].Effective)
+
+		assert.Equal(t, VariableScopeWorkspace, e.Definitions[1].Scope)
+		assert.Equal(t, wsVariable.ID, e.Definitions[1].Variable.ID)
+		assert.True(t, e.Definitions[1].Effective)
+
+		effective := e.Effective(CategoryEnv)
+		require.NotNil(t, effective)
+		assert.Equal(t, wsVariable.ID, effective.Variable.ID)
+		assert.Nil(t, e.Effective(CategoryTerraform))
+	})
+
+	t.Run("when the key is not defined", func(t *testing.T) {
+		e, err := client.Variables.Explain(ctx, wsTest.ID, randomVariableKey(t))
+		require.NoError(t, err)
+		assert.Empty(t, e.Definitions)
+	})
+
+	t.Run("with invalid workspace ID", func(t *testing.T) {
+		e, err := client.Variables.Explain(ctx, badIdentifier, envVariable.Key)
+		assert.Nil(t, e)
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+
+	t.Run("without a key", func(t *testing.T) {
+		e, err := client.Variables.Explain(ctx, wsTest.ID, "")
+		assert.Nil(t, e)
+		assert.EqualError(t, err, "key is required")
+	})
+}
+
+func TestVariablesShadowReport(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	envTest, envTestCleanup := createEnvironment(t, client)
+	defer envTestCleanup()
+
+	key := randomVariableKey(t)
+	accVariable, err := client.Variables.Create(ctx, VariableCreateOptions{
+		Key:      String(key),
+		Value:    SecretString("account"),
+		Category: Category(CategoryEnv),
+		Account:  &Account{ID: defaultAccountID},
+	})
+	require.NoError(t, err)
+	defer client.Variables.Delete(ctx, accVariable.ID)
+
+	envVariable, err := client.Variables.Create(ctx, VariableCreateOptions{
+		Key:         String(key),
+		Value:       SecretString("environment"),
+		Category:    Category(CategoryEnv),
+		Sensitive:   Bool(true),
+		Environment: envTest,
+		Account:     &Account{ID: defaultAccountID},
+	})
+	require.NoError(t, err)
+	defer client.Variables.Delete(ctx, envVariable.ID)
+
+	t.Run("when the environment shadows the account", func(t *testing.T) {
+		report, err := client.Variables.ShadowReport(ctx, envTest.ID)
+		require.NoError(t, err)
+		require.Len(t, report, 1)
+
+		assert.Equal(t, key, report[0].Key)
+		assert.Equal(t, envVariable.ID, report[0].EnvironmentVariableID)
+		assert.Equal(t, MaskedVariableValue, report[0].EnvironmentValue)
+		assert.Equal(t, accVariable.ID, report[0].AccountVariableID)
+		assert.Equal(t, "account", report[0].AccountValue)
+		assert.False(t, report[0].AccountFinal)
+	})
+
+	t.Run("with invalid environment ID", func(t *testing.T) {
+		report, err := client.Variables.ShadowReport(ctx, badIdentifier)
+		assert.Nil(t, report)
+		assert.EqualError(t, err, "invalid value for environment ID")
+	})
+}
+
+func TestVariablesCreateEnsureUnique(t *testing.T) {
+	var created bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/iacp/v3/vars", r.URL.Path)
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		if r.Method == "POST" {
+			created = true
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"data":{"id":"var-new","type":"vars","attributes":{"key":"region","category":"env"}}}`)
+			return
+		}
+
+		assert.Equal(t, "region", r.URL.Query().Get("filter[key]"))
+		assert.Equal(t, "env", r.URL.Query().Get("filter[category]"))
+		// The environment variable is listed along with the ones of its workspaces.
+		envVariable := ""
+		if r.URL.Query().Get("filter[environment]") == "env-123" {
+			envVariable = `,{"id":"var-env","type":"vars","attributes":{"key":"region","category":"env"},` +
+				`"relationships":{"environment":{"data":{"id":"env-123","type":"environments"}}}}`
+		}
+		fmt.Fprintf(w, `{"data":[{"id":"var-ws","type":"vars","attributes":{"key":"region","category":"env"},`+
+			`"relationships":{"workspace":{"data":{"id":"ws-123","type":"workspaces"}},`+
+			`"environment":{"data":{"id":"env-123","type":"environments"}}}}%s]}`, envVariable)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("when the key exists in the scope", func(t *testing.T) {
+		created = false
+		v, err := client.Variables.Create(ctx, VariableCreateOptions{
+			Key:          String("region"),
+			Category:     Category(CategoryEnv),
+			Environment:  &Environment{ID: "env-123"},
+			EnsureUnique: true,
+		})
+		assert.Nil(t, v)
+		assert.False(t, created)
+
+		var exists *ErrVariableExists
+		require.ErrorAs(t, err, &exists)
+		assert.Equal(t, "var-env", exists.ExistingID)
+		assert.ErrorIs(t, err, ErrResourceAlreadyExists)
+		assert.EqualError(t, err, "variable with key 'region' already exists (var-env)")
+	})
+
+	t.Run("when the key exists in another scope only", func(t *testing.T) {
+		created = false
+		v, err := client.Variables.Create(ctx, VariableCreateOptions{
+			Key:          String("region"),
+			Category:     Category(CategoryEnv),
+			Environment:  &Environment{ID: "env-other"},
+			EnsureUnique: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "var-new", v.ID)
+		assert.True(t, created)
+	})
+
+	t.Run("with invalid scope ID", func(t *testing.T) {
+		_, err := client.Variables.Create(ctx, VariableCreateOptions{
+			Key:          String("region"),
+			Category:     Category(CategoryEnv),
+			Workspace:    &Workspace{ID: badIdentifier},
+			EnsureUnique: true,
+		})
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+}
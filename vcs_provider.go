@@ -58,7 +58,17 @@ type VcsProvidersList struct {
 // OAuth contains the properties required for 'oauth2' authorization type.
 type OAuth struct {
 	ClientId     string `json:"client-id"`
-	ClientSecret string `json:"client-secret"`
+	ClientSecret Secret `json:"client-secret"`
+}
+
+func (o *OAuth) revealSecrets() interface{} {
+	if o == nil {
+		return nil
+	}
+	return struct {
+		ClientId     string `json:"client-id"`
+		ClientSecret string `json:"client-secret"`
+	}{o.ClientId, o.ClientSecret.Reveal()}
 }
 
 // VcsProvider represents a Scalr IACP VcsProvider.
@@ -69,7 +79,7 @@ type VcsProvider struct {
 	VcsType  VcsType  `jsonapi:"attr,vcs-type"`
 	AuthType AuthType `jsonapi:"attr,auth-type"`
 	OAuth    *OAuth   `jsonapi:"attr,oauth"`
-	Token    *string  `jsonapi:"attr,token"`
+	Token    Secret   `jsonapi:"attr,token"`
 	Username *string  `jsonapi:"attr,username"`
 	IsShared bool     `jsonapi:"attr,is-shared"`
 
@@ -124,7 +134,7 @@ type VcsProviderCreateOptions struct {
 	VcsType  VcsType  `jsonapi:"attr,vcs-type"`
 	AuthType AuthType `jsonapi:"attr,auth-type"`
 	OAuth    *OAuth   `jsonapi:"attr,oauth"`
-	Token    string   `jsonapi:"attr,token"`
+	Token    Secret   `jsonapi:"attr,token"`
 	Url      *string  `jsonapi:"attr,url"`
 	Username *string  `jsonapi:"attr,username"`
 	IsShared *bool    `jsonapi:"attr,is-shared,omitempty"`
@@ -132,7 +142,8 @@ type VcsProviderCreateOptions struct {
 	// Relations
 	Environments []*Environment `jsonapi:"relation,environments,omitempty"`
 	Account      *Account       `jsonapi:"relation,account,omitempty"`
-	AgentPool    *AgentPool     `jsonapi:"relation,agent-pool,omitempty"`
+	// The agent pool used to access a VCS in a private network, must be VCS-enabled.
+	AgentPool *AgentPool `jsonapi:"relation,agent-pool,omitempty"`
 }
 
 // Create is used to create a new vcs provider.
@@ -140,6 +151,10 @@ func (s *vcsProviders) Create(ctx context.Context, options VcsProviderCreateOpti
 	// Make sure we don't send a user provided ID.
 	options.ID = ""
 
+	if err := s.validAgentPool(ctx, options.AgentPool); err != nil {
+		return nil, err
+	}
+
 	req, err := s.client.newRequest("POST", "vcs-providers", &options)
 	if err != nil {
 		return nil, err
@@ -180,14 +195,15 @@ type VcsProviderUpdateOptions struct {
 	// For internal use only!
 	ID       string  `jsonapi:"primary,vcs-providers"`
 	Name     *string `jsonapi:"attr,name,omitempty"`
-	Token    *string `jsonapi:"attr,token,omitempty"`
+	Token    *Secret `jsonapi:"attr,token,omitempty"`
 	Url      *string `jsonapi:"attr,url,omitempty"`
 	Username *string `jsonapi:"attr,username,omitempty"`
 	IsShared *bool   `jsonapi:"attr,is-shared,omitempty"`
 
 	// Relations
 	Environments []*Environment `jsonapi:"relation,environments"`
-	AgentPool    *AgentPool     `jsonapi:"relation,agent-pool"`
+	// The agent pool used to access a VCS in a private network, nil detaches the pool.
+	AgentPool *AgentPool `jsonapi:"relation,agent-pool"`
 }
 
 // Update settings of an existing vcs provider.
@@ -198,6 +214,10 @@ func (s *vcsProviders) Update(ctx context.Context, vcsProviderId string, options
 	// Make sure we don't send a user provided ID.
 	options.ID = ""
 
+	if err := s.validAgentPool(ctx, options.AgentPool); err != nil {
+		return nil, err
+	}
+
 	u := fmt.Sprintf("vcs-providers/%s", url.QueryEscape(vcsProviderId))
 	req, err := s.client.newRequest("PATCH", u, &options)
 	if err != nil {
@@ -227,3 +247,23 @@ func (s *vcsProviders) Delete(ctx context.Context, vcsProviderId string) error {
 
 	return s.client.do(ctx, req, nil)
 }
+
+// validAgentPool makes sure the agent pool, if any, can serve VCS requests.
+func (s *vcsProviders) validAgentPool(ctx context.Context, agentPool *AgentPool) error {
+	if agentPool == nil {
+		return nil
+	}
+	if !validStringID(&agentPool.ID) {
+		return errors.New("invalid value for agent pool ID")
+	}
+
+	ap, err := s.client.AgentPools.Read(ctx, agentPool.ID)
+	if err != nil {
+		return err
+	}
+	if !ap.VcsEnabled {
+		return fmt.Errorf("agent pool %s is not VCS-enabled", ap.ID)
+	}
+
+	return nil
+}
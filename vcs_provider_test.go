@@ -3,6 +3,8 @@ package scalr
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
@@ -69,7 +71,7 @@ func TestVcsProvidersCreate(t *testing.T) {
 			Name:     String("vcs-" + randomString(t)),
 			VcsType:  Github,
 			AuthType: PersonalToken,
-			Token:    os.Getenv("GITHUB_TOKEN"),
+			Token:    Secret(os.Getenv("GITHUB_TOKEN")),
 
 			Environments: []*Environment{envTest},
 			Account:      &Account{ID: defaultAccountID},
@@ -99,7 +101,7 @@ func TestVcsProvidersCreate(t *testing.T) {
 			Name:     String("vcs-" + randomString(t)),
 			VcsType:  Github,
 			AuthType: PersonalToken,
-			Token:    os.Getenv("GITHUB_TOKEN"),
+			Token:    Secret(os.Getenv("GITHUB_TOKEN")),
 			IsShared: Bool(true),
 
 			Account: &Account{ID: defaultAccountID},
@@ -124,7 +126,7 @@ func TestVcsProvidersCreate(t *testing.T) {
 			Name:     String("vcs-" + randomString(t)),
 			VcsType:  Github,
 			AuthType: PersonalToken,
-			Token:    os.Getenv("GITHUB_TOKEN"),
+			Token:    Secret(os.Getenv("GITHUB_TOKEN")),
 
 			Environments: []*Environment{envTest},
 			Account:      &Account{ID: defaultAccountID},
@@ -132,7 +134,20 @@ func TestVcsProvidersCreate(t *testing.T) {
 		}
 
 		_, err := client.VcsProviders.Create(ctx, options)
-		require.Error(t, err)
+		assert.EqualError(t, err, fmt.Sprintf("agent pool %s is not VCS-enabled", ap.ID))
+	})
+
+	t.Run("with invalid agent-pool ID", func(t *testing.T) {
+		options := VcsProviderCreateOptions{
+			Name:      String("vcs-" + randomString(t)),
+			VcsType:   Github,
+			AuthType:  PersonalToken,
+			Token:     Secret(os.Getenv("GITHUB_TOKEN")),
+			AgentPool: &AgentPool{ID: badIdentifier},
+		}
+
+		_, err := client.VcsProviders.Create(ctx, options)
+		assert.EqualError(t, err, "invalid value for agent pool ID")
 	})
 
 	t.Run("when options has an invalid environment", func(t *testing.T) {
@@ -284,3 +299,42 @@ func TestVcsProvidersDelete(t *testing.T) {
 		assert.EqualError(t, err, "invalid value for vcs provider ID")
 	})
 }
+
+func TestVcsProvidersAgentPoolValidation(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		switch r.URL.Path {
+		case "/api/iacp/v3/agent-pools/apool-vcs":
+			fmt.Fprint(w, `{"data":{"id":"apool-vcs","type":"agent-pools","attributes":{"vcs-enabled":true}}}`)
+		case "/api/iacp/v3/agent-pools/apool-runs":
+			fmt.Fprint(w, `{"data":{"id":"apool-runs","type":"agent-pools","attributes":{"vcs-enabled":false}}}`)
+		case "/api/iacp/v3/vcs-providers/vcs-123":
+			assert.Equal(t, "PATCH", r.Method)
+			fmt.Fprint(w, `{"data":{"id":"vcs-123","type":"vcs-providers",`+
+				`"relationships":{"agent-pool":{"data":{"id":"apool-vcs","type":"agent-pools"}}}}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("with vcs-enabled agent pool", func(t *testing.T) {
+		vcs, err := client.VcsProviders.Update(ctx, "vcs-123", VcsProviderUpdateOptions{
+			AgentPool: &AgentPool{ID: "apool-vcs"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "apool-vcs", vcs.AgentPool.ID)
+	})
+
+	t.Run("with agent pool that is not vcs-enabled", func(t *testing.T) {
+		vcs, err := client.VcsProviders.Update(ctx, "vcs-123", VcsProviderUpdateOptions{
+			AgentPool: &AgentPool{ID: "apool-runs"},
+		})
+		assert.Nil(t, vcs)
+		assert.EqualError(t, err, "agent pool apool-runs is not VCS-enabled")
+	})
+}
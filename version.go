@@ -0,0 +1,173 @@
+package scalr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// version is a parsed Terraform version, e.g. "1.5.7" or "1.6.0-beta1".
+type version struct {
+	segments   [3]int
+	prerelease string
+}
+
+// parseVersion parses a version with up to three numeric segments and
+// returns the number of segments given.
+func parseVersion(v string) (version, int, error) {
+	var parsed version
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.Index(v, "-"); i >= 0 {
+		parsed.prerelease = v[i+1:]
+		v = v[:i]
+	}
+	parts := strings.Split(v, ".")
+	if len(parts) > 3 {
+		return parsed, 0, fmt.Errorf("invalid version %q", v)
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return parsed, 0, fmt.Errorf("invalid version %q", v)
+		}
+		parsed.segments[i] = n
+	}
+	return parsed, len(parts), nil
+}
+
+// compare returns -1, 0 or 1 if the version is lower, equal or greater than
+// the other one. A prerelease is lower than the release of the same version.
+func (v version) compare(o version) int {
+	for i := range v.segments {
+		switch {
+		case v.segments[i] < o.segments[i]:
+			return -1
+		case v.segments[i] > o.segments[i]:
+			return 1
+		}
+	}
+	switch {
+	case v.prerelease == o.prerelease:
+		return 0
+	case v.prerelease == "":
+		return 1
+	case o.prerelease == "":
+		return -1
+	}
+	return comparePrerelease(v.prerelease, o.prerelease)
+}
+
+// comparePrerelease compares two prereleases, e.g. "beta2" and "beta10", by
+// their runs of digits and non-digits. The runs of digits are compared
+// numerically, so beta2 is lower than beta10, and the others lexically.
+func comparePrerelease(a, b string) int {
+	ra, rb := prereleaseRuns(a), prereleaseRuns(b)
+	for i := 0; i < len(ra) && i < len(rb); i++ {
+		if ra[i] == rb[i] {
+			continue
+		}
+		na, errA := strconv.Atoi(ra[i])
+		nb, errB := strconv.Atoi(rb[i])
+		switch {
+		case errA == nil && errB == nil && na != nb:
+			if na < nb {
+				return -1
+			}
+			return 1
+		case errA == nil && errB != nil:
+			// A numeric identifier is lower than an alphanumeric one.
+			return -1
+		case errA != nil && errB == nil:
+			return 1
+		case ra[i] < rb[i]:
+			return -1
+		default:
+			return 1
+		}
+	}
+	switch {
+	case len(ra) < len(rb):
+		return -1
+	case len(ra) > len(rb):
+		return 1
+	}
+	return 0
+}
+
+// prereleaseRuns splits the prerelease into the runs of digits and
+// non-digits, dropping the dot separators, e.g. "rc1.2" into "rc", "1", "2".
+func prereleaseRuns(s string) []string {
+	var runs []string
+	start := 0
+	for i := 1; i <= len(s); i++ {
+		if i < len(s) && s[i] != '.' && s[i-1] != '.' && isDigit(s[i]) == isDigit(s[i-1]) {
+			continue
+		}
+		if run := s[start:i]; run != "" && run != "." {
+			runs = append(runs, run)
+		}
+		start = i
+	}
+	return runs
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// versionSatisfies reports whether the version matches all the comma-separated
+// constraints, e.g. ">= 1.3, < 2.0" or "~> 1.5". The operators follow the
+// Terraform version constraint syntax.
+func versionSatisfies(v, constraints string) (bool, error) {
+	parsed, _, err := parseVersion(v)
+	if err != nil {
+		return false, err
+	}
+	if !validVersionConstraint(constraints) {
+		return false, fmt.Errorf("invalid version constraint %q", constraints)
+	}
+
+	for _, c := range strings.Split(constraints, ",") {
+		c = strings.TrimSpace(c)
+		rest := strings.TrimLeft(c, "=!<>~")
+		op := c[:len(c)-len(rest)]
+		want, n, err := parseVersion(rest)
+		if err != nil {
+			return false, err
+		}
+
+		cmp := parsed.compare(want)
+		var ok bool
+		switch op {
+		case "", "=":
+			ok = cmp == 0
+		case "!=":
+			ok = cmp != 0
+		case ">":
+			ok = cmp > 0
+		case ">=":
+			ok = cmp >= 0
+		case "<":
+			ok = cmp < 0
+		case "<=":
+			ok = cmp <= 0
+		case "~>":
+			// Only the rightmost given segment may increase, e.g. "~> 1.5" allows
+			// 1.6 but not 2.0, while "~> 1.5.0" allows 1.5.9 but not 1.6.0.
+			upper := want
+			upper.prerelease = ""
+			if n < 2 {
+				n = 2
+			}
+			upper.segments[n-2]++
+			for i := n - 1; i < len(upper.segments); i++ {
+				upper.segments[i] = 0
+			}
+			ok = cmp >= 0 && parsed.compare(upper) < 0
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,102 @@
+package scalr
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+)
+
+// WarningHandler is invoked for each API response that carries
+// deprecation or warning headers.
+type WarningHandler func(w *APIWarning)
+
+// APIWarning represents the deprecation notices and warnings returned
+// by the Scalr API for a single request.
+type APIWarning struct {
+	// The method and path of the request that caused the warning.
+	Method string
+	Path   string
+
+	// Messages of the Warning headers.
+	Messages []string
+
+	// Deprecated is true if the endpoint is marked as deprecated with
+	// the Deprecation header. Deprecation holds the raw header value,
+	// which may be "true" or the date of the deprecation.
+	Deprecated  bool
+	Deprecation string
+
+	// Sunset is the date the endpoint will stop responding, from
+	// the Sunset header. It is zero if the header is absent.
+	Sunset time.Time
+
+	// Link to the documentation of the deprecation, if any.
+	Link string
+}
+
+// responseWarning returns the warning carried by the response headers,
+// or nil if there is none.
+func responseWarning(resp *http.Response) *APIWarning {
+	w := &APIWarning{}
+	for _, v := range resp.Header.Values("Warning") {
+		if msg := parseWarningHeader(v); msg != "" {
+			w.Messages = append(w.Messages, msg)
+		}
+	}
+	if v := resp.Header.Get("Deprecation"); v != "" && v != "false" {
+		w.Deprecated = true
+		w.Deprecation = v
+	}
+	if v := resp.Header.Get("Sunset"); v != "" {
+		if t, err := http.ParseTime(v); err == nil {
+			w.Sunset = t
+		}
+	}
+	if len(w.Messages) == 0 && !w.Deprecated && w.Sunset.IsZero() {
+		return nil
+	}
+
+	for _, v := range resp.Header.Values("Link") {
+		for _, link := range strings.Split(v, ",") {
+			if strings.Contains(link, `rel="deprecation"`) || strings.Contains(link, `rel="sunset"`) {
+				w.Link = strings.Trim(strings.TrimSpace(strings.SplitN(link, ";", 2)[0]), "<>")
+			}
+		}
+	}
+	if resp.Request != nil {
+		w.Method = resp.Request.Method
+		w.Path = resp.Request.URL.Path
+	}
+	return w
+}
+
+// parseWarningHeader extracts the text of a Warning header in the
+// `<code> <agent> "<text>" ["<date>"]` format. Values that do not
+// follow the format are returned as is.
+func parseWarningHeader(v string) string {
+	v = strings.TrimSpace(v)
+	start := strings.Index(v, `"`)
+	if start < 0 {
+		return v
+	}
+	end := strings.Index(v[start+1:], `"`)
+	if end < 0 {
+		return v
+	}
+	return v[start+1 : start+1+end]
+}
+
+// warnDeprecated reports the use of a deprecated client API to the WarningHandler, if any.
+func (c *Client) warnDeprecated(req *retryablehttp.Request, message string) {
+	if c.warningHandler == nil {
+		return
+	}
+	c.warningHandler(&APIWarning{
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		Messages:   []string{message},
+		Deprecated: true,
+	})
+}
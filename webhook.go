@@ -15,6 +15,10 @@ var _ Webhooks = (*webhooks)(nil)
 // IACP API supports.
 //
 // IACP API docs: https://www.scalr.com/docs/en/latest/api/index.html
+//
+// Deprecated: Webhooks and Endpoints are replaced by WebhookIntegrations,
+// use NewWebhookIntegrationOptions to migrate. The service is going to be
+// removed in the next major version. Each call is reported to the WarningHandler.
 type Webhooks interface {
 	// List the webhooks.
 	List(ctx context.Context, options WebhookListOptions) (*WebhookList, error)
@@ -87,6 +91,7 @@ func (s *webhooks) List(ctx context.Context, options WebhookListOptions) (*Webho
 	if err != nil {
 		return nil, err
 	}
+	s.client.warnDeprecated(req, webhooksDeprecation)
 
 	wl := &WebhookList{}
 	err = s.client.do(ctx, req, wl)
@@ -131,6 +136,7 @@ func (s *webhooks) Create(ctx context.Context, options WebhookCreateOptions) (*W
 	if err != nil {
 		return nil, err
 	}
+	s.client.warnDeprecated(req, webhooksDeprecation)
 
 	w := &Webhook{}
 	err = s.client.do(ctx, req, w)
@@ -152,6 +158,7 @@ func (s *webhooks) Read(ctx context.Context, webhookID string) (*Webhook, error)
 	if err != nil {
 		return nil, err
 	}
+	s.client.warnDeprecated(req, webhooksDeprecation)
 
 	w := &Webhook{}
 	err = s.client.do(ctx, req, w)
@@ -188,6 +195,7 @@ func (s *webhooks) Update(ctx context.Context, webhookID string, options Webhook
 	if err != nil {
 		return nil, err
 	}
+	s.client.warnDeprecated(req, webhooksDeprecation)
 
 	w := &Webhook{}
 	err = s.client.do(ctx, req, w)
@@ -209,6 +217,10 @@ func (s *webhooks) Delete(ctx context.Context, webhookID string) error {
 	if err != nil {
 		return err
 	}
+	s.client.warnDeprecated(req, webhooksDeprecation)
 
 	return s.client.do(ctx, req, nil)
 }
+
+// webhooksDeprecation is reported to the WarningHandler on each call of the Webhooks service.
+const webhooksDeprecation = "the webhooks API is deprecated, use the webhook integrations instead"
@@ -0,0 +1,104 @@
+package scalr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// WebhookDeliveryStatus represents the status of a webhook delivery.
+type WebhookDeliveryStatus string
+
+// List of available webhook delivery statuses.
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDeliveryList represents a list of webhook deliveries.
+type WebhookDeliveryList struct {
+	*Pagination
+	Items []*WebhookDelivery
+}
+
+// WebhookDelivery represents a single event sent by a webhook integration.
+type WebhookDelivery struct {
+	ID        string                `jsonapi:"primary,webhook-deliveries"`
+	Status    WebhookDeliveryStatus `jsonapi:"attr,status"`
+	CreatedAt time.Time             `jsonapi:"attr,created-at,iso8601"`
+
+	// The number of attempts made to deliver the event, up to the max attempts of the webhook.
+	Attempts int `jsonapi:"attr,attempts"`
+
+	// The time of the last attempt, nil if no attempt was made yet.
+	LastAttemptAt *time.Time `jsonapi:"attr,last-attempt-at,iso8601"`
+
+	// The snapshot of the request sent to the receiver.
+	RequestBody string `jsonapi:"attr,request-body"`
+
+	// The HTTP status code and body of the receiver response to the last attempt,
+	// the status code is zero if no response was received.
+	ResponseStatusCode int    `jsonapi:"attr,response-status-code"`
+	ResponseBody       string `jsonapi:"attr,response-body"`
+
+	// The reason of the failure, e.g. a connection timeout.
+	ErrorMessage string `jsonapi:"attr,error-message"`
+
+	// Relations
+	Webhook *WebhookIntegration `jsonapi:"relation,webhook"`
+	Event   *EventDefinition    `jsonapi:"relation,event"`
+}
+
+// WebhookDeliveryListOptions represents the options for listing webhook deliveries.
+type WebhookDeliveryListOptions struct {
+	ListOptions
+
+	Status *WebhookDeliveryStatus `url:"filter[status],omitempty"`
+
+	// Filter by the creation time.
+	CreatedAt *TimeRangeFilter `url:"filter[created-at],omitempty"`
+
+	Include string `url:"include,omitempty"`
+}
+
+// ListDeliveries lists the deliveries of a webhook integration, the latest first,
+// e.g. the failed ones to debug the receiver.
+func (s *webhookIntegrations) ListDeliveries(
+	ctx context.Context, wi string, options WebhookDeliveryListOptions,
+) (*WebhookDeliveryList, error) {
+	if !validStringID(&wi) {
+		return nil, errors.New("invalid value for webhook ID")
+	}
+
+	u := fmt.Sprintf("integrations/webhooks/%s/deliveries", url.QueryEscape(wi))
+	req, err := s.client.newRequest("GET", u, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	dl := &WebhookDeliveryList{}
+	err = s.client.do(ctx, req, dl)
+	if err != nil {
+		return nil, err
+	}
+
+	return dl, nil
+}
+
+// Redeliver sends the event of the delivery to the receiver again.
+func (s *webhookIntegrations) Redeliver(ctx context.Context, deliveryID string) error {
+	if !validStringID(&deliveryID) {
+		return errors.New("invalid value for webhook delivery ID")
+	}
+
+	u := fmt.Sprintf("webhook-deliveries/%s/actions/redeliver", url.QueryEscape(deliveryID))
+	req, err := s.client.newRequest("POST", u, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.client.do(ctx, req, nil)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 	"time"
 )
 
@@ -17,6 +18,12 @@ type WebhookIntegrations interface {
 	Read(ctx context.Context, wi string) (*WebhookIntegration, error)
 	Update(ctx context.Context, wi string, options WebhookIntegrationUpdateOptions) (*WebhookIntegration, error)
 	Delete(ctx context.Context, wi string) error
+	// FindByURL returns the webhook integrations that send requests to the given receiver URL.
+	FindByURL(ctx context.Context, receiverURL string) ([]*WebhookIntegration, error)
+	// ListDeliveries lists the deliveries of a webhook integration.
+	ListDeliveries(ctx context.Context, wi string, options WebhookDeliveryListOptions) (*WebhookDeliveryList, error)
+	// Redeliver sends the event of a delivery again.
+	Redeliver(ctx context.Context, deliveryID string) error
 }
 
 // webhookIntegrations implements WebhookIntegrations.
@@ -62,8 +69,10 @@ type WebhookIntegrationListOptions struct {
 	Sort        *string `url:"sort,omitempty"`
 	Enabled     *bool   `url:"filter[enabled],omitempty"`
 	Event       *string `url:"filter[event],omitempty"`
+	IsShared    *bool   `url:"filter[is-shared],omitempty"`
 	Environment *string `url:"filter[environment],omitempty"`
 	Account     *string `url:"filter[account],omitempty"`
+	Include     string  `url:"include,omitempty"`
 }
 
 type WebhookIntegrationCreateOptions struct {
@@ -194,3 +203,44 @@ func (s *webhookIntegrations) Delete(ctx context.Context, wi string) error {
 
 	return s.client.do(ctx, req, nil)
 }
+
+// FindByURL lists all webhook integrations and returns those pointing to the
+// receiverURL. The scheme and host are compared case-insensitively and a trailing
+// slash is ignored. It helps to avoid creating duplicate integrations for the same receiver.
+func (s *webhookIntegrations) FindByURL(ctx context.Context, receiverURL string) ([]*WebhookIntegration, error) {
+	if !validString(&receiverURL) {
+		return nil, errors.New("invalid value for url")
+	}
+	target := normalizeWebhookURL(receiverURL)
+
+	var found []*WebhookIntegration
+	options := WebhookIntegrationListOptions{}
+	for {
+		wl, err := s.List(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, wi := range wl.Items {
+			if normalizeWebhookURL(wi.Url) == target {
+				found = append(found, wi)
+			}
+		}
+		if wl.Pagination == nil || wl.NextPage == 0 {
+			break
+		}
+		options.PageNumber = wl.NextPage
+	}
+
+	return found, nil
+}
+
+func normalizeWebhookURL(rawURL string) string {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return rawURL
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	return u.String()
+}
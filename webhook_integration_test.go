@@ -3,6 +3,8 @@ package scalr
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -42,6 +44,61 @@ func TestWebhookIntegrationsList(t *testing.T) {
 		}
 		assert.ElementsMatch(t, expectedIDs, actualIDs)
 	})
+
+	t.Run("with shared filter", func(t *testing.T) {
+		whl, err := client.WebhookIntegrations.List(
+			ctx, WebhookIntegrationListOptions{
+				Account:  String(defaultAccountID),
+				IsShared: Bool(true),
+				Include:  "events",
+			},
+		)
+		require.NoError(t, err)
+		for _, wh := range whl.Items {
+			assert.True(t, wh.IsShared)
+		}
+	})
+}
+
+func TestWebhookIntegrationsFindByURL(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/iacp/v3/integrations/webhooks", r.URL.Path)
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		if r.URL.Query().Get("page[number]") == "2" {
+			fmt.Fprint(w, `{"data":[{"id":"wh-3","type":"webhook-integrations","attributes":{"url":"HTTPS://Hooks.Example.com/scalr/"}}],`+
+				`"meta":{"pagination":{"current-page":2,"total-pages":2}}}`)
+			return
+		}
+		fmt.Fprint(w, `{"data":[`+
+			`{"id":"wh-1","type":"webhook-integrations","attributes":{"url":"https://hooks.example.com/scalr"}},`+
+			`{"id":"wh-2","type":"webhook-integrations","attributes":{"url":"https://hooks.example.com/other"}}],`+
+			`"meta":{"pagination":{"current-page":1,"next-page":2,"total-pages":2}}}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("when integrations exist", func(t *testing.T) {
+		whs, err := client.WebhookIntegrations.FindByURL(ctx, "https://hooks.example.com/scalr")
+		require.NoError(t, err)
+		require.Len(t, whs, 2)
+		assert.Equal(t, "wh-1", whs[0].ID)
+		assert.Equal(t, "wh-3", whs[1].ID)
+	})
+
+	t.Run("when no integration matches", func(t *testing.T) {
+		whs, err := client.WebhookIntegrations.FindByURL(ctx, "https://hooks.example.com/missing")
+		require.NoError(t, err)
+		assert.Empty(t, whs)
+	})
+
+	t.Run("with empty url", func(t *testing.T) {
+		whs, err := client.WebhookIntegrations.FindByURL(ctx, "")
+		assert.Nil(t, whs)
+		assert.EqualError(t, err, "invalid value for url")
+	})
 }
 
 func TestWebhookIntegrationsRead(t *testing.T) {
@@ -161,3 +218,58 @@ func TestWebhookIntegrationsDelete(t *testing.T) {
 		)
 	})
 }
+
+func TestWebhookIntegrationsDeliveries(t *testing.T) {
+	var redelivered string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/iacp/v3/integrations/webhooks/wh-123/deliveries":
+			assert.Equal(t, "failed", r.URL.Query().Get("filter[status]"))
+			fmt.Fprint(w, `{"data":[{"id":"whd-1","type":"webhook-deliveries","attributes":{"status":"failed",`+
+				`"attempts":3,"last-attempt-at":"2023-01-02T03:04:05Z","request-body":"{\"event\":\"run:completed\"}",`+
+				`"response-status-code":502,"response-body":"Bad Gateway"},`+
+				`"relationships":{"webhook":{"data":{"id":"wh-123","type":"webhook-integrations"}},`+
+				`"event":{"data":{"id":"run:completed","type":"event-definitions"}}}}],`+
+				`"meta":{"pagination":{"current-page":1,"total-pages":1,"total-count":1}}}`)
+		case r.Method == "POST" && r.URL.Path == "/api/iacp/v3/webhook-deliveries/whd-1/actions/redeliver":
+			redelivered = "whd-1"
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("list failed deliveries", func(t *testing.T) {
+		status := WebhookDeliveryFailed
+		dl, err := client.WebhookIntegrations.ListDeliveries(ctx, "wh-123", WebhookDeliveryListOptions{Status: &status})
+		require.NoError(t, err)
+		require.Len(t, dl.Items, 1)
+
+		d := dl.Items[0]
+		assert.Equal(t, WebhookDeliveryFailed, d.Status)
+		assert.Equal(t, 3, d.Attempts)
+		assert.Equal(t, 502, d.ResponseStatusCode)
+		assert.Equal(t, `{"event":"run:completed"}`, d.RequestBody)
+		assert.Equal(t, "wh-123", d.Webhook.ID)
+		assert.Equal(t, "run:completed", d.Event.ID)
+		require.NotNil(t, d.LastAttemptAt)
+	})
+
+	t.Run("redeliver", func(t *testing.T) {
+		require.NoError(t, client.WebhookIntegrations.Redeliver(ctx, "whd-1"))
+		assert.Equal(t, "whd-1", redelivered)
+	})
+
+	t.Run("with invalid IDs", func(t *testing.T) {
+		_, err := client.WebhookIntegrations.ListDeliveries(ctx, badIdentifier, WebhookDeliveryListOptions{})
+		assert.EqualError(t, err, "invalid value for webhook ID")
+		err = client.WebhookIntegrations.Redeliver(ctx, badIdentifier)
+		assert.EqualError(t, err, "invalid value for webhook delivery ID")
+	})
+}
@@ -2,10 +2,16 @@ package scalr
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 // Compile-time proof of interface implementation.
@@ -28,11 +34,47 @@ type Workspaces interface {
 	// Update settings of an existing workspace.
 	Update(ctx context.Context, workspaceID string, options WorkspaceUpdateOptions) (*Workspace, error)
 
+	// UpdateIfUnchanged updates the workspace if the given fields were not modified since it was read.
+	UpdateIfUnchanged(ctx context.Context, expected *Workspace, fields []string, options WorkspaceUpdateOptions) (*Workspace, error)
+
 	// Delete deletes a workspace by its ID.
 	Delete(ctx context.Context, workspaceID string) error
 
+	// DeleteWithOptions deletes a workspace by its ID, optionally detaching its dependencies first.
+	DeleteWithOptions(ctx context.Context, workspaceID string, options WorkspaceDeleteOptions) error
+
 	// SetSchedule sets run schedules for workspace.
 	SetSchedule(ctx context.Context, workspaceID string, options WorkspaceRunScheduleOptions) (*Workspace, error)
+
+	// ClearSchedules removes both apply and destroy run schedules of the workspace.
+	ClearSchedules(ctx context.Context, workspaceID string) (*Workspace, error)
+
+	// RunnerEnv returns the SCALR_* environment variables describing the workspace.
+	RunnerEnv(ws *Workspace) map[string]string
+
+	// PromoteOutputs copies the outputs of one workspace to terraform variables of another.
+	PromoteOutputs(ctx context.Context, fromWorkspaceID, toWorkspaceID string, mapping map[string]string) ([]*Variable, error)
+
+	// ListByPrefix lists the workspaces of an environment whose names start with the prefix.
+	ListByPrefix(ctx context.Context, environmentID, prefix string) ([]*Workspace, error)
+
+	// ValidateCreate checks the options for creating a workspace without creating it.
+	ValidateCreate(ctx context.Context, options WorkspaceCreateOptions) (*WorkspaceValidationReport, error)
+
+	// SelectTerraformVersion returns the Terraform version of a workspace created from a module version.
+	SelectTerraformVersion(ctx context.Context, options WorkspaceCreateOptions, available []string) (string, error)
+
+	// MigrateToRemote switches a workspace from the local to the remote execution mode.
+	MigrateToRemote(ctx context.Context, workspaceID string, options WorkspaceMigrationOptions) (*WorkspaceMigrationReport, error)
+
+	// Lock a workspace by its ID.
+	Lock(ctx context.Context, workspaceID string, reason string) (*Workspace, error)
+
+	// Unlock a workspace by its ID.
+	Unlock(ctx context.Context, workspaceID string) (*Workspace, error)
+
+	// ForceUnlock a workspace locked by another user.
+	ForceUnlock(ctx context.Context, workspaceID string) (*Workspace, error)
 }
 
 // workspaces implements Workspaces.
@@ -90,7 +132,10 @@ type Workspace struct {
 	AutoQueueRuns             WorkspaceAutoQueueRuns `jsonapi:"attr,auto-queue-runs"`
 	Hooks                     *Hooks                 `jsonapi:"attr,hooks"`
 	RunOperationTimeout       *int                   `jsonapi:"attr,run-operation-timeout"`
+	PlanOperationTimeout      *int                   `jsonapi:"attr,plan-operation-timeout"`
+	ApplyOperationTimeout     *int                   `jsonapi:"attr,apply-operation-timeout"`
 	VarFiles                  []string               `jsonapi:"attr,var-files"`
+	VCSTriggersDisabled       bool                   `jsonapi:"attr,vcs-triggers-disabled"`
 
 	// Relations
 	CurrentRun    *Run           `jsonapi:"relation,current-run"`
@@ -99,6 +144,7 @@ type Workspace struct {
 	VcsProvider   *VcsProvider   `jsonapi:"relation,vcs-provider"`
 	AgentPool     *AgentPool     `jsonapi:"relation,agent-pool"`
 	ModuleVersion *ModuleVersion `jsonapi:"relation,module-version,omitempty"`
+	SSHKey        *SSHKey        `jsonapi:"relation,ssh-key"`
 	Tags          []*Tag         `jsonapi:"relation,tags"`
 }
 
@@ -119,6 +165,13 @@ type WorkspaceVCSRepo struct {
 	Path              string   `json:"path"`
 	TriggerPrefixes   []string `json:"trigger-prefixes,omitempty"`
 	DryRunsEnabled    bool     `json:"dry-runs-enabled"`
+	TriggerTags       bool     `json:"trigger-tags"`
+	TagRegex          string   `json:"tag-regex,omitempty"`
+
+	// Whether runs can be triggered by commands in pull request comments.
+	CommentCommandsEnabled bool `json:"comment-commands-enabled"`
+	// The prefix of the pull request comment commands, e.g. "/scalr".
+	CommentCommandPrefix string `json:"comment-command-prefix,omitempty"`
 }
 
 // WorkspaceActions represents the workspace actions.
@@ -140,11 +193,20 @@ type WorkspacePermissions struct {
 	CanUpdateVariable bool `json:"can-update-variable"`
 }
 
+// List of attributes workspaces can be sorted by.
+const (
+	WorkspaceSortByName      SortKey = "name"
+	WorkspaceSortByCreatedAt SortKey = "created-at"
+)
+
 // WorkspaceListOptions represents the options for listing workspaces.
 type WorkspaceListOptions struct {
 	ListOptions
 	Include string           `url:"include,omitempty"`
 	Filter  *WorkspaceFilter `url:"filter,omitempty"`
+
+	// The comma-separated list of attributes, see SortBy.
+	Sort *string `url:"sort,omitempty"`
 }
 
 // WorkspaceFilter represents the options for filtering workspaces.
@@ -155,16 +217,57 @@ type WorkspaceFilter struct {
 	Name        *string `url:"name,omitempty"`
 	Tag         *string `url:"tag,omitempty"`
 	AgentPool   *string `url:"agent-pool,omitempty"`
+
+	// Filter workspaces by whether their VCS-triggered runs are disabled.
+	VCSTriggersDisabled *bool `url:"vcs-triggers-disabled,omitempty"`
+
+	// Filter workspaces by their current run.
+	CurrentRun *WorkspaceCurrentRunFilter `url:"current-run,omitempty"`
 }
 
-// WorkspaceRunScheduleOptions represents option for setting run schedules for workspace
+// WorkspaceCurrentRunFilter represents the options for filtering workspaces by their current run.
+// Include "current-run" in the list options to get the matched runs along with the workspaces.
+type WorkspaceCurrentRunFilter struct {
+	// The run statuses to match, see RunStatusesFilter, e.g. the workspaces
+	// awaiting a policy override: RunStatusesFilter(RunPolicyOverride, RunPolicySoftFailed).
+	Status *string `url:"status,omitempty"`
+}
+
+// WorkspaceRunScheduleOptions represents option for setting run schedules for workspace.
+// Each schedule is one of:
+//   - nil: leave the schedule unchanged;
+//   - an empty string: clear the schedule;
+//   - a cron expression: set the schedule.
 type WorkspaceRunScheduleOptions struct {
-	ApplySchedule   *string `json:"apply-schedule"`
-	DestroySchedule *string `json:"destroy-schedule"`
+	ApplySchedule   *string `json:"apply-schedule,omitempty"`
+	DestroySchedule *string `json:"destroy-schedule,omitempty"`
+}
+
+// MarshalJSON omits the schedules to leave unchanged and sends an empty
+// schedule as null, which removes it.
+func (o WorkspaceRunScheduleOptions) MarshalJSON() ([]byte, error) {
+	schedules := make(map[string]*string)
+	for name, v := range map[string]*string{
+		"apply-schedule":   o.ApplySchedule,
+		"destroy-schedule": o.DestroySchedule,
+	} {
+		switch {
+		case v == nil:
+		case *v == "":
+			schedules[name] = nil
+		default:
+			schedules[name] = v
+		}
+	}
+	return json.Marshal(schedules)
 }
 
 // List all the workspaces within an environment.
 func (s *workspaces) List(ctx context.Context, options WorkspaceListOptions) (*WorkspaceList, error) {
+	if err := validSort(options.Sort, WorkspaceSortByName, WorkspaceSortByCreatedAt); err != nil {
+		return nil, err
+	}
+
 	req, err := s.client.newRequest("GET", "workspaces", &options)
 	if err != nil {
 		return nil, err
@@ -241,6 +344,14 @@ type WorkspaceCreateOptions struct {
 	// Specifies the number of minutes run operation can be executed before termination.
 	RunOperationTimeout *int `jsonapi:"attr,run-operation-timeout"`
 
+	// Specifies the number of minutes the plan and apply phases can be executed
+	// before termination, overriding RunOperationTimeout for the phase.
+	PlanOperationTimeout  *int `jsonapi:"attr,plan-operation-timeout,omitempty"`
+	ApplyOperationTimeout *int `jsonapi:"attr,apply-operation-timeout,omitempty"`
+
+	// Specifies the SSH key used to fetch private modules.
+	SSHKey *SSHKey `jsonapi:"relation,ssh-key,omitempty"`
+
 	// Specifies tags assigned to the workspace
 	Tags []*Tag `jsonapi:"relation,tags,omitempty"`
 }
@@ -253,6 +364,34 @@ type WorkspaceVCSRepoOptions struct {
 	Path              *string   `json:"path,omitempty"`
 	TriggerPrefixes   *[]string `json:"trigger-prefixes,omitempty"`
 	DryRunsEnabled    *bool     `json:"dry-runs-enabled,omitempty"`
+
+	// Whether runs are triggered by pushed tags instead of branch commits.
+	TriggerTags *bool `json:"trigger-tags,omitempty"`
+	// A regular expression the pushed tag must match to trigger a run.
+	TagRegex *string `json:"tag-regex,omitempty"`
+
+	// Whether dry runs and applies can be triggered by commands, e.g. "/scalr plan",
+	// in the comments of the pull requests.
+	CommentCommandsEnabled *bool `json:"comment-commands-enabled,omitempty"`
+	// The prefix of the pull request comment commands.
+	CommentCommandPrefix *string `json:"comment-command-prefix,omitempty"`
+}
+
+func (o *WorkspaceVCSRepoOptions) valid() error {
+	if o == nil {
+		return nil
+	}
+	if o.TagRegex != nil {
+		if _, err := regexp.Compile(*o.TagRegex); err != nil {
+			return fmt.Errorf("invalid value for tag regex: %v", err)
+		}
+	}
+	if o.CommentCommandPrefix != nil {
+		if *o.CommentCommandPrefix == "" || strings.ContainsAny(*o.CommentCommandPrefix, " \t\n") {
+			return errors.New("invalid value for comment command prefix")
+		}
+	}
+	return nil
 }
 
 // HooksOptions represents the WorkspaceHooks configuration.
@@ -264,6 +403,52 @@ type HooksOptions struct {
 	PostApply *string `json:"post-apply,omitempty"`
 }
 
+// MaxHookCommandLength is the maximum length of a hook command, longer
+// commands are truncated by the API.
+const MaxHookCommandLength = 1024
+
+func (o *HooksOptions) valid() error {
+	if o == nil {
+		return nil
+	}
+	hooks := []struct {
+		name    string
+		command *string
+	}{
+		{"pre-init", o.PreInit},
+		{"pre-plan", o.PrePlan},
+		{"post-plan", o.PostPlan},
+		{"pre-apply", o.PreApply},
+		{"post-apply", o.PostApply},
+	}
+	for _, h := range hooks {
+		if h.command == nil {
+			continue
+		}
+		if err := validHookCommand(*h.command); err != nil {
+			return fmt.Errorf("invalid value for %s hook: %v", h.name, err)
+		}
+	}
+	return nil
+}
+
+// validHookCommand checks that the command is stored by the API as is: it is
+// not too long and has no characters a shell script cannot contain.
+func validHookCommand(command string) error {
+	if !utf8.ValidString(command) {
+		return errors.New("command is not valid UTF-8")
+	}
+	if n := utf8.RuneCountInString(command); n > MaxHookCommandLength {
+		return fmt.Errorf("command is %d characters long, the maximum is %d", n, MaxHookCommandLength)
+	}
+	for _, r := range command {
+		if unicode.IsControl(r) && r != '\n' && r != '\r' && r != '\t' {
+			return fmt.Errorf("command contains the control character %U", r)
+		}
+	}
+	return nil
+}
+
 func (o WorkspaceCreateOptions) valid() error {
 	if !validString(o.Name) {
 		return errors.New("name is required")
@@ -271,6 +456,12 @@ func (o WorkspaceCreateOptions) valid() error {
 	if !validStringID(o.Name) {
 		return errors.New("invalid value for name")
 	}
+	if err := o.VCSRepo.valid(); err != nil {
+		return err
+	}
+	if err := o.Hooks.valid(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -367,6 +558,10 @@ type WorkspaceUpdateOptions struct {
 	// Whether to prevent deletion when the workspace has resources.
 	DeletionProtectionEnabled *bool `jsonapi:"attr,deletion-protection-enabled,omitempty"`
 
+	// Whether to temporarily stop VCS events from triggering runs,
+	// e.g. during a maintenance freeze. The VCS repo link is kept.
+	VCSTriggersDisabled *bool `jsonapi:"attr,vcs-triggers-disabled,omitempty"`
+
 	// A new name for the workspace, which can only include letters, numbers, -,
 	// and _. This will be used as an identifier and must be unique in the
 	// environment. Warning: Changing a workspace's name changes its URL in the
@@ -420,6 +615,14 @@ type WorkspaceUpdateOptions struct {
 
 	// Specifies the number of minutes run operation can be executed before termination.
 	RunOperationTimeout *int `jsonapi:"attr,run-operation-timeout"`
+
+	// Specifies the number of minutes the plan and apply phases can be executed
+	// before termination, overriding RunOperationTimeout for the phase.
+	PlanOperationTimeout  *int `jsonapi:"attr,plan-operation-timeout,omitempty"`
+	ApplyOperationTimeout *int `jsonapi:"attr,apply-operation-timeout,omitempty"`
+
+	// Specifies the SSH key used to fetch private modules.
+	SSHKey *SSHKey `jsonapi:"relation,ssh-key,omitempty"`
 }
 
 // Update settings of an existing workspace.
@@ -427,6 +630,12 @@ func (s *workspaces) Update(ctx context.Context, workspaceID string, options Wor
 	if !validStringID(&workspaceID) {
 		return nil, errors.New("invalid value for workspace ID")
 	}
+	if err := options.VCSRepo.valid(); err != nil {
+		return nil, err
+	}
+	if err := options.Hooks.valid(); err != nil {
+		return nil, err
+	}
 
 	// Make sure we don't send a user provided ID.
 	options.ID = ""
@@ -461,6 +670,81 @@ func (s *workspaces) Delete(ctx context.Context, workspaceID string) error {
 	return s.client.do(ctx, req, nil)
 }
 
+// WorkspaceDeleteOptions represents the options for deleting a workspace.
+type WorkspaceDeleteOptions struct {
+	// Delete the run triggers the workspace is the upstream or downstream of
+	// and its provider configuration links before deleting the workspace.
+	DetachDependencies bool
+}
+
+// DeleteWithOptions deletes a workspace by its ID. With DetachDependencies,
+// the dependent resources are removed first, so that the deletion does not
+// fail or leave them orphaned.
+func (s *workspaces) DeleteWithOptions(ctx context.Context, workspaceID string, options WorkspaceDeleteOptions) error {
+	if !validStringID(&workspaceID) {
+		return errors.New("invalid value for workspace ID")
+	}
+
+	if options.DetachDependencies {
+		if err := s.detachDependencies(ctx, workspaceID); err != nil {
+			return err
+		}
+	}
+
+	return s.Delete(ctx, workspaceID)
+}
+
+// detachDependencies removes the run triggers and provider configuration links of the workspace.
+func (s *workspaces) detachDependencies(ctx context.Context, workspaceID string) error {
+	var triggers []*RunTrigger
+	for _, options := range []RunTriggerListOptions{
+		{Upstream: String(workspaceID)},
+		{Downstream: String(workspaceID)},
+	} {
+		for {
+			rtl, err := s.client.RunTriggers.List(ctx, options)
+			if err != nil {
+				return fmt.Errorf("listing run triggers: %w", err)
+			}
+			triggers = append(triggers, rtl.Items...)
+			if rtl.Pagination == nil || rtl.NextPage == 0 {
+				break
+			}
+			options.PageNumber = rtl.NextPage
+		}
+	}
+
+	var links []*ProviderConfigurationLink
+	linkOptions := ProviderConfigurationLinksListOptions{}
+	for {
+		ll, err := s.client.ProviderConfigurationLinks.List(ctx, workspaceID, linkOptions)
+		if err != nil {
+			return fmt.Errorf("listing provider configuration links: %w", err)
+		}
+		links = append(links, ll.Items...)
+		if ll.Pagination == nil || ll.NextPage == 0 {
+			break
+		}
+		linkOptions.PageNumber = ll.NextPage
+	}
+
+	// A trigger between the workspace and itself is listed twice.
+	for _, rt := range triggers {
+		err := s.client.RunTriggers.Delete(ctx, rt.ID)
+		if err != nil && !errors.Is(err, ErrResourceNotFound) {
+			return fmt.Errorf("deleting run trigger %s: %w", rt.ID, err)
+		}
+	}
+	for _, l := range links {
+		err := s.client.ProviderConfigurationLinks.Delete(ctx, l.ID)
+		if err != nil && !errors.Is(err, ErrResourceNotFound) {
+			return fmt.Errorf("deleting provider configuration link %s: %w", l.ID, err)
+		}
+	}
+
+	return nil
+}
+
 // SetSchedule set scheduled runs
 func (s *workspaces) SetSchedule(ctx context.Context, workspaceID string, options WorkspaceRunScheduleOptions) (*Workspace, error) {
 	if !validStringID(&workspaceID) {
@@ -481,3 +765,247 @@ func (s *workspaces) SetSchedule(ctx context.Context, workspaceID string, option
 
 	return w, nil
 }
+
+// ClearSchedules removes both run schedules of the workspace.
+func (s *workspaces) ClearSchedules(ctx context.Context, workspaceID string) (*Workspace, error) {
+	return s.SetSchedule(ctx, workspaceID, WorkspaceRunScheduleOptions{
+		ApplySchedule:   String(""),
+		DestroySchedule: String(""),
+	})
+}
+
+// List of the environment variables returned by RunnerEnv.
+const (
+	EnvScalrHostname        = "SCALR_HOSTNAME"
+	EnvScalrAccountID       = "SCALR_ACCOUNT_ID"
+	EnvScalrEnvironmentID   = "SCALR_ENVIRONMENT_ID"
+	EnvScalrEnvironmentName = "SCALR_ENVIRONMENT_NAME"
+	EnvScalrWorkspaceID     = "SCALR_WORKSPACE_ID"
+	EnvScalrWorkspaceName   = "SCALR_WORKSPACE_NAME"
+)
+
+// RunnerEnv returns the environment variables hooks and custom scripts use to
+// identify the workspace. Variables of relations that are not loaded are omitted.
+func (s *workspaces) RunnerEnv(ws *Workspace) map[string]string {
+	env := map[string]string{
+		EnvScalrHostname: s.client.baseURL.Host,
+	}
+	if ws == nil {
+		return env
+	}
+
+	env[EnvScalrWorkspaceID] = ws.ID
+	env[EnvScalrWorkspaceName] = ws.Name
+	if ws.Environment != nil {
+		env[EnvScalrEnvironmentID] = ws.Environment.ID
+		if ws.Environment.Name != "" {
+			env[EnvScalrEnvironmentName] = ws.Environment.Name
+		}
+		if ws.Environment.Account != nil {
+			env[EnvScalrAccountID] = ws.Environment.Account.ID
+		}
+	}
+	return env
+}
+
+// PromoteOutputs reads the outputs of the current state of the source workspace
+// and writes them as terraform variables of the target workspace, creating or
+// updating the variables. The mapping is from the output name to the variable key;
+// a nil mapping promotes all the outputs under their own names. Non-string values
+// are written as HCL, sensitive outputs as sensitive variables. The sensitive
+// outputs without a value are skipped and left out of the result.
+func (s *workspaces) PromoteOutputs(ctx context.Context, fromWorkspaceID, toWorkspaceID string, mapping map[string]string) ([]*Variable, error) {
+	if !validStringID(&fromWorkspaceID) {
+		return nil, errors.New("invalid value for source workspace ID")
+	}
+	if !validStringID(&toWorkspaceID) {
+		return nil, errors.New("invalid value for target workspace ID")
+	}
+
+	sv, err := s.client.StateVersions.ReadCurrentForWorkspace(ctx, fromWorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make(map[string]*StateVersionOutput, len(sv.Outputs))
+	for _, o := range sv.Outputs {
+		outputs[o.Name] = o
+	}
+	if mapping == nil {
+		mapping = make(map[string]string, len(outputs))
+		for name := range outputs {
+			mapping[name] = name
+		}
+	}
+
+	names := make([]string, 0, len(mapping))
+	for name := range mapping {
+		if _, ok := outputs[name]; !ok {
+			return nil, fmt.Errorf("output %s not found in workspace %s", name, fromWorkspaceID)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var result []*Variable
+	for _, name := range names {
+		o, key := outputs[name], mapping[name]
+
+		// The API redacts the values of the sensitive outputs, which would
+		// be written as HCL null, so the variables are not touched.
+		if o.Sensitive && o.Value == nil {
+			continue
+		}
+
+		value, hcl, err := outputVariableValue(o.Value)
+		if err != nil {
+			return nil, fmt.Errorf("output %s: %v", name, err)
+		}
+
+		vl, err := s.client.Variables.List(ctx, VariableListOptions{
+			Filter: &VariableFilter{
+				Key:       String(key),
+				Category:  String(string(CategoryTerraform)),
+				Workspace: String(toWorkspaceID),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var v *Variable
+		if len(vl.Items) > 0 {
+			v, err = s.client.Variables.Update(ctx, vl.Items[0].ID, VariableUpdateOptions{
+				Value:     SecretString(value),
+				HCL:       Bool(hcl),
+				Sensitive: Bool(o.Sensitive),
+			})
+		} else {
+			v, err = s.client.Variables.Create(ctx, VariableCreateOptions{
+				Key:       String(key),
+				Value:     SecretString(value),
+				Category:  Category(CategoryTerraform),
+				HCL:       Bool(hcl),
+				Sensitive: Bool(o.Sensitive),
+				Workspace: &Workspace{ID: toWorkspaceID},
+			})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("variable %s: %w", key, err)
+		}
+		result = append(result, v)
+	}
+
+	return result, nil
+}
+
+// outputVariableValue converts the output value to the variable value,
+// reporting whether it must be evaluated as HCL.
+func outputVariableValue(value interface{}) (string, bool, error) {
+	if s, ok := value.(string); ok {
+		return s, false, nil
+	}
+	// JSON is valid HCL for any other value.
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", false, err
+	}
+	return string(b), true, nil
+}
+
+// ListByPrefix lists all the workspaces of the environment whose names start with
+// the prefix and are longer than it, sorted by name. This is the set of workspaces the Terraform CLI sees
+// with the remote backend configured with `workspaces { prefix = "..." }`, where
+// the organization is the environment ID; see CLIWorkspaceName.
+func (s *workspaces) ListByPrefix(ctx context.Context, environmentID, prefix string) ([]*Workspace, error) {
+	if !validStringID(&environmentID) {
+		return nil, errors.New("invalid value for environment")
+	}
+	if !validString(&prefix) {
+		return nil, errors.New("prefix is required")
+	}
+
+	// The API only supports a substring match, the prefix is checked below.
+	options := WorkspaceListOptions{
+		Filter: &WorkspaceFilter{Environment: &environmentID, Name: String("like:" + prefix)},
+		Sort:   SortBy(WorkspaceSortByName),
+	}
+
+	var result []*Workspace
+	for {
+		wl, err := s.List(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, ws := range wl.Items {
+			if _, ok := CLIWorkspaceName(prefix, ws.Name); ok {
+				result = append(result, ws)
+			}
+		}
+		if wl.Pagination == nil || wl.NextPage == 0 {
+			break
+		}
+		options.PageNumber = wl.NextPage
+	}
+
+	return result, nil
+}
+
+// PrefixedWorkspaceName returns the name of the Scalr workspace the Terraform CLI
+// workspace maps to with the remote backend workspace prefix.
+func PrefixedWorkspaceName(prefix, cliName string) string {
+	return prefix + cliName
+}
+
+// CLIWorkspaceName returns the Terraform CLI workspace name of the Scalr workspace
+// with the remote backend workspace prefix. It reports false if the workspace is
+// not visible to the CLI with this prefix.
+func CLIWorkspaceName(prefix, workspaceName string) (string, bool) {
+	if !strings.HasPrefix(workspaceName, prefix) || len(workspaceName) == len(prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(workspaceName, prefix), true
+}
+
+// Lock a workspace by its ID, so no runs can be started until it is unlocked.
+// ErrWorkspaceLocked is returned if the workspace is already locked.
+func (s *workspaces) Lock(ctx context.Context, workspaceID string, reason string) (*Workspace, error) {
+	options := struct {
+		Reason string `json:"reason,omitempty"`
+	}{
+		Reason: reason,
+	}
+	return s.lockAction(ctx, workspaceID, "lock", &options)
+}
+
+// Unlock a workspace by its ID.
+// ErrWorkspaceNotLocked is returned if the workspace is not locked.
+func (s *workspaces) Unlock(ctx context.Context, workspaceID string) (*Workspace, error) {
+	return s.lockAction(ctx, workspaceID, "unlock", nil)
+}
+
+// ForceUnlock a workspace locked by another user.
+// ErrWorkspaceNotLocked is returned if the workspace is not locked.
+func (s *workspaces) ForceUnlock(ctx context.Context, workspaceID string) (*Workspace, error) {
+	return s.lockAction(ctx, workspaceID, "force-unlock", nil)
+}
+
+func (s *workspaces) lockAction(ctx context.Context, workspaceID, action string, options interface{}) (*Workspace, error) {
+	if !validStringID(&workspaceID) {
+		return nil, errors.New("invalid value for workspace ID")
+	}
+
+	u := fmt.Sprintf("workspaces/%s/actions/%s", url.QueryEscape(workspaceID), action)
+	req, err := s.client.newJsonRequest("POST", u, options)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Workspace{}
+	err = s.client.do(ctx, req, w)
+	if err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
@@ -0,0 +1,76 @@
+package scalr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/mermoldy/go-scalr/v2/internal/jsonapifield"
+)
+
+// WorkspaceModifiedError is returned by UpdateIfUnchanged when the workspace
+// was modified since the expected state was read.
+type WorkspaceModifiedError struct {
+	WorkspaceID string
+	// The names of the compared fields that have changed.
+	Fields []string
+}
+
+func (e WorkspaceModifiedError) Error() string {
+	return fmt.Sprintf("workspace %s was modified concurrently: %s changed", e.WorkspaceID, strings.Join(e.Fields, ", "))
+}
+
+func (e WorkspaceModifiedError) Unwrap() error {
+	return ErrResourceConflict
+}
+
+// UpdateIfUnchanged updates the workspace only if the given fields still have the values
+// of the expected workspace, usually the one read before computing the update. The fields
+// are the JSON:API attribute or relationship names, e.g. "terraform-version" or "agent-pool";
+// the relationships are compared by the IDs of the related resources.
+//
+// The API does not support conditional requests, so the workspace is re-read and compared
+// right before it is updated. This prevents most of the lost updates between concurrent
+// controllers, but a change made between the read and the update is not detected.
+func (s *workspaces) UpdateIfUnchanged(
+	ctx context.Context, expected *Workspace, fields []string, options WorkspaceUpdateOptions,
+) (*Workspace, error) {
+	if expected == nil || !validStringID(&expected.ID) {
+		return nil, errors.New("invalid value for workspace ID")
+	}
+	if len(fields) == 0 {
+		return nil, errors.New("at least one field is required")
+	}
+
+	current, err := s.ReadByID(ctx, expected.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	for _, name := range fields {
+		equal, err := workspaceFieldEqual(expected, current, name)
+		if err != nil {
+			return nil, err
+		}
+		if !equal {
+			changed = append(changed, name)
+		}
+	}
+	if len(changed) > 0 {
+		return nil, WorkspaceModifiedError{WorkspaceID: expected.ID, Fields: changed}
+	}
+
+	return s.Update(ctx, expected.ID, options)
+}
+
+// workspaceFieldEqual compares the attribute or relationship of two workspaces by its JSON:API name.
+func workspaceFieldEqual(a, b *Workspace, name string) (bool, error) {
+	f, ok := jsonapifield.Lookup(reflect.TypeOf(Workspace{}), name)
+	if !ok {
+		return false, fmt.Errorf("unknown workspace field %q", name)
+	}
+	return reflect.DeepEqual(jsonapifield.Value(reflect.ValueOf(a), f), jsonapifield.Value(reflect.ValueOf(b), f)), nil
+}
@@ -0,0 +1,78 @@
+package scalr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspacesUpdateIfUnchanged(t *testing.T) {
+	var patched bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/iacp/v3/workspaces/ws-1", r.URL.Path)
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		if r.Method == "PATCH" {
+			patched = true
+		}
+		fmt.Fprint(w, `{"data":{"id":"ws-1","type":"workspaces","attributes":{`+
+			`"name":"ws","terraform-version":"1.5.0","auto-apply":true},`+
+			`"relationships":{"agent-pool":{"data":{"id":"apool-2","type":"agent-pools"}}}}}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	expected := &Workspace{
+		ID:               "ws-1",
+		Name:             "ws",
+		TerraformVersion: "1.5.0",
+		AutoApply:        false,
+		AgentPool:        &AgentPool{ID: "apool-1"},
+	}
+	options := WorkspaceUpdateOptions{TerraformVersion: String("1.6.0")}
+
+	t.Run("when the fields are unchanged", func(t *testing.T) {
+		patched = false
+		ws, err := client.Workspaces.UpdateIfUnchanged(ctx, expected, []string{"name", "terraform-version"}, options)
+		require.NoError(t, err)
+		assert.Equal(t, "ws-1", ws.ID)
+		assert.True(t, patched)
+	})
+
+	t.Run("when the fields were modified", func(t *testing.T) {
+		patched = false
+		ws, err := client.Workspaces.UpdateIfUnchanged(
+			ctx, expected, []string{"name", "auto-apply", "agent-pool"}, options,
+		)
+		assert.Nil(t, ws)
+		assert.False(t, patched)
+		assert.True(t, errors.Is(err, ErrResourceConflict))
+		assert.EqualError(t, err, "workspace ws-1 was modified concurrently: auto-apply, agent-pool changed")
+	})
+
+	t.Run("with unknown field", func(t *testing.T) {
+		ws, err := client.Workspaces.UpdateIfUnchanged(ctx, expected, []string{"colour"}, options)
+		assert.Nil(t, ws)
+		assert.EqualError(t, err, `unknown workspace field "colour"`)
+	})
+
+	t.Run("without fields", func(t *testing.T) {
+		ws, err := client.Workspaces.UpdateIfUnchanged(ctx, expected, nil, options)
+		assert.Nil(t, ws)
+		assert.EqualError(t, err, "at least one field is required")
+	})
+
+	t.Run("with invalid workspace ID", func(t *testing.T) {
+		ws, err := client.Workspaces.UpdateIfUnchanged(ctx, &Workspace{ID: badIdentifier}, []string{"name"}, options)
+		assert.Nil(t, ws)
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+}
@@ -0,0 +1,164 @@
+package scalr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// WorkspaceMigrationStep represents the result of a single step of the
+// execution mode migration.
+type WorkspaceMigrationStep struct {
+	Name    string
+	Skipped bool
+	Message string
+}
+
+// WorkspaceMigrationReport represents the steps done by the execution mode migration.
+type WorkspaceMigrationReport struct {
+	Steps []*WorkspaceMigrationStep
+
+	// The migrated workspace.
+	Workspace *Workspace
+
+	// The plan-only run queued to verify the migration, nil if it was not queued.
+	Run *Run
+}
+
+func (r *WorkspaceMigrationReport) add(name string, skipped bool, format string, args ...interface{}) {
+	r.Steps = append(r.Steps, &WorkspaceMigrationStep{Name: name, Skipped: skipped, Message: fmt.Sprintf(format, args...)})
+}
+
+// WorkspaceMigrationOptions represents the options for migrating a workspace to remote execution.
+type WorkspaceMigrationOptions struct {
+	// The raw Terraform state to upload, e.g. the local terraform.tfstate file.
+	// Required unless the state was already pushed to the workspace.
+	State []byte
+
+	// The configuration version to queue the verification plan with. The plan
+	// is expected to have no changes. The verification is skipped if nil.
+	ConfigurationVersion *ConfigurationVersion
+}
+
+// MigrateToRemote switches a workspace from the local to the remote execution
+// mode following the documented procedure: it checks that the workspace has a
+// state, uploading the given one if any, sets the remote execution mode and
+// queues a plan-only run to verify the remote runs see no changes. The workspace
+// is locked while its state is uploaded and its execution mode is switched, and
+// a state is only uploaded to a workspace in the local execution mode. Each step
+// is reported; on error the report lists the steps done so far.
+func (s *workspaces) MigrateToRemote(
+	ctx context.Context, workspaceID string, options WorkspaceMigrationOptions,
+) (*WorkspaceMigrationReport, error) {
+	if !validStringID(&workspaceID) {
+		return nil, errors.New("invalid value for workspace ID")
+	}
+	if options.ConfigurationVersion != nil && !validStringID(&options.ConfigurationVersion.ID) {
+		return nil, errors.New("invalid value for configuration version ID")
+	}
+
+	report := &WorkspaceMigrationReport{}
+
+	ws, err := s.ReadByID(ctx, workspaceID)
+	if err != nil {
+		return report, err
+	}
+	report.Workspace = ws
+
+	if ws.ExecutionMode == WorkspaceExecutionModeRemote {
+		if options.State != nil {
+			return report, fmt.Errorf("workspace %s already uses the remote execution mode, its state is not replaced", workspaceID)
+		}
+		if err := s.migrateState(ctx, workspaceID, nil, report); err != nil {
+			return report, err
+		}
+		report.add("execution-mode", true, "workspace already uses the remote execution mode")
+	} else {
+		err := s.withLock(ctx, workspaceID, "Migrating to the remote execution mode", func() error {
+			if err := s.migrateState(ctx, workspaceID, options.State, report); err != nil {
+				return err
+			}
+
+			from := ws.ExecutionMode
+			ws, err := s.Update(ctx, workspaceID, WorkspaceUpdateOptions{
+				ExecutionMode: Ptr(WorkspaceExecutionModeRemote),
+			})
+			if err != nil {
+				return fmt.Errorf("unable to set the remote execution mode: %w", err)
+			}
+			report.Workspace = ws
+			report.add("execution-mode", false, "switched from the %s to the remote execution mode", from)
+			return nil
+		})
+		if err != nil {
+			return report, err
+		}
+	}
+
+	if options.ConfigurationVersion == nil {
+		report.add("verify", true, "no configuration version to queue the verification plan with")
+		return report, nil
+	}
+	run, err := s.client.Runs.Create(ctx, RunCreateOptions{
+		Message:              String("Verify the migration to the remote execution mode"),
+		IsDry:                Bool(true),
+		ConfigurationVersion: options.ConfigurationVersion,
+		Workspace:            &Workspace{ID: workspaceID},
+	})
+	if err != nil {
+		return report, fmt.Errorf("unable to queue the verification plan: %w", err)
+	}
+	report.Run = run
+	report.add("verify", false, "queued the verification plan %s", run.ID)
+
+	return report, nil
+}
+
+// migrateState uploads the state to the workspace, or checks that the
+// workspace has a state already if none is given.
+func (s *workspaces) migrateState(
+	ctx context.Context, workspaceID string, state []byte, report *WorkspaceMigrationReport,
+) error {
+	if state != nil {
+		svOptions, err := NewStateVersionCreateOptions(workspaceID, state)
+		if err != nil {
+			return err
+		}
+		sv, err := s.client.StateVersions.Create(ctx, svOptions)
+		if err != nil {
+			return fmt.Errorf("unable to upload the state: %w", err)
+		}
+		report.add("state", false, "uploaded state version %s with serial %d", sv.ID, sv.Serial)
+		return nil
+	}
+
+	sv, err := s.client.StateVersions.ReadCurrentForWorkspace(ctx, workspaceID)
+	if errors.Is(err, ErrResourceNotFound) {
+		return fmt.Errorf("workspace %s has no state, push the local state or pass it in the State option", workspaceID)
+	}
+	if err != nil {
+		return err
+	}
+	report.add("state", true, "found state version %s with serial %d", sv.ID, sv.Serial)
+	return nil
+}
+
+// withLock calls fn with the workspace locked, and unlocks it afterwards,
+// even if ctx was canceled or timed out in the meantime.
+func (s *workspaces) withLock(ctx context.Context, workspaceID, reason string, fn func() error) error {
+	if _, err := s.Lock(ctx, workspaceID, reason); err != nil {
+		return fmt.Errorf("unable to lock the workspace: %w", err)
+	}
+
+	err := fn()
+
+	unlockCtx, cancel := cleanupContext()
+	defer cancel()
+	if _, uerr := s.Unlock(unlockCtx, workspaceID); uerr != nil {
+		if err != nil {
+			return fmt.Errorf("%w (the workspace is left locked: %v)", err, uerr)
+		}
+		return fmt.Errorf("unable to unlock the workspace: %w", uerr)
+	}
+	return err
+}
@@ -0,0 +1,133 @@
+package scalr
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspacesMigrateToRemote(t *testing.T) {
+	var requests []string
+	var uploaded, run string
+	var onUpload func()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/iacp/v3/workspaces/ws-local", "GET /api/iacp/v3/workspaces/ws-empty":
+			fmt.Fprint(w, `{"data":{"id":"ws-local","type":"workspaces","attributes":{"execution-mode":"local"}}}`)
+		case "GET /api/iacp/v3/workspaces/ws-remote":
+			fmt.Fprint(w, `{"data":{"id":"ws-remote","type":"workspaces","attributes":{"execution-mode":"remote"}}}`)
+		case "POST /api/iacp/v3/workspaces/ws-local/actions/lock", "POST /api/iacp/v3/workspaces/ws-empty/actions/lock",
+			"POST /api/iacp/v3/workspaces/ws-local/actions/unlock", "POST /api/iacp/v3/workspaces/ws-empty/actions/unlock":
+			fmt.Fprint(w, `{"data":{"id":"ws-local","type":"workspaces","attributes":{"execution-mode":"local"}}}`)
+		case "GET /api/iacp/v3/workspaces/ws-local/current-state-version":
+			fmt.Fprint(w, `{"data":{"id":"sv-1","type":"state-versions","attributes":{"serial":4}}}`)
+		case "POST /api/iacp/v3/state-versions":
+			body, _ := io.ReadAll(r.Body)
+			uploaded = string(body)
+			if onUpload != nil {
+				onUpload()
+			}
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"data":{"id":"sv-2","type":"state-versions","attributes":{"serial":5}}}`)
+		case "PATCH /api/iacp/v3/workspaces/ws-local", "PATCH /api/iacp/v3/workspaces/ws-empty":
+			fmt.Fprint(w, `{"data":{"id":"ws-local","type":"workspaces","attributes":{"execution-mode":"remote"}}}`)
+		case "POST /api/iacp/v3/runs":
+			body, _ := io.ReadAll(r.Body)
+			run = string(body)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"data":{"id":"run-1","type":"runs","attributes":{"status":"pending","is-dry":true}}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("with existing state", func(t *testing.T) {
+		requests = nil
+		report, err := client.Workspaces.MigrateToRemote(ctx, "ws-local", WorkspaceMigrationOptions{
+			ConfigurationVersion: &ConfigurationVersion{ID: "cv-1"},
+		})
+		require.NoError(t, err)
+		require.Len(t, report.Steps, 3)
+		assert.Equal(t, "found state version sv-1 with serial 4", report.Steps[0].Message)
+		assert.True(t, report.Steps[0].Skipped)
+		assert.Equal(t, "switched from the local to the remote execution mode", report.Steps[1].Message)
+		assert.Equal(t, "queued the verification plan run-1", report.Steps[2].Message)
+		assert.Equal(t, WorkspaceExecutionModeRemote, report.Workspace.ExecutionMode)
+		assert.True(t, report.Run.IsDry)
+		assert.Contains(t, run, `"is-dry":true`)
+		assert.Equal(t, []string{
+			"GET /api/iacp/v3/workspaces/ws-local",
+			"POST /api/iacp/v3/workspaces/ws-local/actions/lock",
+			"GET /api/iacp/v3/workspaces/ws-local/current-state-version",
+			"PATCH /api/iacp/v3/workspaces/ws-local",
+			"POST /api/iacp/v3/workspaces/ws-local/actions/unlock",
+			"POST /api/iacp/v3/runs",
+		}, requests)
+	})
+
+	t.Run("with uploaded state", func(t *testing.T) {
+		state := []byte(`{"version":4,"serial":5,"lineage":"abc"}`)
+		report, err := client.Workspaces.MigrateToRemote(ctx, "ws-empty", WorkspaceMigrationOptions{State: state})
+		require.NoError(t, err)
+		require.Len(t, report.Steps, 3)
+		assert.Equal(t, "uploaded state version sv-2 with serial 5", report.Steps[0].Message)
+		assert.True(t, report.Steps[2].Skipped)
+		assert.Nil(t, report.Run)
+		assert.Contains(t, uploaded, `"serial":5`)
+		assert.Contains(t, uploaded, base64.StdEncoding.EncodeToString(state))
+	})
+
+	t.Run("without state", func(t *testing.T) {
+		requests = nil
+		report, err := client.Workspaces.MigrateToRemote(ctx, "ws-empty", WorkspaceMigrationOptions{})
+		assert.EqualError(t, err, "workspace ws-empty has no state, push the local state or pass it in the State option")
+		assert.Empty(t, report.Steps)
+		assert.Contains(t, requests, "POST /api/iacp/v3/workspaces/ws-empty/actions/unlock")
+	})
+
+	t.Run("with canceled context", func(t *testing.T) {
+		requests = nil
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		onUpload = cancel
+		defer func() { onUpload = nil }()
+
+		state := []byte(`{"version":4,"serial":5,"lineage":"abc"}`)
+		_, err := client.Workspaces.MigrateToRemote(ctx, "ws-empty", WorkspaceMigrationOptions{State: state})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, "POST /api/iacp/v3/workspaces/ws-empty/actions/unlock", requests[len(requests)-1])
+		assert.NotContains(t, requests, "PATCH /api/iacp/v3/workspaces/ws-empty")
+	})
+
+	t.Run("with state of a remote workspace", func(t *testing.T) {
+		requests = nil
+		state := []byte(`{"version":4,"serial":5,"lineage":"abc"}`)
+		_, err := client.Workspaces.MigrateToRemote(ctx, "ws-remote", WorkspaceMigrationOptions{State: state})
+		assert.EqualError(t, err, "workspace ws-remote already uses the remote execution mode, its state is not replaced")
+		assert.Equal(t, []string{"GET /api/iacp/v3/workspaces/ws-remote"}, requests)
+	})
+
+	t.Run("with invalid state", func(t *testing.T) {
+		_, err := client.Workspaces.MigrateToRemote(ctx, "ws-empty", WorkspaceMigrationOptions{State: []byte(`{}`)})
+		assert.EqualError(t, err, "invalid terraform state: serial is missing")
+	})
+
+	t.Run("with invalid workspace ID", func(t *testing.T) {
+		_, err := client.Workspaces.MigrateToRemote(ctx, badIdentifier, WorkspaceMigrationOptions{})
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+}
@@ -2,6 +2,7 @@ package scalr
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 )
@@ -12,9 +13,20 @@ var _ WorkspaceTags = (*workspaceTag)(nil)
 // WorkspaceTags describes all the workspace tags related methods that the
 // Scalr API supports.
 type WorkspaceTags interface {
+	// Add attaches the tags to the workspace, keeping the ones it already has.
 	Add(ctx context.Context, wsID string, tags []*TagRelation) error
+	// Replace sets the tags of the workspace, an empty list removes all of them.
 	Replace(ctx context.Context, wsID string, tags []*TagRelation) error
+	// Delete detaches the tags from the workspace.
 	Delete(ctx context.Context, wsID string, tags []*TagRelation) error
+	// BulkAdd attaches the tags to all the workspaces matching the list options.
+	BulkAdd(ctx context.Context, options WorkspaceListOptions, tagIDs []string, batch BatchOptions) ([]*WorkspaceTagsResult, error)
+}
+
+// WorkspaceTagsResult represents the outcome of tagging a single workspace.
+type WorkspaceTagsResult struct {
+	Workspace *Workspace
+	Err       error
 }
 
 // workspaceTag implements WorkspaceTags.
@@ -22,8 +34,15 @@ type workspaceTag struct {
 	client *Client
 }
 
-// Add tags to the workspace
+// Add tags to the workspace.
 func (s *workspaceTag) Add(ctx context.Context, wsID string, trs []*TagRelation) error {
+	if !validStringID(&wsID) {
+		return errors.New("invalid value for workspace ID")
+	}
+	if err := validTagRelations(trs, false); err != nil {
+		return err
+	}
+
 	u := fmt.Sprintf("workspaces/%s/relationships/tags", url.QueryEscape(wsID))
 	req, err := s.client.newRequest("POST", u, trs)
 	if err != nil {
@@ -33,8 +52,15 @@ func (s *workspaceTag) Add(ctx context.Context, wsID string, trs []*TagRelation)
 	return s.client.do(ctx, req, nil)
 }
 
-// Replace workspace's tags
+// Replace workspace's tags.
 func (s *workspaceTag) Replace(ctx context.Context, wsID string, trs []*TagRelation) error {
+	if !validStringID(&wsID) {
+		return errors.New("invalid value for workspace ID")
+	}
+	if err := validTagRelations(trs, true); err != nil {
+		return err
+	}
+
 	u := fmt.Sprintf("workspaces/%s/relationships/tags", url.QueryEscape(wsID))
 	req, err := s.client.newRequest("PATCH", u, trs)
 	if err != nil {
@@ -44,8 +70,15 @@ func (s *workspaceTag) Replace(ctx context.Context, wsID string, trs []*TagRelat
 	return s.client.do(ctx, req, nil)
 }
 
-// Delete workspace's tags
+// Delete workspace's tags.
 func (s *workspaceTag) Delete(ctx context.Context, wsID string, trs []*TagRelation) error {
+	if !validStringID(&wsID) {
+		return errors.New("invalid value for workspace ID")
+	}
+	if err := validTagRelations(trs, false); err != nil {
+		return err
+	}
+
 	u := fmt.Sprintf("workspaces/%s/relationships/tags", url.QueryEscape(wsID))
 	req, err := s.client.newRequest("DELETE", u, trs)
 	if err != nil {
@@ -54,3 +87,46 @@ func (s *workspaceTag) Delete(ctx context.Context, wsID string, trs []*TagRelati
 
 	return s.client.do(ctx, req, nil)
 }
+
+// BulkAdd adds the tags to all the workspaces matching the list options. The
+// workspaces are updated concurrently and a result is returned for each of them,
+// in the listing order. The error is only returned if the workspaces can not be listed,
+// or along with the results if the context is done before all the workspaces are updated.
+func (s *workspaceTag) BulkAdd(
+	ctx context.Context, options WorkspaceListOptions, tagIDs []string, batch BatchOptions,
+) ([]*WorkspaceTagsResult, error) {
+	if err := batch.valid(); err != nil {
+		return nil, err
+	}
+	if len(tagIDs) == 0 {
+		return nil, errors.New("at least one tag is required")
+	}
+	trs := make([]*TagRelation, 0, len(tagIDs))
+	for _, id := range tagIDs {
+		if !validStringID(&id) {
+			return nil, fmt.Errorf("invalid value for tag ID: '%s'", id)
+		}
+		trs = append(trs, &TagRelation{ID: id})
+	}
+
+	var results []*WorkspaceTagsResult
+	for {
+		wl, err := s.client.Workspaces.List(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, ws := range wl.Items {
+			results = append(results, &WorkspaceTagsResult{Workspace: ws})
+		}
+		if wl.Pagination == nil || wl.NextPage == 0 {
+			break
+		}
+		options.PageNumber = wl.NextPage
+	}
+
+	err := runBatch(ctx, len(results), batch.concurrency(), func(i int) {
+		results[i].Err = s.Add(ctx, results[i].Workspace.ID, trs)
+	})
+
+	return results, err
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -162,3 +164,92 @@ func TestWorkspaceTagsDelete(t *testing.T) {
 		assert.EqualError(t, err, fmt.Sprintf("Validation Error\n\nTag with ID '%s' not found or user unauthorized.", tagID))
 	})
 }
+
+func TestWorkspaceTagsBulkAdd(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	env, deleteEnv := createEnvironment(t, client)
+	defer deleteEnv()
+
+	ws1, deleteWs1 := createWorkspace(t, client, env)
+	defer deleteWs1()
+	ws2, deleteWs2 := createWorkspace(t, client, env)
+	defer deleteWs2()
+
+	tag, deleteTag := createTag(t, client)
+	defer deleteTag()
+
+	t.Run("with valid options", func(t *testing.T) {
+		results, err := client.WorkspaceTags.BulkAdd(ctx, WorkspaceListOptions{
+			Filter: &WorkspaceFilter{Environment: &env.ID},
+		}, []string{tag.ID}, BatchOptions{Concurrency: 1})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+
+		for _, r := range results {
+			assert.NoError(t, r.Err)
+			assert.Contains(t, []string{ws1.ID, ws2.ID}, r.Workspace.ID)
+
+			refreshed, err := client.Workspaces.ReadByID(ctx, r.Workspace.ID)
+			require.NoError(t, err)
+			require.Len(t, refreshed.Tags, 1)
+			assert.Equal(t, tag.ID, refreshed.Tags[0].ID)
+		}
+	})
+
+	t.Run("without tags", func(t *testing.T) {
+		results, err := client.WorkspaceTags.BulkAdd(ctx, WorkspaceListOptions{}, nil, BatchOptions{})
+		assert.Nil(t, results)
+		assert.EqualError(t, err, "at least one tag is required")
+	})
+
+	t.Run("with invalid tag ID", func(t *testing.T) {
+		results, err := client.WorkspaceTags.BulkAdd(ctx, WorkspaceListOptions{}, []string{badIdentifier}, BatchOptions{})
+		assert.Nil(t, results)
+		assert.EqualError(t, err, fmt.Sprintf("invalid value for tag ID: '%s'", badIdentifier))
+	})
+}
+
+func TestTagRelationsValidation(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("with valid tags", func(t *testing.T) {
+		requests = nil
+		trs := TagRelations("tag-1", "tag-2")
+		require.NoError(t, client.WorkspaceTags.Add(ctx, "ws-1", trs))
+		require.NoError(t, client.WorkspaceTags.Replace(ctx, "ws-1", []*TagRelation{}))
+		require.NoError(t, client.EnvironmentTags.Delete(ctx, "env-1", trs))
+		assert.Equal(t, []string{
+			"POST /api/iacp/v3/workspaces/ws-1/relationships/tags",
+			"PATCH /api/iacp/v3/workspaces/ws-1/relationships/tags",
+			"DELETE /api/iacp/v3/environments/env-1/relationships/tags",
+		}, requests)
+	})
+
+	t.Run("without tags", func(t *testing.T) {
+		assert.EqualError(t, client.WorkspaceTags.Add(ctx, "ws-1", nil), "at least one tag is required")
+		assert.EqualError(t, client.EnvironmentTags.Delete(ctx, "env-1", nil), "at least one tag is required")
+	})
+
+	t.Run("with invalid tag ID", func(t *testing.T) {
+		err := client.EnvironmentTags.Replace(ctx, "env-1", TagRelations(badIdentifier))
+		assert.EqualError(t, err, "invalid value for tag ID")
+	})
+
+	t.Run("with invalid resource ID", func(t *testing.T) {
+		err := client.WorkspaceTags.Add(ctx, badIdentifier, TagRelations("tag-1"))
+		assert.EqualError(t, err, "invalid value for workspace ID")
+		err = client.EnvironmentTags.Add(ctx, badIdentifier, TagRelations("tag-1"))
+		assert.EqualError(t, err, "invalid value for environment ID")
+	})
+}
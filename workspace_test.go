@@ -2,7 +2,12 @@ package scalr
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -174,6 +179,34 @@ func TestWorkspacesCreate(t *testing.T) {
 		assert.EqualError(t, err, "invalid value for name")
 	})
 
+	t.Run("when options has an invalid tag regex", func(t *testing.T) {
+		w, err := client.Workspaces.Create(ctx, WorkspaceCreateOptions{
+			Name:        String("foo"),
+			Environment: envTest,
+			VCSRepo: &WorkspaceVCSRepoOptions{
+				Identifier:  String("foo/bar"),
+				TriggerTags: Bool(true),
+				TagRegex:    String("v[0-9"),
+			},
+		})
+		assert.Nil(t, w)
+		assert.EqualError(t, err, "invalid value for tag regex: error parsing regexp: missing closing ]: `[0-9`")
+	})
+
+	t.Run("when options has an invalid comment command prefix", func(t *testing.T) {
+		w, err := client.Workspaces.Create(ctx, WorkspaceCreateOptions{
+			Name:        String("foo"),
+			Environment: envTest,
+			VCSRepo: &WorkspaceVCSRepoOptions{
+				Identifier:             String("foo/bar"),
+				CommentCommandsEnabled: Bool(true),
+				CommentCommandPrefix:   String("/scalr run"),
+			},
+		})
+		assert.Nil(t, w)
+		assert.EqualError(t, err, "invalid value for comment command prefix")
+	})
+
 	t.Run("when options has an invalid environment", func(t *testing.T) {
 		_, err := client.Workspaces.Create(ctx, WorkspaceCreateOptions{
 			Name:        String("foo"),
@@ -342,6 +375,30 @@ func TestWorkspacesUpdate(t *testing.T) {
 		assert.Nil(t, wsAfter.AgentPool)
 	})
 
+	t.Run("when disabling VCS triggers", func(t *testing.T) {
+		wsAfter, err := client.Workspaces.Update(ctx, wsTest.ID, WorkspaceUpdateOptions{
+			VCSTriggersDisabled: Bool(true),
+		})
+		require.NoError(t, err)
+		assert.True(t, wsAfter.VCSTriggersDisabled)
+
+		wl, err := client.Workspaces.List(ctx, WorkspaceListOptions{
+			Filter: &WorkspaceFilter{
+				Environment:         &envTest.ID,
+				VCSTriggersDisabled: Bool(true),
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, wl.Items, 1)
+		assert.Equal(t, wsTest.ID, wl.Items[0].ID)
+
+		wsAfter, err = client.Workspaces.Update(ctx, wsTest.ID, WorkspaceUpdateOptions{
+			VCSTriggersDisabled: Bool(false),
+		})
+		require.NoError(t, err)
+		assert.False(t, wsAfter.VCSTriggersDisabled)
+	})
+
 	t.Run("with valid options", func(t *testing.T) {
 		options := WorkspaceUpdateOptions{
 			Name:                      String(randomString(t)),
@@ -493,6 +550,40 @@ func TestWorkspacesDelete(t *testing.T) {
 	})
 }
 
+func TestWorkspacesDeleteWithOptions(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	envTest, envTestCleanup := createEnvironment(t, client)
+	defer envTestCleanup()
+
+	wsUpstream, _ := createWorkspace(t, client, envTest)
+	wsDownstream, wsDownstreamCleanup := createWorkspace(t, client, envTest)
+	defer wsDownstreamCleanup()
+
+	trigger, err := client.RunTriggers.Create(ctx, RunTriggerCreateOptions{
+		Upstream:   &Upstream{ID: wsUpstream.ID},
+		Downstream: &Downstream{ID: wsDownstream.ID},
+	})
+	require.NoError(t, err)
+
+	t.Run("with detached dependencies", func(t *testing.T) {
+		err := client.Workspaces.DeleteWithOptions(ctx, wsUpstream.ID, WorkspaceDeleteOptions{DetachDependencies: true})
+		require.NoError(t, err)
+
+		_, err = client.RunTriggers.Read(ctx, trigger.ID)
+		assert.ErrorIs(t, err, ErrResourceNotFound)
+
+		_, err = client.Workspaces.ReadByID(ctx, wsUpstream.ID)
+		assert.ErrorIs(t, err, ErrResourceNotFound)
+	})
+
+	t.Run("without a valid workspace ID", func(t *testing.T) {
+		err := client.Workspaces.DeleteWithOptions(ctx, badIdentifier, WorkspaceDeleteOptions{})
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+}
+
 func TestWorkspacesSetSchedule(t *testing.T) {
 	client := testClient(t)
 	ctx := context.Background()
@@ -526,6 +617,32 @@ func TestWorkspacesSetSchedule(t *testing.T) {
 		}
 	})
 
+	t.Run("when a schedule is omitted", func(t *testing.T) {
+		applySchedule := "15 4 5 3-5 2"
+		w, err := client.Workspaces.SetSchedule(ctx, wTest.ID, WorkspaceRunScheduleOptions{
+			ApplySchedule: &applySchedule,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, applySchedule, w.ApplySchedule)
+		assert.Equal(t, "31 5 5 3-5 2", w.DestroySchedule)
+	})
+
+	t.Run("when a schedule is emptied", func(t *testing.T) {
+		w, err := client.Workspaces.SetSchedule(ctx, wTest.ID, WorkspaceRunScheduleOptions{
+			DestroySchedule: String(""),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "15 4 5 3-5 2", w.ApplySchedule)
+		assert.Empty(t, w.DestroySchedule)
+	})
+
+	t.Run("when the schedules are cleared", func(t *testing.T) {
+		w, err := client.Workspaces.ClearSchedules(ctx, wTest.ID)
+		require.NoError(t, err)
+		assert.Empty(t, w.ApplySchedule)
+		assert.Empty(t, w.DestroySchedule)
+	})
+
 	t.Run("when an error is returned from the api", func(t *testing.T) {
 		applySchedule := "bla-bla-bla"
 		w, err := client.Workspaces.SetSchedule(ctx, wTest.ID, WorkspaceRunScheduleOptions{
@@ -541,3 +658,413 @@ func TestWorkspacesSetSchedule(t *testing.T) {
 		assert.EqualError(t, err, "invalid value for workspace ID")
 	})
 }
+
+func TestWorkspaceRunScheduleOptionsMarshalJSON(t *testing.T) {
+	b, err := json.Marshal(WorkspaceRunScheduleOptions{
+		ApplySchedule:   String("30 3 5 3-5 2"),
+		DestroySchedule: String(""),
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"apply-schedule": "30 3 5 3-5 2", "destroy-schedule": null}`, string(b))
+
+	b, err = json.Marshal(WorkspaceRunScheduleOptions{DestroySchedule: String("31 5 5 3-5 2")})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"destroy-schedule": "31 5 5 3-5 2"}`, string(b))
+
+	b, err = json.Marshal(WorkspaceRunScheduleOptions{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(b))
+}
+
+func TestWorkspacesSetScheduleStates(t *testing.T) {
+	var body string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/iacp/v3/workspaces/ws-123/actions/set-schedule", r.URL.Path)
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		body = string(b)
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		fmt.Fprint(w, `{"data":{"id":"ws-123","type":"workspaces"}}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("set", func(t *testing.T) {
+		_, err := client.Workspaces.SetSchedule(ctx, "ws-123", WorkspaceRunScheduleOptions{
+			ApplySchedule:   String("30 3 5 3-5 2"),
+			DestroySchedule: String("31 5 5 3-5 2"),
+		})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"apply-schedule": "30 3 5 3-5 2", "destroy-schedule": "31 5 5 3-5 2"}`, body)
+	})
+
+	t.Run("leave", func(t *testing.T) {
+		_, err := client.Workspaces.SetSchedule(ctx, "ws-123", WorkspaceRunScheduleOptions{
+			ApplySchedule: String("30 3 5 3-5 2"),
+		})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"apply-schedule": "30 3 5 3-5 2"}`, body)
+	})
+
+	t.Run("clear", func(t *testing.T) {
+		_, err := client.Workspaces.ClearSchedules(ctx, "ws-123")
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"apply-schedule": null, "destroy-schedule": null}`, body)
+	})
+}
+
+func TestWorkspacesRunnerEnv(t *testing.T) {
+	client, err := NewClient(&Config{Address: "https://example.scalr.io", Token: "dummy-token"})
+	require.NoError(t, err)
+
+	t.Run("with relations", func(t *testing.T) {
+		ws := &Workspace{
+			ID:   "ws-123",
+			Name: "network",
+			Environment: &Environment{
+				ID:      "env-123",
+				Name:    "production",
+				Account: &Account{ID: "acc-123"},
+			},
+		}
+		assert.Equal(t, map[string]string{
+			"SCALR_HOSTNAME":         "example.scalr.io",
+			"SCALR_ACCOUNT_ID":       "acc-123",
+			"SCALR_ENVIRONMENT_ID":   "env-123",
+			"SCALR_ENVIRONMENT_NAME": "production",
+			"SCALR_WORKSPACE_ID":     "ws-123",
+			"SCALR_WORKSPACE_NAME":   "network",
+		}, client.Workspaces.RunnerEnv(ws))
+	})
+
+	t.Run("without relations", func(t *testing.T) {
+		ws := &Workspace{ID: "ws-123", Name: "network", Environment: &Environment{ID: "env-123"}}
+		assert.Equal(t, map[string]string{
+			"SCALR_HOSTNAME":       "example.scalr.io",
+			"SCALR_ENVIRONMENT_ID": "env-123",
+			"SCALR_WORKSPACE_ID":   "ws-123",
+			"SCALR_WORKSPACE_NAME": "network",
+		}, client.Workspaces.RunnerEnv(ws))
+	})
+}
+
+func TestOutputVariableValue(t *testing.T) {
+	for _, c := range []struct {
+		value interface{}
+		want  string
+		hcl   bool
+	}{
+		{"vpc-123", "vpc-123", false},
+		{float64(3), "3", true},
+		{true, "true", true},
+		{[]interface{}{"a", "b"}, `["a","b"]`, true},
+		{map[string]interface{}{"id": "x"}, `{"id":"x"}`, true},
+	} {
+		got, hcl, err := outputVariableValue(c.value)
+		require.NoError(t, err)
+		assert.Equal(t, c.want, got)
+		assert.Equal(t, c.hcl, hcl)
+	}
+}
+
+func TestWorkspacesPromoteOutputs(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	wsTest, wsTestCleanup := createWorkspace(t, client, nil)
+	defer wsTestCleanup()
+
+	t.Run("when the source workspace has no state", func(t *testing.T) {
+		vars, err := client.Workspaces.PromoteOutputs(ctx, wsTest.ID, wsTest.ID, nil)
+		assert.Nil(t, vars)
+		assert.ErrorIs(t, err, ErrResourceNotFound)
+	})
+
+	t.Run("with invalid workspace IDs", func(t *testing.T) {
+		_, err := client.Workspaces.PromoteOutputs(ctx, badIdentifier, wsTest.ID, nil)
+		assert.EqualError(t, err, "invalid value for source workspace ID")
+
+		_, err = client.Workspaces.PromoteOutputs(ctx, wsTest.ID, badIdentifier, nil)
+		assert.EqualError(t, err, "invalid value for target workspace ID")
+	})
+}
+
+func TestWorkspacesPromoteOutputsSensitive(t *testing.T) {
+	var created []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/iacp/v3/workspaces/ws-from/current-state-version":
+			fmt.Fprint(w, `{"data":{"id":"sv-1","type":"state-versions","attributes":{"outputs":[`+
+				`{"name":"url","value":"https://example.com","sensitive":false},`+
+				`{"name":"password","value":null,"sensitive":true}]}}}`)
+		case "GET /api/iacp/v3/vars":
+			fmt.Fprint(w, `{"data":[]}`)
+		case "POST /api/iacp/v3/vars":
+			var body struct {
+				Data struct {
+					Attributes struct {
+						Key string `json:"key"`
+					} `json:"attributes"`
+				} `json:"data"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			created = append(created, body.Data.Attributes.Key)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"data":{"id":"var-1","type":"vars","attributes":{"key":%q}}}`, body.Data.Attributes.Key)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+
+	vars, err := client.Workspaces.PromoteOutputs(context.Background(), "ws-from", "ws-to", nil)
+	require.NoError(t, err)
+	require.Len(t, vars, 1)
+	assert.Equal(t, "url", vars[0].Key)
+	assert.Equal(t, []string{"url"}, created)
+}
+
+func TestWorkspacesListByPrefix(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	envTest, envTestCleanup := createEnvironment(t, client)
+	defer envTestCleanup()
+
+	wsTest, wsTestCleanup := createWorkspace(t, client, envTest)
+	defer wsTestCleanup()
+
+	t.Run("with a matching prefix", func(t *testing.T) {
+		wl, err := client.Workspaces.ListByPrefix(ctx, envTest.ID, "tst-")
+		require.NoError(t, err)
+		require.Len(t, wl, 1)
+		assert.Equal(t, wsTest.ID, wl[0].ID)
+	})
+
+	t.Run("without matching workspaces", func(t *testing.T) {
+		wl, err := client.Workspaces.ListByPrefix(ctx, envTest.ID, "not-existing-")
+		require.NoError(t, err)
+		assert.Empty(t, wl)
+	})
+
+	t.Run("without a prefix", func(t *testing.T) {
+		_, err := client.Workspaces.ListByPrefix(ctx, envTest.ID, "")
+		assert.EqualError(t, err, "prefix is required")
+	})
+}
+
+func TestWorkspacesListByPrefixFilter(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/iacp/v3/workspaces", r.URL.Path)
+		assert.Equal(t, "like:app-", r.URL.Query().Get("filter[name]"))
+		assert.Equal(t, "env-123", r.URL.Query().Get("filter[environment]"))
+
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		fmt.Fprint(w, `{"data":[`+
+			`{"id":"ws-1","type":"workspaces","attributes":{"name":"app-"}},`+
+			`{"id":"ws-2","type":"workspaces","attributes":{"name":"app-dev"}},`+
+			`{"id":"ws-3","type":"workspaces","attributes":{"name":"my-app-dev"}}]}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+
+	wl, err := client.Workspaces.ListByPrefix(context.Background(), "env-123", "app-")
+	require.NoError(t, err)
+	require.Len(t, wl, 1)
+	assert.Equal(t, "ws-2", wl[0].ID)
+}
+
+func TestCLIWorkspaceName(t *testing.T) {
+	assert.Equal(t, "app-dev", PrefixedWorkspaceName("app-", "dev"))
+
+	name, ok := CLIWorkspaceName("app-", "app-dev")
+	assert.True(t, ok)
+	assert.Equal(t, "dev", name)
+
+	_, ok = CLIWorkspaceName("app-", "app-")
+	assert.False(t, ok)
+
+	_, ok = CLIWorkspaceName("app-", "web-dev")
+	assert.False(t, ok)
+}
+
+func TestWorkspacesCreateWithPhaseTimeouts(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var payload struct {
+			Data struct {
+				Attributes    map[string]interface{} `json:"attributes"`
+				Relationships map[string]interface{} `json:"relationships"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, float64(30), payload.Data.Attributes["plan-operation-timeout"])
+		assert.Equal(t, float64(120), payload.Data.Attributes["apply-operation-timeout"])
+		assert.Contains(t, payload.Data.Relationships, "ssh-key")
+
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"data":{"id":"ws-123","type":"workspaces","attributes":{"name":"foo",`+
+			`"plan-operation-timeout":30,"apply-operation-timeout":120},`+
+			`"relationships":{"ssh-key":{"data":{"id":"ssh-123","type":"ssh-keys"}}}}}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+
+	ws, err := client.Workspaces.Create(context.Background(), WorkspaceCreateOptions{
+		Name:                  String("foo"),
+		Environment:           &Environment{ID: "env-123"},
+		PlanOperationTimeout:  Int(30),
+		ApplyOperationTimeout: Int(120),
+		SSHKey:                &SSHKey{ID: "ssh-123"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 30, *ws.PlanOperationTimeout)
+	assert.Equal(t, 120, *ws.ApplyOperationTimeout)
+	require.NotNil(t, ws.SSHKey)
+	assert.Equal(t, "ssh-123", ws.SSHKey.ID)
+}
+
+func TestWorkspacesLock(t *testing.T) {
+	locked := false
+	var reason string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/iacp/v3/workspaces/ws-123/actions/lock":
+			if locked {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			var body struct {
+				Reason string `json:"reason"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			locked, reason = true, body.Reason
+		case "/api/iacp/v3/workspaces/ws-123/actions/unlock", "/api/iacp/v3/workspaces/ws-123/actions/force-unlock":
+			if !locked {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			locked = false
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		fmt.Fprintf(w, `{"data":{"id":"ws-123","type":"workspaces","attributes":{"locked":%t}}}`, locked)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("lock", func(t *testing.T) {
+		ws, err := client.Workspaces.Lock(ctx, "ws-123", "maintenance")
+		require.NoError(t, err)
+		assert.True(t, ws.Locked)
+		assert.Equal(t, "maintenance", reason)
+	})
+
+	t.Run("lock when locked", func(t *testing.T) {
+		ws, err := client.Workspaces.Lock(ctx, "ws-123", "")
+		assert.Nil(t, ws)
+		assert.ErrorIs(t, err, ErrWorkspaceLocked)
+	})
+
+	t.Run("unlock", func(t *testing.T) {
+		ws, err := client.Workspaces.Unlock(ctx, "ws-123")
+		require.NoError(t, err)
+		assert.False(t, ws.Locked)
+	})
+
+	t.Run("unlock when unlocked", func(t *testing.T) {
+		ws, err := client.Workspaces.Unlock(ctx, "ws-123")
+		assert.Nil(t, ws)
+		assert.ErrorIs(t, err, ErrWorkspaceNotLocked)
+	})
+
+	t.Run("force unlock when unlocked", func(t *testing.T) {
+		ws, err := client.Workspaces.ForceUnlock(ctx, "ws-123")
+		assert.Nil(t, ws)
+		assert.ErrorIs(t, err, ErrWorkspaceNotLocked)
+	})
+
+	t.Run("with invalid workspace ID", func(t *testing.T) {
+		ws, err := client.Workspaces.Lock(ctx, badIdentifier, "")
+		assert.Nil(t, ws)
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+}
+
+func TestWorkspacesListCurrentRunFilter(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "in:planned,policy_override", r.URL.Query().Get("filter[current-run][status]"))
+		assert.Equal(t, "current-run", r.URL.Query().Get("include"))
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		fmt.Fprint(w, `{"data":[{"id":"ws-1","type":"workspaces","attributes":{"name":"app"},`+
+			`"relationships":{"current-run":{"data":{"id":"run-1","type":"runs"}}}}],`+
+			`"included":[{"id":"run-1","type":"runs","attributes":{"status":"planned"}}]}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+
+	wl, err := client.Workspaces.List(context.Background(), WorkspaceListOptions{
+		Include: "current-run",
+		Filter: &WorkspaceFilter{
+			CurrentRun: &WorkspaceCurrentRunFilter{Status: RunStatusesFilter(RunPlanned, RunPolicyOverride)},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, wl.Items, 1)
+	assert.Equal(t, RunPlanned, wl.Items[0].CurrentRun.Status)
+}
+
+func TestWorkspacesHooksValidation(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("when a hook is too long", func(t *testing.T) {
+		w, err := client.Workspaces.Create(ctx, WorkspaceCreateOptions{
+			Name:  String("foo"),
+			Hooks: &HooksOptions{PrePlan: String(strings.Repeat("x", MaxHookCommandLength+1))},
+		})
+		assert.Nil(t, w)
+		assert.EqualError(t, err, "invalid value for pre-plan hook: command is 1025 characters long, the maximum is 1024")
+	})
+
+	t.Run("when a hook has a control character", func(t *testing.T) {
+		w, err := client.Workspaces.Update(ctx, "ws-123", WorkspaceUpdateOptions{
+			Hooks: &HooksOptions{PostApply: String("echo done\x00")},
+		})
+		assert.Nil(t, w)
+		assert.EqualError(t, err, "invalid value for post-apply hook: command contains the control character U+0000")
+	})
+
+	t.Run("multiline hooks are valid", func(t *testing.T) {
+		assert.NoError(t, (&HooksOptions{PreInit: String("set -e\n\tmake init\r\n")}).valid())
+		assert.NoError(t, (*HooksOptions)(nil).valid())
+	})
+}
@@ -0,0 +1,247 @@
+package scalr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// WorkspaceValidationCheck represents the result of a single preflight check.
+type WorkspaceValidationCheck struct {
+	Name    string
+	Passed  bool
+	Message string
+}
+
+// WorkspaceValidationReport represents the results of the workspace creation preflight.
+type WorkspaceValidationReport struct {
+	Checks []*WorkspaceValidationCheck
+}
+
+// Valid reports whether all the checks passed.
+func (r *WorkspaceValidationReport) Valid() bool {
+	for _, c := range r.Checks {
+		if !c.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// Failed returns the checks that did not pass.
+func (r *WorkspaceValidationReport) Failed() []*WorkspaceValidationCheck {
+	var failed []*WorkspaceValidationCheck
+	for _, c := range r.Checks {
+		if !c.Passed {
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
+
+// Err returns an error listing the failed checks, or nil if all the checks passed.
+func (r *WorkspaceValidationReport) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	messages := make([]string, len(failed))
+	for i, c := range failed {
+		messages[i] = fmt.Sprintf("%s: %s", c.Name, c.Message)
+	}
+	return errors.New(strings.Join(messages, "; "))
+}
+
+func (r *WorkspaceValidationReport) add(name string, err error) {
+	c := &WorkspaceValidationCheck{Name: name, Passed: err == nil}
+	if err != nil {
+		c.Message = err.Error()
+	}
+	r.Checks = append(r.Checks, c)
+}
+
+var (
+	terraformVersionRe = regexp.MustCompile(`^\d+\.\d+\.\d+(-[0-9A-Za-z.]+)?$`)
+	vcsIdentifierRe    = regexp.MustCompile(`^[^/\s]+(/[^/\s]+)+$`)
+)
+
+// ValidateCreate checks the options without creating the workspace and reports
+// every problem found rather than the first one. The API has no dry-run create,
+// so the checks are done by the client: the name format and availability, the
+// environment, the Terraform version format, the VCS repository identifier format
+// and the availability of the VCS provider in the environment. Neither the
+// existence of the Terraform version nor the access to the VCS repository is
+// checked, the API does not expose them. An error is returned only if the checks
+// could not be completed, e.g. the context is done or a request failed.
+func (s *workspaces) ValidateCreate(ctx context.Context, options WorkspaceCreateOptions) (*WorkspaceValidationReport, error) {
+	report := &WorkspaceValidationReport{}
+
+	var nameErr error
+	switch {
+	case !validString(options.Name):
+		nameErr = errors.New("name is required")
+	case !validStringID(options.Name):
+		nameErr = errors.New("invalid value for name")
+	}
+	report.add("name", nameErr)
+
+	var envErr error
+	switch {
+	case options.Environment == nil:
+		envErr = errors.New("environment is required")
+	case !validStringID(&options.Environment.ID):
+		envErr = errors.New("invalid value for environment ID")
+	default:
+		_, err := s.client.Environments.Read(ctx, options.Environment.ID)
+		if errors.Is(err, ErrResourceNotFound) {
+			envErr = fmt.Errorf("environment %s not found", options.Environment.ID)
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	report.add("environment", envErr)
+
+	if nameErr == nil && envErr == nil {
+		wl, err := s.List(ctx, WorkspaceListOptions{
+			Filter: &WorkspaceFilter{Environment: &options.Environment.ID, Name: options.Name},
+		})
+		if err != nil {
+			return nil, err
+		}
+		var takenErr error
+		if len(wl.Items) > 0 {
+			takenErr = fmt.Errorf("workspace %s already exists in environment %s", *options.Name, options.Environment.ID)
+		}
+		report.add("name-available", takenErr)
+	}
+
+	if options.TerraformVersion != nil {
+		var versionErr error
+		if !terraformVersionRe.MatchString(*options.TerraformVersion) {
+			versionErr = fmt.Errorf("invalid terraform version %q", *options.TerraformVersion)
+		}
+		report.add("terraform-version-format", versionErr)
+
+		if versionErr == nil && options.ModuleVersion != nil {
+			constraint, err := s.moduleTerraformConstraint(ctx, options.ModuleVersion)
+			if err != nil {
+				return nil, err
+			}
+			report.add("module-terraform-version", checkTerraformVersion(*options.TerraformVersion, constraint))
+		}
+	}
+
+	if options.VCSRepo != nil {
+		repoErr := options.VCSRepo.valid()
+		if repoErr == nil && (options.VCSRepo.Identifier == nil || !vcsIdentifierRe.MatchString(*options.VCSRepo.Identifier)) {
+			repoErr = errors.New("vcs repo identifier must be in the <org>/<repo> format")
+		}
+		report.add("vcs-repo-format", repoErr)
+
+		var providerErr error
+		switch {
+		case options.VcsProvider == nil:
+			providerErr = errors.New("vcs provider is required for the vcs repo")
+		case !validStringID(&options.VcsProvider.ID):
+			providerErr = errors.New("invalid value for vcs provider ID")
+		default:
+			vcs, err := s.client.VcsProviders.Read(ctx, options.VcsProvider.ID)
+			if errors.Is(err, ErrResourceNotFound) {
+				providerErr = fmt.Errorf("vcs provider %s not found", options.VcsProvider.ID)
+			} else if err != nil {
+				return nil, err
+			} else if options.Environment != nil && !vcsProviderAvailable(vcs, options.Environment.ID) {
+				providerErr = fmt.Errorf("vcs provider %s is not available in environment %s", vcs.ID, options.Environment.ID)
+			}
+		}
+		report.add("vcs-provider", providerErr)
+	}
+
+	return report, nil
+}
+
+// vcsProviderAvailable reports whether the VCS provider can be used in the environment.
+func vcsProviderAvailable(vcs *VcsProvider, environmentID string) bool {
+	if vcs.IsShared || len(vcs.Environments) == 0 {
+		return true
+	}
+	for _, env := range vcs.Environments {
+		if env.ID == environmentID {
+			return true
+		}
+	}
+	return false
+}
+
+// SelectTerraformVersion reads the Terraform version constraint required by the
+// module version of the options, so a workspace created from it does not fail on
+// its first run. The Terraform version of the options is returned if it satisfies
+// the constraint, otherwise the latest release among the available versions that
+// satisfies it is selected. An empty version is returned if neither is set,
+// leaving the selection of the latest version to the API.
+func (s *workspaces) SelectTerraformVersion(
+	ctx context.Context, options WorkspaceCreateOptions, available []string,
+) (string, error) {
+	if options.ModuleVersion == nil {
+		return "", errors.New("module version is required")
+	}
+	constraint, err := s.moduleTerraformConstraint(ctx, options.ModuleVersion)
+	if err != nil {
+		return "", err
+	}
+
+	if options.TerraformVersion != nil {
+		if err := checkTerraformVersion(*options.TerraformVersion, constraint); err != nil {
+			return "", err
+		}
+		return *options.TerraformVersion, nil
+	}
+	if constraint == "" {
+		return "", nil
+	}
+
+	var selected string
+	var latest version
+	for _, v := range available {
+		parsed, _, err := parseVersion(v)
+		if err != nil || parsed.prerelease != "" {
+			continue
+		}
+		if ok, err := versionSatisfies(v, constraint); err != nil {
+			return "", err
+		} else if ok && (selected == "" || parsed.compare(latest) > 0) {
+			selected, latest = v, parsed
+		}
+	}
+	if selected == "" {
+		return "", fmt.Errorf("no available terraform version satisfies the module constraint %q", constraint)
+	}
+	return selected, nil
+}
+
+func (s *workspaces) moduleTerraformConstraint(ctx context.Context, mv *ModuleVersion) (string, error) {
+	if !validStringID(&mv.ID) {
+		return "", errors.New("invalid value for module version ID")
+	}
+	mv, err := s.client.ModuleVersions.Read(ctx, mv.ID)
+	if err != nil {
+		return "", err
+	}
+	return mv.RequiredTerraformVersion, nil
+}
+
+func checkTerraformVersion(v, constraint string) error {
+	if constraint == "" {
+		return nil
+	}
+	ok, err := versionSatisfies(v, constraint)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("terraform version %s does not satisfy the module constraint %q", v, constraint)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package scalr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspacesValidateCreate(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		switch r.URL.Path {
+		case "/api/iacp/v3/environments/env-123":
+			fmt.Fprint(w, `{"data":{"id":"env-123","type":"environments","attributes":{"name":"dev"}}}`)
+		case "/api/iacp/v3/workspaces":
+			if r.URL.Query().Get("filter[name]") == "taken" {
+				fmt.Fprint(w, `{"data":[{"id":"ws-1","type":"workspaces","attributes":{"name":"taken"}}]}`)
+				return
+			}
+			fmt.Fprint(w, `{"data":[]}`)
+		case "/api/iacp/v3/vcs-providers/vcs-123":
+			fmt.Fprint(w, `{"data":{"id":"vcs-123","type":"vcs-providers","attributes":{"is-shared":false},`+
+				`"relationships":{"environments":{"data":[{"id":"env-other","type":"environments"}]}}}}`)
+		case "/api/iacp/v3/vcs-providers/vcs-broken":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("with valid options", func(t *testing.T) {
+		report, err := client.Workspaces.ValidateCreate(ctx, WorkspaceCreateOptions{
+			Name:             String("app"),
+			Environment:      &Environment{ID: "env-123"},
+			TerraformVersion: String("1.5.7"),
+		})
+		require.NoError(t, err)
+		assert.True(t, report.Valid())
+		assert.NoError(t, report.Err())
+		assert.Len(t, report.Checks, 4)
+	})
+
+	t.Run("with invalid options", func(t *testing.T) {
+		report, err := client.Workspaces.ValidateCreate(ctx, WorkspaceCreateOptions{
+			Name:             String("taken"),
+			Environment:      &Environment{ID: "env-123"},
+			TerraformVersion: String("latest"),
+			VCSRepo:          &WorkspaceVCSRepoOptions{Identifier: String("org/repo")},
+			VcsProvider:      &VcsProvider{ID: "vcs-123"},
+		})
+		require.NoError(t, err)
+		assert.False(t, report.Valid())
+
+		failed := make(map[string]string)
+		for _, c := range report.Failed() {
+			failed[c.Name] = c.Message
+		}
+		assert.Equal(t, map[string]string{
+			"name-available":           "workspace taken already exists in environment env-123",
+			"terraform-version-format": `invalid terraform version "latest"`,
+			"vcs-provider":             "vcs provider vcs-123 is not available in environment env-123",
+		}, failed)
+	})
+
+	t.Run("when the vcs provider cannot be read", func(t *testing.T) {
+		report, err := client.Workspaces.ValidateCreate(ctx, WorkspaceCreateOptions{
+			Name:        String("app"),
+			Environment: &Environment{ID: "env-123"},
+			VCSRepo:     &WorkspaceVCSRepoOptions{Identifier: String("org/repo")},
+			VcsProvider: &VcsProvider{ID: "vcs-broken"},
+		})
+		assert.Nil(t, report)
+		assert.ErrorIs(t, err, ErrForbidden)
+	})
+
+	t.Run("with missing environment", func(t *testing.T) {
+		report, err := client.Workspaces.ValidateCreate(ctx, WorkspaceCreateOptions{
+			Name:        String("app"),
+			Environment: &Environment{ID: "env-missing"},
+		})
+		require.NoError(t, err)
+		assert.EqualError(t, report.Err(), "environment: environment env-missing not found")
+	})
+
+	t.Run("without name", func(t *testing.T) {
+		report, err := client.Workspaces.ValidateCreate(ctx, WorkspaceCreateOptions{})
+		require.NoError(t, err)
+		assert.EqualError(t, report.Err(), "name: name is required; environment: environment is required")
+	})
+}
+
+func TestWorkspacesSelectTerraformVersion(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		switch r.URL.Path {
+		case "/api/iacp/v3/environments/env-123":
+			fmt.Fprint(w, `{"data":{"id":"env-123","type":"environments","attributes":{"name":"dev"}}}`)
+		case "/api/iacp/v3/workspaces":
+			fmt.Fprint(w, `{"data":[]}`)
+		case "/api/iacp/v3/module-versions/modver-123":
+			fmt.Fprint(w, `{"data":{"id":"modver-123","type":"module-versions",`+
+				`"attributes":{"version":"1.0.0","required-terraform-version":"~> 1.3"}}}`)
+		case "/api/iacp/v3/module-versions/modver-any":
+			fmt.Fprint(w, `{"data":{"id":"modver-any","type":"module-versions","attributes":{"version":"1.0.0"}}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+	available := []string{"0.15.5", "1.3.9", "1.5.7", "1.6.0-beta1", "2.0.0"}
+
+	t.Run("auto-selects the latest satisfying version", func(t *testing.T) {
+		v, err := client.Workspaces.SelectTerraformVersion(ctx, WorkspaceCreateOptions{
+			ModuleVersion: &ModuleVersion{ID: "modver-123"},
+		}, available)
+		require.NoError(t, err)
+		assert.Equal(t, "1.5.7", v)
+	})
+
+	t.Run("keeps a satisfying version", func(t *testing.T) {
+		v, err := client.Workspaces.SelectTerraformVersion(ctx, WorkspaceCreateOptions{
+			ModuleVersion:    &ModuleVersion{ID: "modver-123"},
+			TerraformVersion: String("1.3.9"),
+		}, available)
+		require.NoError(t, err)
+		assert.Equal(t, "1.3.9", v)
+	})
+
+	t.Run("rejects a version not satisfying the constraint", func(t *testing.T) {
+		_, err := client.Workspaces.SelectTerraformVersion(ctx, WorkspaceCreateOptions{
+			ModuleVersion:    &ModuleVersion{ID: "modver-123"},
+			TerraformVersion: String("2.0.0"),
+		}, available)
+		assert.EqualError(t, err, `terraform version 2.0.0 does not satisfy the module constraint "~> 1.3"`)
+
+		report, err := client.Workspaces.ValidateCreate(ctx, WorkspaceCreateOptions{
+			Name:             String("app"),
+			Environment:      &Environment{ID: "env-123"},
+			ModuleVersion:    &ModuleVersion{ID: "modver-123"},
+			TerraformVersion: String("2.0.0"),
+		})
+		require.NoError(t, err)
+		assert.EqualError(t, report.Err(),
+			`module-terraform-version: terraform version 2.0.0 does not satisfy the module constraint "~> 1.3"`)
+	})
+
+	t.Run("without available versions", func(t *testing.T) {
+		_, err := client.Workspaces.SelectTerraformVersion(ctx, WorkspaceCreateOptions{
+			ModuleVersion: &ModuleVersion{ID: "modver-123"},
+		}, []string{"1.0.0"})
+		assert.EqualError(t, err, `no available terraform version satisfies the module constraint "~> 1.3"`)
+	})
+
+	t.Run("without constraint", func(t *testing.T) {
+		v, err := client.Workspaces.SelectTerraformVersion(ctx, WorkspaceCreateOptions{
+			ModuleVersion: &ModuleVersion{ID: "modver-any"},
+		}, available)
+		require.NoError(t, err)
+		assert.Equal(t, "", v)
+	})
+
+	t.Run("without module version", func(t *testing.T) {
+		_, err := client.Workspaces.SelectTerraformVersion(ctx, WorkspaceCreateOptions{}, available)
+		assert.EqualError(t, err, "module version is required")
+	})
+
+	t.Run("constraints", func(t *testing.T) {
+		for _, c := range []struct {
+			version    string
+			constraint string
+			ok         bool
+		}{
+			{"1.5.7", ">= 1.3, < 2.0", true},
+			{"2.0.0", ">= 1.3, < 2.0", false},
+			{"1.5.7", "~> 1.5.0", true},
+			{"1.6.0", "~> 1.5.0", false},
+			{"1.9.0", "~> 1", true},
+			{"1.6.0-beta1", ">= 1.6.0", false},
+			{"1.6.0-beta10", "> 1.6.0-beta2", true},
+			{"1.6.0-beta2", "> 1.6.0-beta10", false},
+			{"1.6.0-rc1", "> 1.6.0-beta10", true},
+			{"1.6.0-rc1.10", "> 1.6.0-rc1.9", true},
+			{"1.5.7", "!= 1.5.7", false},
+			{"1.5.7", "1.5.7", true},
+		} {
+			ok, err := versionSatisfies(c.version, c.constraint)
+			require.NoError(t, err)
+			assert.Equal(t, c.ok, ok, "%s %s", c.version, c.constraint)
+		}
+
+		_, err := versionSatisfies("1.5.7", "latest")
+		assert.EqualError(t, err, `invalid version constraint "latest"`)
+	})
+}